@@ -68,7 +68,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal"
 	"github.com/upbound/xgql/internal/auth"
@@ -206,7 +209,10 @@ func main() { //nolint:gocyclo
 	kingpin.FatalIfError(corev1.AddToScheme(s), "cannot add Kubernetes core/v1 to scheme")
 	kingpin.FatalIfError(kextv1.AddToScheme(s), "cannot add Kubernetes apiextensions/v1 to scheme")
 	kingpin.FatalIfError(pkgv1.AddToScheme(s), "cannot add Crossplane pkg/v1 to scheme")
+	kingpin.FatalIfError(pkgv1alpha1.AddToScheme(s), "cannot add Crossplane pkg/v1alpha1 to scheme")
+	kingpin.FatalIfError(pkgv1beta1.AddToScheme(s), "cannot add Crossplane pkg/v1beta1 to scheme")
 	kingpin.FatalIfError(extv1.AddToScheme(s), "cannot add Crossplane apiextensions/v1 to scheme")
+	kingpin.FatalIfError(extv1alpha1.AddToScheme(s), "cannot add Crossplane apiextensions/v1alpha1 to scheme")
 	kingpin.FatalIfError(appsv1.AddToScheme(s), "cannot add Kubernetes apps/v1 to scheme")
 	kingpin.FatalIfError(rbacv1.AddToScheme(s), "cannot add Kubernetes rbac/v1 to scheme")
 
@@ -226,6 +232,12 @@ func main() { //nolint:gocyclo
 	rm, err := clients.RESTMapper(cfg, httpClient)
 	kingpin.FatalIfError(err, "cannot create REST mapper")
 
+	// The apiResources query uses a global discovery client for the same
+	// reason. It caches discovery results so that repeated queries don't each
+	// rediscover every API group, which would use up our rate limit.
+	dc, err := clients.DiscoveryClient(cfg, httpClient)
+	kingpin.FatalIfError(err, "cannot create discovery client")
+
 	var camid []clients.NewCacheMiddlewareFn
 	// wrap client.Cache in cache.*BBoltCache if cacheFile is specified.
 	if *cacheFile != "" {
@@ -244,7 +256,8 @@ func main() { //nolint:gocyclo
 	cc := clients.Anonymize(cfg)
 	clients.InjectClientTLSCredentials(cc, *tlsClientKey, *tlsClientCert)
 	ca := clients.NewCache(s, cc, caopts...)
-	h := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: resolvers.New(ca)}))
+	rs := resolvers.New(ca, resolvers.WithLogReader(ca), resolvers.WithDiscoverer(dc))
+	h := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: rs}))
 
 	h.AddTransport(transport.Websocket{
 		Upgrader: websocket.Upgrader{
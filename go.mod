@@ -10,7 +10,6 @@ require (
 	github.com/crossplane/crossplane v1.17.0
 	github.com/crossplane/crossplane-runtime v1.17.0
 	github.com/epk/smaz v0.0.0-20220720222521-c11a89997fcf
-	github.com/gertd/go-pluralize v0.2.1
 	github.com/go-chi/chi/v5 v5.0.8
 	github.com/google/addlicense v0.0.0-20210428195630-6d92264d7170
 	github.com/google/go-cmp v0.6.0
@@ -23,8 +23,13 @@ import (
 	"sync"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -42,6 +47,7 @@ const (
 	errNewClient        = "cannot create new write client"
 	errNewCache         = "cannot create new read cache"
 	errNewHTTPClient    = "cannot create new HTTP client"
+	errNewCoreClient    = "cannot create new core/v1 client"
 	errWaitForCacheSync = "cannot sync client cache"
 )
 
@@ -91,6 +97,57 @@ func RESTMapper(cfg *rest.Config, httpClient *http.Client) (meta.RESTMapper, err
 	return apiutil.NewDynamicRESTMapper(dcfg, httpClient)
 }
 
+// DiscoveryClient returns a client that discovers the kinds of resource an API
+// server serves. Like a REST mapper it's intended to be shared by many clients,
+// and is subject to the same rate limits. Discovery results are cached in
+// memory, and rediscovered at most once every DiscoveryExpiry.
+func DiscoveryClient(cfg *rest.Config, httpClient *http.Client) (*DiscoveryCache, error) {
+	dcfg := rest.CopyConfig(cfg)
+	dcfg.QPS = 50
+	dcfg.Burst = 300
+
+	dc, err := discovery.NewDiscoveryClientForConfigAndClient(dcfg, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	return NewDiscoveryCache(memory.NewMemCacheClient(dc), DiscoveryExpiry), nil
+}
+
+// DiscoveryExpiry is how long a DiscoveryClient caches discovery results.
+const DiscoveryExpiry = 30 * time.Second
+
+// A DiscoveryCache caches the kinds of resource an API server serves. Cached
+// results expire so that kinds that are added or removed, for example by
+// installing a provider, are eventually discovered.
+type DiscoveryCache struct {
+	client discovery.CachedDiscoveryInterface
+	expiry time.Duration
+	now    func() time.Time
+
+	mx      sync.Mutex
+	expires time.Time
+}
+
+// NewDiscoveryCache returns a DiscoveryCache that invalidates the supplied
+// cached discovery client's results once they're older than the supplied
+// expiry.
+func NewDiscoveryCache(c discovery.CachedDiscoveryInterface, expiry time.Duration) *DiscoveryCache {
+	return &DiscoveryCache{client: c, expiry: expiry, now: time.Now}
+}
+
+// ServerGroupsAndResources returns the API groups and resources the API server
+// serves, rediscovering them if the cached results have expired.
+func (c *DiscoveryCache) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	c.mx.Lock()
+	if now := c.now(); !now.Before(c.expires) {
+		c.client.Invalidate()
+		c.expires = now.Add(c.expiry)
+	}
+	c.mx.Unlock()
+
+	return c.client.ServerGroupsAndResources()
+}
+
 // Anonymize the supplied config by returning a copy with all authentication
 // details and credentials removed.
 func Anonymize(cfg *rest.Config) *rest.Config {
@@ -223,13 +280,31 @@ func NewCache(s *runtime.Scheme, c *rest.Config, o ...CacheOption) *Cache {
 }
 
 type getOptions struct {
+	uncached bool
 }
 
 // A GetOption modifies the kind of client returned.
 type GetOption func(o *getOptions)
 
+// Uncached returns a client that reads from and writes to the API server
+// directly. Uncached clients aren't reused; each call creates a new client.
+// Use them sparingly, when a read must reflect the latest writes.
+func Uncached() GetOption {
+	return func(o *getOptions) {
+		o.uncached = true
+	}
+}
+
 // Get a client that uses the specified bearer token.
 func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error) { //nolint:gocyclo
+	opts := &getOptions{}
+	for _, fn := range o {
+		fn(opts)
+	}
+	if opts.uncached {
+		return c.getUncached(cr)
+	}
+
 	extra := bytes.Buffer{}
 	extra.Write(c.salt)
 	id := cr.Hash(extra.Bytes())
@@ -336,6 +411,37 @@ func (c *Cache) Get(cr auth.Credentials, o ...GetOption) (client.Client, error)
 	return sn.client, nil
 }
 
+// getUncached returns a client that uses the specified bearer token and isn't
+// backed by a cache.
+func (c *Cache) getUncached(cr auth.Credentials) (client.Client, error) {
+	cfg := cr.Inject(c.cfg)
+	hc, err := rest.HTTPClientFor(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHTTPClient)
+	}
+	wc, err := c.newClient(cfg, client.Options{
+		HTTPClient: hc,
+		Scheme:     c.scheme,
+		Mapper:     c.mapper,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+	return wc, nil
+}
+
+// PodLogs returns the logs of the supplied pod, read using the supplied
+// credentials. Logs are a subresource that controller-runtime clients can't
+// read, so we use a core/v1 client. Logs are never cached; each call reads them
+// from the API server.
+func (c *Cache) PodLogs(ctx context.Context, cr auth.Credentials, namespace, name string, o *corev1.PodLogOptions) ([]byte, error) {
+	cc, err := corev1client.NewForConfig(cr.Inject(c.cfg))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewCoreClient)
+	}
+	return cc.Pods(namespace).GetLogs(name, o).DoRaw(ctx)
+}
+
 func (c *Cache) remove(id string) {
 	c.mx.Lock()
 	defer c.mx.Unlock()
@@ -20,7 +20,9 @@ import (
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -140,6 +142,26 @@ func TestGet(t *testing.T) {
 				active: 0,
 			},
 		},
+		"Uncached": {
+			reason: "Uncached clients should not be backed by a cache, or added to the active map.",
+			copts: []CacheOption{
+				WithNewClientFn(NewClientFn(func(cfg *rest.Config, o client.Options) (client.Client, error) {
+					if o.Cache != nil {
+						return nil, errBoom
+					}
+					return test.NewMockClient(), nil
+				})),
+				WithNewCacheFn(NewCacheFn(func(cfg *rest.Config, o cache.Options) (cache.Cache, error) {
+					return nil, errBoom
+				})),
+			},
+			args: args{
+				o: []GetOption{Uncached()},
+			},
+			want: want{
+				active: 0,
+			},
+		},
 		"Success": {
 			reason: "Caches should be removed from the active map if they don't sync.",
 			copts: []CacheOption{
@@ -270,3 +292,61 @@ func TestInjectClientTLSCredentials(t *testing.T) {
 		})
 	}
 }
+
+type MockCachedDiscovery struct {
+	discovery.CachedDiscoveryInterface
+
+	invalidated int
+}
+
+func (d *MockCachedDiscovery) Invalidate() {
+	d.invalidated++
+}
+
+func (d *MockCachedDiscovery) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	return nil, nil, nil
+}
+
+func TestDiscoveryCache(t *testing.T) {
+	start := time.Now()
+
+	cases := map[string]struct {
+		reason string
+		calls  []time.Time
+		want   int
+	}{
+		"FirstCall": {
+			reason: "The first call should discover the API server's resources.",
+			calls:  []time.Time{start},
+			want:   1,
+		},
+		"NotExpired": {
+			reason: "Calls made before the cached results expire should use them.",
+			calls:  []time.Time{start, start.Add(time.Second), start.Add(DiscoveryExpiry - time.Second)},
+			want:   1,
+		},
+		"Expired": {
+			reason: "Calls made after the cached results expire should rediscover the API server's resources.",
+			calls:  []time.Time{start, start.Add(time.Second), start.Add(DiscoveryExpiry), start.Add(DiscoveryExpiry + time.Second)},
+			want:   2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := &MockCachedDiscovery{}
+			c := NewDiscoveryCache(d, DiscoveryExpiry)
+
+			for _, now := range tc.calls {
+				c.now = func() time.Time { return now }
+				if _, _, err := c.ServerGroupsAndResources(); err != nil {
+					t.Fatalf("c.ServerGroupsAndResources(): %s", err)
+				}
+			}
+
+			if diff := cmp.Diff(tc.want, d.invalidated); diff != "" {
+				t.Errorf("\n%s\nc.ServerGroupsAndResources(): -want invalidations, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -18,10 +18,13 @@ import (
 	"encoding/json"
 
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 
 	"github.com/google/go-cmp/cmp"
 
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 )
 
 // A CompositeResourceDefinitionSpec represents the desired state of a
@@ -161,6 +164,9 @@ func GetComposition(cmp *extv1.Composition) Composition {
 				Kind:       cmp.Spec.CompositeTypeRef.Kind,
 			},
 			WriteConnectionSecretsToNamespace: cmp.Spec.WriteConnectionSecretsToNamespace,
+			Mode:                              GetCompositionMode(cmp.Spec.Mode),
+			Resources:                         GetComposedTemplates(cmp.Spec.Resources),
+			Pipeline:                          GetPipelineSteps(cmp.Spec.Pipeline),
 		},
 		PavedAccess: PavedAccess{
 			Paved: paveObject(cmp),
@@ -168,6 +174,251 @@ func GetComposition(cmp *extv1.Composition) Composition {
 	}
 }
 
+// GetCompositionMode from the supplied Crossplane mode.
+func GetCompositionMode(in *extv1.CompositionMode) *CompositionMode {
+	if in == nil {
+		return nil
+	}
+	switch *in {
+	case extv1.CompositionModeResources:
+		out := CompositionModeResources
+		return &out
+	case extv1.CompositionModePipeline:
+		out := CompositionModePipeline
+		return &out
+	}
+	return nil
+}
+
+// GetComposedTemplates from the supplied Crossplane resource templates.
+func GetComposedTemplates(in []extv1.ComposedTemplate) []ComposedTemplate {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]ComposedTemplate, len(in))
+	for i := range in {
+		out[i] = ComposedTemplate{
+			Name:    in[i].Name,
+			Base:    getRawExtension(&in[i].Base),
+			Patches: GetComposedTemplatePatches(in[i].Patches),
+		}
+	}
+	return out
+}
+
+// GetComposedTemplatePatches from the supplied Crossplane patches.
+func GetComposedTemplatePatches(in []extv1.Patch) []ComposedTemplatePatch {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]ComposedTemplatePatch, len(in))
+	for i := range in {
+		p := ComposedTemplatePatch{
+			Type:          GetComposedTemplatePatchType(in[i].Type),
+			FromFieldPath: in[i].FromFieldPath,
+			ToFieldPath:   in[i].ToFieldPath,
+			PatchSetName:  in[i].PatchSetName,
+		}
+		if in[i].Combine != nil {
+			p.Combine = mustMarshal(in[i].Combine)
+		}
+		if in[i].Transforms != nil {
+			p.Transforms = make([][]byte, len(in[i].Transforms))
+			for j := range in[i].Transforms {
+				p.Transforms[j] = mustMarshal(in[i].Transforms[j])
+			}
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// GetComposedTemplatePatchType from the supplied Crossplane patch type. Patches
+// without a type copy from a composite field path.
+func GetComposedTemplatePatchType(in extv1.PatchType) ComposedTemplatePatchType {
+	switch in {
+	case extv1.PatchTypeFromEnvironmentFieldPath:
+		return ComposedTemplatePatchTypeFromEnvironmentFieldPath
+	case extv1.PatchTypePatchSet:
+		return ComposedTemplatePatchTypePatchSet
+	case extv1.PatchTypeToCompositeFieldPath:
+		return ComposedTemplatePatchTypeToCompositeFieldPath
+	case extv1.PatchTypeToEnvironmentFieldPath:
+		return ComposedTemplatePatchTypeToEnvironmentFieldPath
+	case extv1.PatchTypeCombineFromEnvironment:
+		return ComposedTemplatePatchTypeCombineFromEnvironment
+	case extv1.PatchTypeCombineFromComposite:
+		return ComposedTemplatePatchTypeCombineFromComposite
+	case extv1.PatchTypeCombineToComposite:
+		return ComposedTemplatePatchTypeCombineToComposite
+	case extv1.PatchTypeCombineToEnvironment:
+		return ComposedTemplatePatchTypeCombineToEnvironment
+	}
+	return ComposedTemplatePatchTypeFromCompositeFieldPath
+}
+
+// GetPipelineSteps from the supplied Crossplane pipeline.
+func GetPipelineSteps(in []extv1.PipelineStep) []PipelineStep {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]PipelineStep, len(in))
+	for i := range in {
+		out[i] = PipelineStep{
+			Step:        in[i].Step,
+			FunctionRef: LocalObjectReference{Name: in[i].FunctionRef.Name},
+			Input:       getRawExtension(in[i].Input),
+			Credentials: GetFunctionCredentials(in[i].Credentials),
+		}
+	}
+	return out
+}
+
+// GetFunctionCredentials from the supplied Crossplane function credentials.
+func GetFunctionCredentials(in []extv1.FunctionCredentials) []FunctionCredentials {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]FunctionCredentials, len(in))
+	for i := range in {
+		out[i] = FunctionCredentials{
+			Name:      in[i].Name,
+			Source:    GetFunctionCredentialsSource(in[i].Source),
+			SecretRef: GetSecretReference(in[i].SecretRef),
+		}
+	}
+	return out
+}
+
+// GetFunctionCredentialsSource from the supplied Crossplane source.
+func GetFunctionCredentialsSource(in extv1.FunctionCredentialsSource) FunctionCredentialsSource {
+	if in == extv1.FunctionCredentialsSourceSecret {
+		return FunctionCredentialsSourceSecret
+	}
+	return FunctionCredentialsSourceNone
+}
+
+// getRawExtension returns the JSON bytes of the supplied raw extension, or nil
+// if it is empty.
+func getRawExtension(in *runtime.RawExtension) []byte {
+	if in == nil || len(in.Raw) == 0 {
+		return nil
+	}
+	return in.Raw
+}
+
+// GetCompositionRevisionStatus from the supplied Crossplane status.
+func GetCompositionRevisionStatus(in extv1.CompositionRevisionStatus) *CompositionRevisionStatus {
+	if len(in.Conditions) == 0 {
+		return nil
+	}
+	return &CompositionRevisionStatus{Conditions: GetConditions(in.Conditions)}
+}
+
+// GetCompositionRevision from the supplied Crossplane CompositionRevision.
+func GetCompositionRevision(cr *extv1.CompositionRevision) CompositionRevision {
+	return CompositionRevision{
+		ID: ReferenceID{
+			APIVersion: cr.APIVersion,
+			Kind:       cr.Kind,
+			Name:       cr.GetName(),
+		},
+		APIVersion: cr.APIVersion,
+		Kind:       cr.Kind,
+		Metadata:   GetObjectMeta(cr),
+		Spec: CompositionRevisionSpec{
+			CompositeTypeRef: TypeReference{
+				APIVersion: cr.Spec.CompositeTypeRef.APIVersion,
+				Kind:       cr.Spec.CompositeTypeRef.Kind,
+			},
+			WriteConnectionSecretsToNamespace: cr.Spec.WriteConnectionSecretsToNamespace,
+			Revision:                          int(cr.Spec.Revision),
+		},
+		Status: GetCompositionRevisionStatus(cr.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(cr),
+		},
+	}
+}
+
+// GetEnvironmentConfig from the supplied Crossplane EnvironmentConfig.
+func GetEnvironmentConfig(ec *extv1alpha1.EnvironmentConfig) EnvironmentConfig {
+	return EnvironmentConfig{
+		ID: ReferenceID{
+			APIVersion: ec.APIVersion,
+			Kind:       ec.Kind,
+			Name:       ec.GetName(),
+		},
+		APIVersion: ec.APIVersion,
+		Kind:       ec.Kind,
+		Metadata:   GetObjectMeta(ec),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(ec),
+		},
+	}
+}
+
+// GetUsageResource from the supplied Crossplane usage resource.
+func GetUsageResource(in *extv1alpha1.Resource) *UsageResource {
+	if in == nil {
+		return nil
+	}
+
+	out := &UsageResource{}
+	if in.APIVersion != "" {
+		out.APIVersion = ptr.To(in.APIVersion)
+	}
+	if in.Kind != "" {
+		out.Kind = ptr.To(in.Kind)
+	}
+	if in.ResourceRef != nil {
+		out.ResourceRef = &UsageResourceRef{Name: in.ResourceRef.Name}
+	}
+	if in.ResourceSelector != nil {
+		out.ResourceSelector = &UsageResourceSelector{
+			MatchLabels:        in.ResourceSelector.MatchLabels,
+			MatchControllerRef: in.ResourceSelector.MatchControllerRef,
+		}
+	}
+	return out
+}
+
+// GetUsageStatus from the supplied Crossplane status.
+func GetUsageStatus(in extv1alpha1.UsageStatus) *UsageStatus {
+	if len(in.Conditions) == 0 {
+		return nil
+	}
+	return &UsageStatus{Conditions: GetConditions(in.Conditions)}
+}
+
+// GetUsage from the supplied Crossplane Usage.
+func GetUsage(u *extv1alpha1.Usage) Usage {
+	return Usage{
+		ID: ReferenceID{
+			APIVersion: u.APIVersion,
+			Kind:       u.Kind,
+			Name:       u.GetName(),
+		},
+		APIVersion: u.APIVersion,
+		Kind:       u.Kind,
+		Metadata:   GetObjectMeta(u),
+		Spec: UsageSpec{
+			Of:             *GetUsageResource(&u.Spec.Of),
+			By:             GetUsageResource(u.Spec.By),
+			Reason:         u.Spec.Reason,
+			ReplayDeletion: u.Spec.ReplayDeletion,
+		},
+		Status: GetUsageStatus(u.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(u),
+		},
+	}
+}
+
 /* Handle deprecated items preferring non-deprecated */
 func (options *DefinedCompositeResourceOptionsInput) DeprecationPatch(version *string) {
 	if version != nil && options.Version == nil {
@@ -203,3 +454,24 @@ func (m *CompositeResource) GetConditions() []Condition {
 	}
 	return nil
 }
+
+func (m *ManagedResource) GetConditions() []Condition {
+	if m.Status != nil {
+		return m.Status.Conditions
+	}
+	return nil
+}
+
+func (m *Provider) GetConditions() []Condition {
+	if m.Status != nil {
+		return m.Status.Conditions
+	}
+	return nil
+}
+
+func (m *Configuration) GetConditions() []Condition {
+	if m.Status != nil {
+		return m.Status.Conditions
+	}
+	return nil
+}
@@ -27,6 +27,7 @@ import (
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 )
 
 func TestGetCompositeResourceDefinition(t *testing.T) {
@@ -197,6 +198,30 @@ func TestGetComposition(t *testing.T) {
 						Kind:       "ClusterExample",
 					},
 					WriteConnectionSecretsToNamespace: ptr.To("ns"),
+					Mode:                              ptr.To(extv1.CompositionModePipeline),
+					Resources: []extv1.ComposedTemplate{{
+						Name: ptr.To("bucket"),
+						Base: rschema,
+						Patches: []extv1.Patch{
+							{FromFieldPath: ptr.To("spec.region")},
+							{
+								Type:        extv1.PatchTypeCombineFromComposite,
+								ToFieldPath: ptr.To("spec.name"),
+								Combine:     &extv1.Combine{Strategy: extv1.CombineStrategyString},
+								Transforms:  []extv1.Transform{{Type: extv1.TransformTypeString}},
+							},
+						},
+					}},
+					Pipeline: []extv1.PipelineStep{{
+						Step:        "render",
+						FunctionRef: extv1.FunctionReference{Name: "function-cool"},
+						Input:       &rschema,
+						Credentials: []extv1.FunctionCredentials{{
+							Name:      "creds",
+							Source:    extv1.FunctionCredentialsSourceSecret,
+							SecretRef: &xpv1.SecretReference{Namespace: "ns", Name: "secret"},
+						}},
+					}},
 				},
 			},
 			want: Composition{
@@ -216,6 +241,33 @@ func TestGetComposition(t *testing.T) {
 						Kind:       "ClusterExample",
 					},
 					WriteConnectionSecretsToNamespace: ptr.To("ns"),
+					Mode:                              ptr.To(CompositionModePipeline),
+					Resources: []ComposedTemplate{{
+						Name: ptr.To("bucket"),
+						Base: []byte(schema),
+						Patches: []ComposedTemplatePatch{
+							{
+								Type:          ComposedTemplatePatchTypeFromCompositeFieldPath,
+								FromFieldPath: ptr.To("spec.region"),
+							},
+							{
+								Type:        ComposedTemplatePatchTypeCombineFromComposite,
+								ToFieldPath: ptr.To("spec.name"),
+								Combine:     []byte(`{"variables":null,"strategy":"string"}`),
+								Transforms:  [][]byte{[]byte(`{"type":"string"}`)},
+							},
+						},
+					}},
+					Pipeline: []PipelineStep{{
+						Step:        "render",
+						FunctionRef: LocalObjectReference{Name: "function-cool"},
+						Input:       []byte(schema),
+						Credentials: []FunctionCredentials{{
+							Name:      "creds",
+							Source:    FunctionCredentialsSourceSecret,
+							SecretRef: &SecretReference{Namespace: "ns", Name: "secret"},
+						}},
+					}},
 				},
 			},
 		},
@@ -241,6 +293,221 @@ func TestGetComposition(t *testing.T) {
 	}
 }
 
+func TestGetCompositionRevision(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cr     *extv1.CompositionRevision
+		want   CompositionRevision
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			cr: &extv1.CompositionRevision{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: extv1.CompositionRevisionGroupVersionKind.GroupVersion().String(),
+					Kind:       extv1.CompositionRevisionKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool-abcdef",
+				},
+				Spec: extv1.CompositionRevisionSpec{
+					CompositeTypeRef: extv1.TypeReference{
+						APIVersion: "group/v1",
+						Kind:       "ClusterExample",
+					},
+					WriteConnectionSecretsToNamespace: ptr.To("ns"),
+					Revision:                          2,
+				},
+				Status: extv1.CompositionRevisionStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{{}}},
+				},
+			},
+			want: CompositionRevision{
+				ID: ReferenceID{
+					APIVersion: extv1.CompositionRevisionGroupVersionKind.GroupVersion().String(),
+					Kind:       extv1.CompositionRevisionKind,
+					Name:       "cool-abcdef",
+				},
+				APIVersion: extv1.CompositionRevisionGroupVersionKind.GroupVersion().String(),
+				Kind:       extv1.CompositionRevisionKind,
+				Metadata: ObjectMeta{
+					Name: "cool-abcdef",
+				},
+				Spec: CompositionRevisionSpec{
+					CompositeTypeRef: TypeReference{
+						APIVersion: "group/v1",
+						Kind:       "ClusterExample",
+					},
+					WriteConnectionSecretsToNamespace: ptr.To("ns"),
+					Revision:                          2,
+				},
+				Status: &CompositionRevisionStatus{
+					Conditions: []Condition{{}},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			cr:     &extv1.CompositionRevision{},
+			want: CompositionRevision{
+				Metadata: ObjectMeta{},
+				Spec: CompositionRevisionSpec{
+					CompositeTypeRef: TypeReference{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetCompositionRevision(tc.cr)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(CompositionRevision{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetCompositionRevision(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetEnvironmentConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		ec     *extv1alpha1.EnvironmentConfig
+		want   EnvironmentConfig
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			ec: &extv1alpha1.EnvironmentConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+					Kind:       extv1alpha1.EnvironmentConfigKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+			},
+			want: EnvironmentConfig{
+				ID: ReferenceID{
+					APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+					Kind:       extv1alpha1.EnvironmentConfigKind,
+					Name:       "cool",
+				},
+				APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+				Kind:       extv1alpha1.EnvironmentConfigKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			ec:     &extv1alpha1.EnvironmentConfig{},
+			want: EnvironmentConfig{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetEnvironmentConfig(tc.ec)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(EnvironmentConfig{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetEnvironmentConfig(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetUsage(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		u      *extv1alpha1.Usage
+		want   Usage
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			u: &extv1alpha1.Usage{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+					Kind:       extv1alpha1.UsageKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: extv1alpha1.UsageSpec{
+					Of: extv1alpha1.Resource{
+						APIVersion:  "example.org/v1",
+						Kind:        "Bucket",
+						ResourceRef: &extv1alpha1.ResourceRef{Name: "coolbucket"},
+					},
+					By: &extv1alpha1.Resource{
+						APIVersion: "example.org/v1",
+						Kind:       "Instance",
+						ResourceSelector: &extv1alpha1.ResourceSelector{
+							MatchLabels:        map[string]string{"cool": "true"},
+							MatchControllerRef: ptr.To(true),
+						},
+					},
+					Reason:         ptr.To("cool reason"),
+					ReplayDeletion: ptr.To(true),
+				},
+				Status: extv1alpha1.UsageStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{Conditions: []xpv1.Condition{{}}},
+				},
+			},
+			want: Usage{
+				ID: ReferenceID{
+					APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+					Kind:       extv1alpha1.UsageKind,
+					Name:       "cool",
+				},
+				APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+				Kind:       extv1alpha1.UsageKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: UsageSpec{
+					Of: UsageResource{
+						APIVersion:  ptr.To("example.org/v1"),
+						Kind:        ptr.To("Bucket"),
+						ResourceRef: &UsageResourceRef{Name: "coolbucket"},
+					},
+					By: &UsageResource{
+						APIVersion: ptr.To("example.org/v1"),
+						Kind:       ptr.To("Instance"),
+						ResourceSelector: &UsageResourceSelector{
+							MatchLabels:        map[string]string{"cool": "true"},
+							MatchControllerRef: ptr.To(true),
+						},
+					},
+					Reason:         ptr.To("cool reason"),
+					ReplayDeletion: ptr.To(true),
+				},
+				Status: &UsageStatus{
+					Conditions: []Condition{{}},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			u:      &extv1alpha1.Usage{},
+			want: Usage{
+				Metadata: ObjectMeta{},
+				Spec: UsageSpec{
+					Of: UsageResource{},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetUsage(tc.u)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Usage{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetUsage(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestDefinedCompositeResourceOptionsInputDeprecation(t *testing.T) {
 	version1 := "v1"
 	version2 := "v2"
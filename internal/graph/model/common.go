@@ -23,6 +23,7 @@ import (
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 
@@ -30,7 +31,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/unstructured"
 )
@@ -109,6 +113,49 @@ func GetLabelSelector(s *metav1.LabelSelector) *LabelSelector {
 	return &LabelSelector{MatchLabels: s.MatchLabels}
 }
 
+// AsSelector converts the supplied label selector input to a Kubernetes label
+// selector. A nil input selects everything.
+func (in *LabelSelectorInput) AsSelector() (labels.Selector, error) {
+	if in == nil {
+		return labels.Everything(), nil
+	}
+
+	ls := &metav1.LabelSelector{
+		MatchLabels:      in.MatchLabels,
+		MatchExpressions: make([]metav1.LabelSelectorRequirement, len(in.MatchExpressions)),
+	}
+	for i, r := range in.MatchExpressions {
+		ls.MatchExpressions[i] = metav1.LabelSelectorRequirement{
+			Key:      r.Key,
+			Operator: labelSelectorOperators[r.Operator],
+			Values:   r.Values,
+		}
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(ls)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Selector == nil {
+		return s, nil
+	}
+
+	p, err := labels.Parse(*in.Selector)
+	if err != nil {
+		return nil, err
+	}
+	rs, _ := p.Requirements()
+	return s.Add(rs...), nil
+}
+
+var labelSelectorOperators = map[LabelSelectorOperator]metav1.LabelSelectorOperator{
+	LabelSelectorOperatorIn:           metav1.LabelSelectorOpIn,
+	LabelSelectorOperatorNotIn:        metav1.LabelSelectorOpNotIn,
+	LabelSelectorOperatorExists:       metav1.LabelSelectorOpExists,
+	LabelSelectorOperatorDoesNotExist: metav1.LabelSelectorOpDoesNotExist,
+}
+
 // GetGenericResource from the suppled Kubernetes resource.
 func GetGenericResource(u *kunstructured.Unstructured) GenericResource {
 	return GenericResource{
@@ -328,6 +375,9 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 	case unstructured.ProbablyProviderConfig(u):
 		return GetProviderConfig(u), nil
 
+	case unstructured.ProbablyProviderConfigUsage(u):
+		return GetProviderConfigUsage(u), nil
+
 	case unstructured.ProbablyComposite(u):
 		return GetCompositeResource(u), nil
 
@@ -356,6 +406,20 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetProviderRevision(pr), nil
 
+	case u.GroupVersionKind() == pkgv1.FunctionGroupVersionKind:
+		f := &pkgv1.Function{}
+		if err := convert(u, f); err != nil {
+			return nil, errors.Wrap(err, "cannot convert function")
+		}
+		return GetFunction(f), nil
+
+	case u.GroupVersionKind() == pkgv1.FunctionRevisionGroupVersionKind:
+		fr := &pkgv1.FunctionRevision{}
+		if err := convert(u, fr); err != nil {
+			return nil, errors.Wrap(err, "cannot convert function revision")
+		}
+		return GetFunctionRevision(fr), nil
+
 	case u.GroupVersionKind() == pkgv1.ConfigurationGroupVersionKind:
 		c := &pkgv1.Configuration{}
 		if err := convert(u, c); err != nil {
@@ -384,6 +448,48 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetComposition(cmp), nil
 
+	case u.GroupVersionKind() == extv1.CompositionRevisionGroupVersionKind:
+		cr := &extv1.CompositionRevision{}
+		if err := convert(u, cr); err != nil {
+			return nil, errors.Wrap(err, "cannot convert composition revision")
+		}
+		return GetCompositionRevision(cr), nil
+
+	case u.GroupVersionKind() == extv1alpha1.EnvironmentConfigGroupVersionKind:
+		ec := &extv1alpha1.EnvironmentConfig{}
+		if err := convert(u, ec); err != nil {
+			return nil, errors.Wrap(err, "cannot convert environment config")
+		}
+		return GetEnvironmentConfig(ec), nil
+
+	case u.GroupVersionKind() == extv1alpha1.UsageGroupVersionKind:
+		us := &extv1alpha1.Usage{}
+		if err := convert(u, us); err != nil {
+			return nil, errors.Wrap(err, "cannot convert usage")
+		}
+		return GetUsage(us), nil
+
+	case u.GroupVersionKind() == pkgv1beta1.DeploymentRuntimeConfigGroupVersionKind:
+		rc := &pkgv1beta1.DeploymentRuntimeConfig{}
+		if err := convert(u, rc); err != nil {
+			return nil, errors.Wrap(err, "cannot convert deployment runtime config")
+		}
+		return GetDeploymentRuntimeConfig(rc), nil
+
+	case u.GroupVersionKind() == pkgv1beta1.LockGroupVersionKind:
+		l := &pkgv1beta1.Lock{}
+		if err := convert(u, l); err != nil {
+			return nil, errors.Wrap(err, "cannot convert package lock")
+		}
+		return GetPackageLock(l), nil
+
+	case u.GroupVersionKind() == pkgv1alpha1.ControllerConfigGroupVersionKind:
+		cc := &pkgv1alpha1.ControllerConfig{}
+		if err := convert(u, cc); err != nil {
+			return nil, errors.Wrap(err, "cannot convert controller config")
+		}
+		return GetControllerConfig(cc), nil
+
 	case u.GroupVersionKind() == schema.GroupVersionKind{Group: kextv1.GroupName, Version: "v1", Kind: "CustomResourceDefinition"}:
 		crd := &unstructured.CustomResourceDefinition{}
 		crd.SetAPIVersion("apiextensions.k8s.io/v1")
@@ -407,6 +513,13 @@ func GetKubernetesResource(u *kunstructured.Unstructured) (KubernetesResource, e
 		}
 		return GetConfigMap(cm), nil
 
+	case u.GroupVersionKind() == schema.GroupVersionKind{Group: corev1.GroupName, Version: "v1", Kind: "Pod"}:
+		p := &corev1.Pod{}
+		if err := convert(u, p); err != nil {
+			return nil, errors.Wrap(err, "cannot convert pod")
+		}
+		return GetPod(p), nil
+
 	default:
 		return GetGenericResource(u), nil
 	}
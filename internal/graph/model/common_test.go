@@ -31,7 +31,10 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/unstructured"
 )
@@ -433,17 +436,25 @@ func TestGetKubernetesResource(t *testing.T) {
 		cmp.AllowUnexported(Secret{}, ConfigMap{}, ObjectMeta{}),
 		cmpopts.IgnoreFields(ManagedResource{}, "PavedAccess"),
 		cmpopts.IgnoreFields(ProviderConfig{}, "PavedAccess"),
+		cmpopts.IgnoreFields(ProviderConfigUsage{}, "PavedAccess"),
 		cmpopts.IgnoreFields(CompositeResource{}, "PavedAccess"),
 		cmpopts.IgnoreFields(CompositeResourceClaim{}, "PavedAccess"),
 		cmpopts.IgnoreFields(Provider{}, "PavedAccess"),
 		cmpopts.IgnoreFields(ProviderRevision{}, "PavedAccess"),
+		cmpopts.IgnoreFields(Function{}, "PavedAccess"),
+		cmpopts.IgnoreFields(FunctionRevision{}, "PavedAccess"),
 		cmpopts.IgnoreFields(Configuration{}, "PavedAccess"),
 		cmpopts.IgnoreFields(ConfigurationRevision{}, "PavedAccess"),
 		cmpopts.IgnoreFields(CompositeResourceDefinition{}, "PavedAccess"),
 		cmpopts.IgnoreFields(Composition{}, "PavedAccess"),
+		cmpopts.IgnoreFields(Usage{}, "PavedAccess"),
+		cmpopts.IgnoreFields(DeploymentRuntimeConfig{}, "PavedAccess"),
+		cmpopts.IgnoreFields(PackageLock{}, "PavedAccess"),
+		cmpopts.IgnoreFields(ControllerConfig{}, "PavedAccess"),
 		cmpopts.IgnoreFields(CustomResourceDefinition{}, "PavedAccess"),
 		cmpopts.IgnoreFields(Secret{}, "PavedAccess"),
 		cmpopts.IgnoreFields(ConfigMap{}, "PavedAccess"),
+		cmpopts.IgnoreFields(Pod{}, "PavedAccess"),
 		cmpopts.IgnoreFields(GenericResource{}, "PavedAccess"),
 	}
 
@@ -491,6 +502,20 @@ func TestGetKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"ProviderConfigUsage": {
+			u: func() *kunstructured.Unstructured {
+				pcu := &unstructured.ProviderConfigUsage{}
+				pcu.SetKind("ProviderConfigUsage")
+				return pcu.GetUnstructured()
+			}(),
+			want: want{
+				kr: ProviderConfigUsage{
+					ID:       ReferenceID{Kind: "ProviderConfigUsage"},
+					Kind:     "ProviderConfigUsage",
+					Metadata: ObjectMeta{},
+				},
+			},
+		},
 		"Composite": {
 			u: func() *kunstructured.Unstructured {
 				// Set resource refs to convince unstructured.ProbablyComposite
@@ -505,8 +530,9 @@ func TestGetKubernetesResource(t *testing.T) {
 					ID:       ReferenceID{Name: "cool"},
 					Metadata: ObjectMeta{Name: "cool"},
 					Spec: CompositeResourceSpec{
-						CompositionReference: &corev1.ObjectReference{Name: "cmp"},
-						ResourceReferences:   []corev1.ObjectReference{{Name: "cool"}},
+						CompositionReference:        &corev1.ObjectReference{Name: "cmp"},
+						ResourceReferences:          []corev1.ObjectReference{{Name: "cool"}},
+						EnvironmentConfigReferences: []corev1.ObjectReference{},
 					},
 				},
 			},
@@ -578,6 +604,46 @@ func TestGetKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"Function": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(kschema.GroupVersion{Group: pkgv1.Group, Version: pkgv1.Version}.String())
+				u.SetKind(pkgv1.FunctionKind)
+				return u
+			}(),
+			want: want{
+				kr: Function{
+					ID: ReferenceID{
+						APIVersion: kschema.GroupVersion{Group: pkgv1.Group, Version: pkgv1.Version}.String(),
+						Kind:       pkgv1.FunctionKind,
+					},
+					APIVersion: kschema.GroupVersion{Group: pkgv1.Group, Version: pkgv1.Version}.String(),
+					Kind:       pkgv1.FunctionKind,
+					Metadata:   ObjectMeta{},
+					Spec:       FunctionSpec{},
+				},
+			},
+		},
+		"FunctionRevision": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(kschema.GroupVersion{Group: pkgv1.Group, Version: pkgv1.Version}.String())
+				u.SetKind(pkgv1.FunctionRevisionKind)
+				return u
+			}(),
+			want: want{
+				kr: FunctionRevision{
+					ID: ReferenceID{
+						APIVersion: kschema.GroupVersion{Group: pkgv1.Group, Version: pkgv1.Version}.String(),
+						Kind:       pkgv1.FunctionRevisionKind,
+					},
+					APIVersion: kschema.GroupVersion{Group: pkgv1.Group, Version: pkgv1.Version}.String(),
+					Kind:       pkgv1.FunctionRevisionKind,
+					Metadata:   ObjectMeta{},
+					Spec:       FunctionRevisionSpec{},
+				},
+			},
+		},
 		"Configuration": {
 			u: func() *kunstructured.Unstructured {
 				u := &kunstructured.Unstructured{}
@@ -662,6 +728,85 @@ func TestGetKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"Usage": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(extv1alpha1.SchemeGroupVersion.String())
+				u.SetKind(extv1alpha1.UsageKind)
+				return u
+			}(),
+			want: want{
+				kr: Usage{
+					ID: ReferenceID{
+						APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+						Kind:       extv1alpha1.UsageKind,
+					},
+					APIVersion: extv1alpha1.SchemeGroupVersion.String(),
+					Kind:       extv1alpha1.UsageKind,
+					Metadata:   ObjectMeta{},
+					Spec: UsageSpec{
+						Of: UsageResource{},
+					},
+				},
+			},
+		},
+		"DeploymentRuntimeConfig": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(pkgv1beta1.SchemeGroupVersion.String())
+				u.SetKind(pkgv1beta1.DeploymentRuntimeConfigKind)
+				return u
+			}(),
+			want: want{
+				kr: DeploymentRuntimeConfig{
+					ID: ReferenceID{
+						APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+						Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+					},
+					APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+					Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+					Metadata:   ObjectMeta{},
+				},
+			},
+		},
+		"PackageLock": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(pkgv1beta1.SchemeGroupVersion.String())
+				u.SetKind(pkgv1beta1.LockKind)
+				return u
+			}(),
+			want: want{
+				kr: PackageLock{
+					ID: ReferenceID{
+						APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+						Kind:       pkgv1beta1.LockKind,
+					},
+					APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+					Kind:       pkgv1beta1.LockKind,
+					Metadata:   ObjectMeta{},
+				},
+			},
+		},
+		"ControllerConfig": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(pkgv1alpha1.SchemeGroupVersion.String())
+				u.SetKind(pkgv1alpha1.ControllerConfigKind)
+				return u
+			}(),
+			want: want{
+				kr: ControllerConfig{
+					ID: ReferenceID{
+						APIVersion: pkgv1alpha1.SchemeGroupVersion.String(),
+						Kind:       pkgv1alpha1.ControllerConfigKind,
+					},
+					APIVersion: pkgv1alpha1.SchemeGroupVersion.String(),
+					Kind:       pkgv1alpha1.ControllerConfigKind,
+					Metadata:   ObjectMeta{},
+				},
+			},
+		},
 		"CustomResourceDefinition": {
 			u: func() *kunstructured.Unstructured {
 				u := &kunstructured.Unstructured{}
@@ -723,6 +868,25 @@ func TestGetKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"Pod": {
+			u: func() *kunstructured.Unstructured {
+				u := &kunstructured.Unstructured{}
+				u.SetAPIVersion(kschema.GroupVersion{Group: corev1.GroupName, Version: "v1"}.String())
+				u.SetKind("Pod")
+				return u
+			}(),
+			want: want{
+				kr: Pod{
+					ID: ReferenceID{
+						APIVersion: corev1.SchemeGroupVersion.String(),
+						Kind:       "Pod",
+					},
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Pod",
+					Metadata:   ObjectMeta{},
+				},
+			},
+		},
 		"Unknown": {
 			u: &kunstructured.Unstructured{},
 			want: want{
@@ -821,3 +985,60 @@ func TestGetSecretReference(t *testing.T) {
 		})
 	}
 }
+
+func TestLabelSelectorInputAsSelector(t *testing.T) {
+	type want struct {
+		selector string
+		err      bool
+	}
+	cases := map[string]struct {
+		reason string
+		in     *LabelSelectorInput
+		want   want
+	}{
+		"Nil": {
+			reason: "A nil input should select everything",
+			in:     nil,
+			want:   want{selector: ""},
+		},
+		"Full": {
+			reason: "All supported fields should be combined into one selector",
+			in: &LabelSelectorInput{
+				Selector:    ptr.To("tier!=db"),
+				MatchLabels: map[string]string{"team": "payments"},
+				MatchExpressions: []LabelSelectorRequirementInput{
+					{Key: "env", Operator: LabelSelectorOperatorIn, Values: []string{"prod", "staging"}},
+					{Key: "deprecated", Operator: LabelSelectorOperatorDoesNotExist},
+				},
+			},
+			want: want{selector: "!deprecated,env in (prod,staging),team=payments,tier!=db"},
+		},
+		"InvalidSelector": {
+			reason: "An unparseable selector string should return an error",
+			in:     &LabelSelectorInput{Selector: ptr.To("team in payments")},
+			want:   want{err: true},
+		},
+		"InvalidExpression": {
+			reason: "An IN requirement without values should return an error",
+			in: &LabelSelectorInput{
+				MatchExpressions: []LabelSelectorRequirementInput{{Key: "env", Operator: LabelSelectorOperatorIn}},
+			},
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tc.in.AsSelector()
+			if diff := cmp.Diff(tc.want.err, err != nil); diff != "" {
+				t.Errorf("\n%s\nAsSelector(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.selector, got.String()); diff != "" {
+				t.Errorf("\n%s\nAsSelector(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -28,11 +28,15 @@ import (
 
 // A CompositeResourceSpec defines the desired state of a composite resource.
 type CompositeResourceSpec struct {
-	CompositionSelector *LabelSelector `json:"compositionSelector"`
+	CompositionSelector         *LabelSelector           `json:"compositionSelector"`
+	CompositionRevisionSelector *LabelSelector           `json:"compositionRevisionSelector"`
+	CompositionUpdatePolicy     *CompositionUpdatePolicy `json:"compositionUpdatePolicy"`
 
 	CompositionReference             *corev1.ObjectReference
+	CompositionRevisionReference     *corev1.ObjectReference
 	ClaimReference                   *claim.Reference
 	ResourceReferences               []corev1.ObjectReference
+	EnvironmentConfigReferences      []corev1.ObjectReference
 	WriteConnectionSecretToReference *xpv1.SecretReference
 }
 
@@ -71,9 +75,13 @@ func GetCompositeResource(u *kunstructured.Unstructured) CompositeResource {
 		Metadata:   GetObjectMeta(xr),
 		Spec: CompositeResourceSpec{
 			CompositionSelector:              GetLabelSelector(xr.GetCompositionSelector()),
+			CompositionRevisionSelector:      GetLabelSelector(xr.GetCompositionRevisionSelector()),
+			CompositionUpdatePolicy:          GetCompositionUpdatePolicy(xr.GetCompositionUpdatePolicy()),
 			CompositionReference:             xr.GetCompositionReference(),
+			CompositionRevisionReference:     xr.GetCompositionRevisionReference(),
 			ClaimReference:                   xr.GetClaimReference(),
 			ResourceReferences:               xr.GetResourceReferences(),
+			EnvironmentConfigReferences:      xr.GetEnvironmentConfigReferences(),
 			WriteConnectionSecretToReference: xr.GetWriteConnectionSecretToReference(),
 		},
 		Status: GetCompositeResourceStatus(xr),
@@ -83,6 +91,22 @@ func GetCompositeResource(u *kunstructured.Unstructured) CompositeResource {
 	}
 }
 
+// GetCompositionUpdatePolicy from the supplied Crossplane policy.
+func GetCompositionUpdatePolicy(in *xpv1.UpdatePolicy) *CompositionUpdatePolicy {
+	if in == nil {
+		return nil
+	}
+	switch *in {
+	case xpv1.UpdateAutomatic:
+		out := CompositionUpdatePolicyAutomatic
+		return &out
+	case xpv1.UpdateManual:
+		out := CompositionUpdatePolicyManual
+		return &out
+	}
+	return nil
+}
+
 func delocalize(ref *xpv1.LocalSecretReference, namespace string) *xpv1.SecretReference {
 	if ref == nil {
 		return nil
@@ -93,10 +117,13 @@ func delocalize(ref *xpv1.LocalSecretReference, namespace string) *xpv1.SecretRe
 // A CompositeResourceClaimSpec represents the desired state of a composite
 // resource claim.
 type CompositeResourceClaimSpec struct {
-	CompositionSelector *LabelSelector `json:"compositionSelector"`
+	CompositionSelector         *LabelSelector           `json:"compositionSelector"`
+	CompositionRevisionSelector *LabelSelector           `json:"compositionRevisionSelector"`
+	CompositionUpdatePolicy     *CompositionUpdatePolicy `json:"compositionUpdatePolicy"`
 
-	CompositionReference *corev1.ObjectReference
-	ResourceReference    *corev1.ObjectReference
+	CompositionReference         *corev1.ObjectReference
+	CompositionRevisionReference *corev1.ObjectReference
+	ResourceReference            *corev1.ObjectReference
 
 	// We use a non-local secret reference because we need to know what
 	// namespace the secret is in when we're resolving it, when we only have
@@ -140,7 +167,10 @@ func GetCompositeResourceClaim(u *kunstructured.Unstructured) CompositeResourceC
 		Metadata:   GetObjectMeta(xrc),
 		Spec: CompositeResourceClaimSpec{
 			CompositionSelector:              GetLabelSelector(xrc.GetCompositionSelector()),
+			CompositionRevisionSelector:      GetLabelSelector(xrc.GetCompositionRevisionSelector()),
+			CompositionUpdatePolicy:          GetCompositionUpdatePolicy(xrc.GetCompositionUpdatePolicy()),
 			CompositionReference:             xrc.GetCompositionReference(),
+			CompositionRevisionReference:     xrc.GetCompositionRevisionReference(),
 			ResourceReference:                xrc.GetResourceReference(),
 			WriteConnectionSecretToReference: delocalize(xrc.GetWriteConnectionSecretToReference(), xrc.GetNamespace()),
 		},
@@ -50,8 +50,12 @@ func TestGetCompositeResource(t *testing.T) {
 				xr.SetName("cool")
 				xr.SetCompositionSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"cool": "very"}})
 				xr.SetCompositionReference(&corev1.ObjectReference{Name: "coolcmp"})
+				xr.SetCompositionRevisionSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"channel": "stable"}})
+				xr.SetCompositionRevisionReference(&corev1.ObjectReference{Name: "coolcmp-abcdef"})
+				xr.SetCompositionUpdatePolicy(ptr.To(xpv1.UpdateManual))
 				xr.SetClaimReference(&claim.Reference{Name: "coolclaim"})
 				xr.SetResourceReferences([]corev1.ObjectReference{{Name: "coolmanaged"}})
+				xr.SetEnvironmentConfigReferences([]corev1.ObjectReference{{Name: "coolenv"}})
 				xr.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Name: "coolsecret"})
 				xr.SetConnectionDetailsLastPublishedTime(&mp)
 				xr.SetConditions(xpv1.Condition{})
@@ -71,9 +75,13 @@ func TestGetCompositeResource(t *testing.T) {
 				},
 				Spec: CompositeResourceSpec{
 					CompositionSelector:              &LabelSelector{MatchLabels: map[string]string{"cool": "very"}},
+					CompositionRevisionSelector:      &LabelSelector{MatchLabels: map[string]string{"channel": "stable"}},
+					CompositionUpdatePolicy:          ptr.To(CompositionUpdatePolicyManual),
 					CompositionReference:             &corev1.ObjectReference{Name: "coolcmp"},
+					CompositionRevisionReference:     &corev1.ObjectReference{Name: "coolcmp-abcdef"},
 					ClaimReference:                   &claim.Reference{Name: "coolclaim"},
 					ResourceReferences:               []corev1.ObjectReference{{Name: "coolmanaged"}},
+					EnvironmentConfigReferences:      []corev1.ObjectReference{{Name: "coolenv"}},
 					WriteConnectionSecretToReference: &xpv1.SecretReference{Name: "coolsecret"},
 				},
 				Status: &CompositeResourceStatus{
@@ -90,10 +98,11 @@ func TestGetCompositeResource(t *testing.T) {
 			want: CompositeResource{
 				Metadata: ObjectMeta{},
 				Spec: CompositeResourceSpec{
-					// We don't mind this empty list being here because it's
-					// not exposed as part of our GraphQL API. We use it instead
-					// to resolve the resources array.
-					ResourceReferences: []corev1.ObjectReference{},
+					// We don't mind these empty lists being here because they're
+					// not exposed as part of our GraphQL API. We use them instead
+					// to resolve the resources and environmentConfigs arrays.
+					ResourceReferences:          []corev1.ObjectReference{},
+					EnvironmentConfigReferences: []corev1.ObjectReference{},
 				},
 			},
 		},
@@ -135,6 +144,9 @@ func TestGetCompositeResourceClaim(t *testing.T) {
 				xrc.SetName("cool")
 				xrc.SetCompositionSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"cool": "very"}})
 				xrc.SetCompositionReference(&corev1.ObjectReference{Name: "coolcmp"})
+				xrc.SetCompositionRevisionSelector(&metav1.LabelSelector{MatchLabels: map[string]string{"channel": "stable"}})
+				xrc.SetCompositionRevisionReference(&corev1.ObjectReference{Name: "coolcmp-abcdef"})
+				xrc.SetCompositionUpdatePolicy(ptr.To(xpv1.UpdateManual))
 				xrc.SetResourceReference(&corev1.ObjectReference{Name: "coolxr"})
 				xrc.SetWriteConnectionSecretToReference(&xpv1.LocalSecretReference{Name: "coolsecret"})
 				xrc.SetConnectionDetailsLastPublishedTime(&mp)
@@ -157,7 +169,10 @@ func TestGetCompositeResourceClaim(t *testing.T) {
 				},
 				Spec: CompositeResourceClaimSpec{
 					CompositionSelector:              &LabelSelector{MatchLabels: map[string]string{"cool": "very"}},
+					CompositionRevisionSelector:      &LabelSelector{MatchLabels: map[string]string{"channel": "stable"}},
+					CompositionUpdatePolicy:          ptr.To(CompositionUpdatePolicyManual),
 					CompositionReference:             &corev1.ObjectReference{Name: "coolcmp"},
+					CompositionRevisionReference:     &corev1.ObjectReference{Name: "coolcmp-abcdef"},
 					ResourceReference:                &corev1.ObjectReference{Name: "coolxr"},
 					WriteConnectionSecretToReference: &xpv1.SecretReference{Namespace: "default", Name: "coolsecret"},
 				},
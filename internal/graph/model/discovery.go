@@ -0,0 +1,98 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+)
+
+// GetAPIResources from the supplied Kubernetes API discovery results.
+// Subresources, like status or scale, are omitted. The returned resources are
+// sorted by group, version, then kind.
+func GetAPIResources(groups []*metav1.APIGroup, lists []*metav1.APIResourceList) []APIResource {
+	preferred := make(map[string]string, len(groups))
+	for _, g := range groups {
+		if g == nil {
+			continue
+		}
+		preferred[g.Name] = g.PreferredVersion.Version
+	}
+
+	out := make([]APIResource, 0)
+	for _, l := range lists {
+		if l == nil {
+			continue
+		}
+		gv, err := schema.ParseGroupVersion(l.GroupVersion)
+		if err != nil {
+			// The API server should never return a malformed group version.
+			continue
+		}
+		for _, r := range l.APIResources {
+			if strings.Contains(r.Name, "/") {
+				continue
+			}
+
+			// A resource may be served from a different group or version than
+			// the list it's discovered in.
+			rgv := gv
+			if r.Group != "" {
+				rgv.Group = r.Group
+			}
+			if r.Version != "" {
+				rgv.Version = r.Version
+			}
+
+			res := APIResource{
+				Group:            rgv.Group,
+				Version:          rgv.Version,
+				APIVersion:       rgv.String(),
+				Kind:             r.Kind,
+				Name:             r.Name,
+				Scope:            ResourceScopeClusterScoped,
+				Verbs:            r.Verbs,
+				Categories:       r.Categories,
+				ShortNames:       r.ShortNames,
+				PreferredVersion: preferred[rgv.Group] == rgv.Version,
+			}
+			if res.Verbs == nil {
+				res.Verbs = []string{}
+			}
+			if r.Namespaced {
+				res.Scope = ResourceScopeNamespaceScoped
+			}
+			if r.SingularName != "" {
+				res.SingularName = ptr.To(r.SingularName)
+			}
+			out = append(out, res)
+		}
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		switch {
+		case out[i].Group != out[j].Group:
+			return out[i].Group < out[j].Group
+		case out[i].Version != out[j].Version:
+			return out[i].Version < out[j].Version
+		}
+		return out[i].Kind < out[j].Kind
+	})
+	return out
+}
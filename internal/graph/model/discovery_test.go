@@ -0,0 +1,63 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetAPIResources(t *testing.T) {
+	groups := []*metav1.APIGroup{
+		{Name: "", PreferredVersion: metav1.GroupVersionForDiscovery{Version: "v1"}},
+		{Name: "example.org", PreferredVersion: metav1.GroupVersionForDiscovery{Version: "v1"}},
+	}
+	lists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "example.org/v1beta1",
+			APIResources: []metav1.APIResource{
+				{Name: "buckets", Kind: "Bucket", Verbs: []string{"get", "list"}, Categories: []string{"managed"}},
+			},
+		},
+		{
+			GroupVersion: "example.org/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "buckets", SingularName: "bucket", Kind: "Bucket", Verbs: []string{"get", "list"}, Categories: []string{"managed"}},
+				{Name: "buckets/status", Kind: "Bucket", Verbs: []string{"get"}},
+			},
+		},
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: []string{"get"}, ShortNames: []string{"sec"}},
+				{Name: "bindings", Kind: "Binding", Namespaced: true},
+			},
+		},
+	}
+
+	want := []APIResource{
+		{Group: "", Version: "v1", APIVersion: "v1", Kind: "Binding", Name: "bindings", Scope: ResourceScopeNamespaceScoped, Verbs: []string{}, PreferredVersion: true},
+		{Group: "", Version: "v1", APIVersion: "v1", Kind: "Secret", Name: "secrets", Scope: ResourceScopeNamespaceScoped, Verbs: []string{"get"}, ShortNames: []string{"sec"}, PreferredVersion: true},
+		{Group: "example.org", Version: "v1", APIVersion: "example.org/v1", Kind: "Bucket", Name: "buckets", SingularName: ptr.To("bucket"), Scope: ResourceScopeClusterScoped, Verbs: []string{"get", "list"}, Categories: []string{"managed"}, PreferredVersion: true},
+		{Group: "example.org", Version: "v1beta1", APIVersion: "example.org/v1beta1", Kind: "Bucket", Name: "buckets", Scope: ResourceScopeClusterScoped, Verbs: []string{"get", "list"}, Categories: []string{"managed"}},
+	}
+
+	if diff := cmp.Diff(want, GetAPIResources(groups, lists)); diff != "" {
+		t.Errorf("\nGetAPIResources(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+// Filter errors.
+const (
+	errFilterNoField   = "a field is required by the eq, ne, in, exists and regex predicates"
+	errFmtFilterField  = "cannot parse field %q"
+	errFmtFilterRegex  = "cannot compile regex %q"
+	errFilterUnpavable = "cannot filter resource without paved access"
+)
+
+// paved is implemented by every KubernetesResource by way of the embedded
+// PavedAccess.
+type paved interface {
+	paved() *fieldpath.Paved
+}
+
+func (f PavedAccess) paved() *fieldpath.Paved {
+	return f.Paved
+}
+
+// A ResourceMatcher matches objects against a ResourceFilter. Its field paths
+// are parsed and its regular expressions compiled once, when the filter is
+// compiled, rather than for every object it's matched against.
+type ResourceMatcher struct {
+	f     *ResourceFilter
+	field fieldpath.Segments
+	regex *regexp.Regexp
+	and   []*ResourceMatcher
+	or    []*ResourceMatcher
+	not   *ResourceMatcher
+}
+
+// Compile the filter into a ResourceMatcher, returning an error if any of its
+// fields or regular expressions are invalid. A nil filter compiles to a nil
+// matcher, which matches every object.
+func (f *ResourceFilter) Compile() (*ResourceMatcher, error) {
+	if f == nil {
+		return nil, nil
+	}
+
+	m := &ResourceMatcher{f: f}
+
+	if f.hasPredicate() {
+		if f.Field == nil || *f.Field == "" {
+			return nil, errors.New(errFilterNoField)
+		}
+		s, err := fieldpath.Parse(*f.Field)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtFilterField, *f.Field)
+		}
+		m.field = s
+	}
+
+	if f.Regex != nil {
+		re, err := regexp.Compile(*f.Regex)
+		if err != nil {
+			return nil, errors.Wrapf(err, errFmtFilterRegex, *f.Regex)
+		}
+		m.regex = re
+	}
+
+	for i := range f.And {
+		c, err := f.And[i].Compile()
+		if err != nil {
+			return nil, err
+		}
+		m.and = append(m.and, c)
+	}
+
+	for i := range f.Or {
+		c, err := f.Or[i].Compile()
+		if err != nil {
+			return nil, err
+		}
+		m.or = append(m.or, c)
+	}
+
+	if f.Not != nil {
+		c, err := f.Not.Compile()
+		if err != nil {
+			return nil, err
+		}
+		m.not = c
+	}
+
+	return m, nil
+}
+
+func (f *ResourceFilter) hasPredicate() bool {
+	return f.Eq != nil || f.Ne != nil || f.In != nil || f.Exists != nil || f.Regex != nil
+}
+
+// MatchesResource returns true if the supplied resource matches the filter. A
+// nil matcher matches every resource.
+func (m *ResourceMatcher) MatchesResource(r KubernetesResource) (bool, error) {
+	if m == nil {
+		return true, nil
+	}
+	p, ok := r.(paved)
+	if !ok {
+		return false, errors.New(errFilterUnpavable)
+	}
+	return m.Matches(p.paved()), nil
+}
+
+// Matches returns true if the supplied object matches the filter. A nil matcher
+// matches every object. Fields that cannot be found in the object, including
+// fields that would require traversing a value of the wrong type, are treated
+// as not existing.
+func (m *ResourceMatcher) Matches(p *fieldpath.Paved) bool {
+	if m == nil {
+		return true
+	}
+
+	if m.f.hasPredicate() && !m.matchesField(p) {
+		return false
+	}
+
+	for _, c := range m.and {
+		if !c.Matches(p) {
+			return false
+		}
+	}
+
+	if len(m.or) > 0 {
+		matched := false
+		for _, c := range m.or {
+			if c.Matches(p) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	if m.not != nil && m.not.Matches(p) {
+		return false
+	}
+
+	return true
+}
+
+// matchesField returns true if the object matches all of the filter's field
+// predicates.
+func (m *ResourceMatcher) matchesField(p *fieldpath.Paved) bool { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a series of simple predicates.
+
+	f := m.f
+	v, exists := get(p.UnstructuredContent(), m.field)
+
+	if f.Exists != nil && *f.Exists != exists {
+		return false
+	}
+
+	if f.Eq != nil && (!exists || !equal(v, f.Eq)) {
+		return false
+	}
+
+	if f.Ne != nil && exists && equal(v, f.Ne) {
+		return false
+	}
+
+	if f.In != nil {
+		if !exists {
+			return false
+		}
+		in := false
+		for _, want := range f.In {
+			if equal(v, want) {
+				in = true
+				break
+			}
+		}
+		if !in {
+			return false
+		}
+	}
+
+	if m.regex != nil {
+		s, ok := v.(string)
+		if !exists || !ok || !m.regex.MatchString(s) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// get returns the value at the supplied path, and whether it exists. It
+// behaves like fieldpath.Paved's GetValue, but takes an already parsed path.
+func get(v any, path fieldpath.Segments) (any, bool) {
+	for _, s := range path {
+		switch s.Type {
+		case fieldpath.SegmentField:
+			o, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			v, ok = o[s.Field]
+			if !ok {
+				return nil, false
+			}
+		case fieldpath.SegmentIndex:
+			a, ok := v.([]any)
+			if !ok || s.Index >= uint(len(a)) {
+				return nil, false
+			}
+			v = a[s.Index]
+		}
+	}
+	return v, true
+}
+
+// equal returns true if the supplied value is equal to the supplied JSON. The
+// value is round-tripped through JSON so that, for example, an int64 field
+// compares equal to the JSON number 1.
+func equal(v any, want []byte) bool {
+	got, err := json.Marshal(v)
+	if err != nil {
+		return false
+	}
+	var a, b any
+	if err := json.Unmarshal(got, &a); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(want, &b); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,168 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+func TestResourceFilterMatches(t *testing.T) {
+	obj := fieldpath.Pave(map[string]any{
+		"spec": map[string]any{
+			"forProvider": map[string]any{
+				"region": "us-east-1",
+				"size":   int64(3),
+			},
+		},
+		"status": map[string]any{
+			"atProvider": map[string]any{
+				"state": "available",
+			},
+		},
+	})
+
+	region := ptr.To("spec.forProvider.region")
+
+	cases := map[string]struct {
+		reason  string
+		f       *ResourceFilter
+		want    bool
+		wantErr bool
+	}{
+		"Nil": {
+			reason: "A nil filter should match every object.",
+			want:   true,
+		},
+		"Eq": {
+			reason: "A field equal to the eq value should match.",
+			f:      &ResourceFilter{Field: region, Eq: []byte(`"us-east-1"`)},
+			want:   true,
+		},
+		"EqNumber": {
+			reason: "Numbers should be compared by value, not by Go type.",
+			f:      &ResourceFilter{Field: ptr.To("spec.forProvider.size"), Eq: []byte(`3`)},
+			want:   true,
+		},
+		"EqMissing": {
+			reason: "A field that doesn't exist should not equal anything.",
+			f:      &ResourceFilter{Field: ptr.To("spec.forProvider.zone"), Eq: []byte(`"a"`)},
+			want:   false,
+		},
+		"Ne": {
+			reason: "A field equal to the ne value should not match.",
+			f:      &ResourceFilter{Field: region, Ne: []byte(`"us-east-1"`)},
+			want:   false,
+		},
+		"NeMissing": {
+			reason: "A field that doesn't exist should not equal the ne value.",
+			f:      &ResourceFilter{Field: ptr.To("spec.forProvider.zone"), Ne: []byte(`"a"`)},
+			want:   true,
+		},
+		"In": {
+			reason: "A field equal to one of the in values should match.",
+			f:      &ResourceFilter{Field: ptr.To("status.atProvider.state"), In: [][]byte{[]byte(`"pending"`), []byte(`"available"`)}},
+			want:   true,
+		},
+		"NotIn": {
+			reason: "A field equal to none of the in values should not match.",
+			f:      &ResourceFilter{Field: ptr.To("status.atProvider.state"), In: [][]byte{[]byte(`"pending"`)}},
+			want:   false,
+		},
+		"Exists": {
+			reason: "A field that exists should match exists: true.",
+			f:      &ResourceFilter{Field: region, Exists: ptr.To(true)},
+			want:   true,
+		},
+		"DoesNotExist": {
+			reason: "A field that doesn't exist should match exists: false.",
+			f:      &ResourceFilter{Field: ptr.To("spec.forProvider.region.nested"), Exists: ptr.To(false)},
+			want:   true,
+		},
+		"Regex": {
+			reason: "A string field matching the regex should match.",
+			f:      &ResourceFilter{Field: region, Regex: ptr.To("^us-")},
+			want:   true,
+		},
+		"RegexNotString": {
+			reason: "A field that isn't a string should not match a regex.",
+			f:      &ResourceFilter{Field: ptr.To("spec.forProvider.size"), Regex: ptr.To(".*")},
+			want:   false,
+		},
+		"And": {
+			reason: "An object should match only if it matches every and filter.",
+			f: &ResourceFilter{And: []ResourceFilter{
+				{Field: region, Eq: []byte(`"us-east-1"`)},
+				{Field: ptr.To("status.atProvider.state"), Eq: []byte(`"pending"`)},
+			}},
+			want: false,
+		},
+		"Or": {
+			reason: "An object should match if it matches any or filter.",
+			f: &ResourceFilter{Or: []ResourceFilter{
+				{Field: region, Eq: []byte(`"eu-west-1"`)},
+				{Field: ptr.To("status.atProvider.state"), Eq: []byte(`"available"`)},
+			}},
+			want: true,
+		},
+		"Not": {
+			reason: "An object should match only if it doesn't match the not filter.",
+			f:      &ResourceFilter{Not: &ResourceFilter{Field: region, Eq: []byte(`"us-east-1"`)}},
+			want:   false,
+		},
+		"NoField": {
+			reason:  "A predicate without a field should return an error.",
+			f:       &ResourceFilter{Eq: []byte(`"us-east-1"`)},
+			wantErr: true,
+		},
+		"InvalidField": {
+			reason:  "A field that can't be parsed should return an error.",
+			f:       &ResourceFilter{Field: ptr.To("spec..region"), Exists: ptr.To(true)},
+			wantErr: true,
+		},
+		"InvalidRegex": {
+			reason:  "A regex that can't be compiled should return an error.",
+			f:       &ResourceFilter{Field: region, Regex: ptr.To("(")},
+			wantErr: true,
+		},
+		"InvalidNestedRegex": {
+			reason: "A regex that can't be compiled should return an error even if an earlier or filter would match.",
+			f: &ResourceFilter{Or: []ResourceFilter{
+				{Field: region, Eq: []byte(`"us-east-1"`)},
+				{Field: region, Regex: ptr.To("(")},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m, err := tc.f.Compile()
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("\n%s\nCompile(): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want, m.Matches(obj)); diff != "" {
+				t.Errorf("\n%s\nMatches(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
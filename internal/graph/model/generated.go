@@ -46,6 +46,78 @@ type ProviderConfigDefinition interface {
 	IsProviderConfigDefinition()
 }
 
+// An APIResource is a kind of resource served by the Kubernetes API server.
+type APIResource struct {
+	// The API group of this kind of resource. The core API group is empty.
+	Group string `json:"group"`
+	// The API version of this kind of resource, without its group, e.g. `v1`.
+	Version string `json:"version"`
+	// The API version of this kind of resource, including its group.
+	APIVersion string `json:"apiVersion"`
+	// The kind of this resource, e.g. `Deployment`.
+	Kind string `json:"kind"`
+	// The plural name of this kind of resource, e.g. `deployments`.
+	Name string `json:"name"`
+	// The singular name of this kind of resource, e.g. `deployment`.
+	SingularName *string `json:"singularName,omitempty"`
+	// The scope of this kind of resource.
+	Scope ResourceScope `json:"scope"`
+	// The verbs this kind of resource supports, e.g. `get`, `list` and `watch`.
+	Verbs []string `json:"verbs"`
+	// The categories this kind of resource belongs to, e.g. `managed`.
+	Categories []string `json:"categories,omitempty"`
+	// Short names for this kind of resource, e.g. `deploy`.
+	ShortNames []string `json:"shortNames,omitempty"`
+	// Whether this is the preferred version of the API group.
+	PreferredVersion bool `json:"preferredVersion"`
+}
+
+// ApplyKubernetesResourceInput is the input required to apply a Kubernetes
+// resource.
+type ApplyKubernetesResourceInput struct {
+	// The Kubernetes resource to be applied, as raw JSON. It should include only
+	// the fields the field manager has an opinion about.
+	Unstructured []byte `json:"unstructured"`
+	// Patches that should be applied to the Kubernetes resource before applying.
+	Patches []Patch `json:"patches,omitempty"`
+}
+
+// ApplyKubernetesResourcePayload is the result of applying a Kubernetes resource.
+type ApplyKubernetesResourcePayload struct {
+	// The applied Kubernetes resource. Null if the apply failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// The RFC 6902 JSON patch operations that would transform the current resource
+	// into the applied resource. Only set for a dry run.
+	Diff []JSONPatchOperation `json:"diff,omitempty"`
+}
+
+// A ComposedTemplate is used to compose a resource in RESOURCES mode.
+type ComposedTemplate struct {
+	// A name that uniquely identifies this template within its composition.
+	Name *string `json:"name,omitempty"`
+	// The base resource that patches will be applied to.
+	Base []byte `json:"base,omitempty"`
+	// Patches that will be applied to the base resource.
+	Patches []ComposedTemplatePatch `json:"patches,omitempty"`
+}
+
+// A ComposedTemplatePatch copies data between a composite resource and a
+// composed resource, optionally transforming it.
+type ComposedTemplatePatch struct {
+	// The type of this patch.
+	Type ComposedTemplatePatchType `json:"type"`
+	// The field path this patch copies data from.
+	FromFieldPath *string `json:"fromFieldPath,omitempty"`
+	// The field path this patch copies data to.
+	ToFieldPath *string `json:"toFieldPath,omitempty"`
+	// The name of the patch set to apply, for PATCH_SET patches.
+	PatchSetName *string `json:"patchSetName,omitempty"`
+	// How to combine multiple fields, for COMBINE_* patches.
+	Combine []byte `json:"combine,omitempty"`
+	// Transforms applied to the data before it is copied, in order.
+	Transforms [][]byte `json:"transforms,omitempty"`
+}
+
 // A CompositeResource is a resource this is reconciled by composing other
 // composite or managed resources. Composite resources use a Composition to
 // determine which resources to compose, and how.
@@ -136,8 +208,14 @@ type CompositeResource struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 	// The definition of this resource.
 	Definition *CompositeResourceDefinition `json:"definition,omitempty"`
+	// The ancestors of this resource, ordered from its parent to its root.
+	Ancestors KubernetesResourceConnection `json:"ancestors"`
 }
 
 func (CompositeResource) IsNode() {}
@@ -232,6 +310,10 @@ type CompositeResourceClaim struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 	// The definition of this resource.
 	Definition *CompositeResourceDefinition `json:"definition,omitempty"`
 }
@@ -245,6 +327,10 @@ func (CompositeResourceClaim) IsKubernetesResource() {}
 type CompositeResourceClaimConnection struct {
 	// Connected nodes.
 	Nodes []CompositeResourceClaim `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CompositeResourceClaimEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
@@ -257,6 +343,15 @@ type CompositeResourceClaimConnectionDetails struct {
 	LastPublishedTime *time.Time `json:"lastPublishedTime,omitempty"`
 }
 
+// A CompositeResourceClaimEdge represents a node and its position within a
+// CompositeResourceClaimConnection.
+type CompositeResourceClaimEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node CompositeResourceClaim `json:"node"`
+}
+
 // A CompositeResourceClaimStatus represents the observed status of a composite
 // resource claim.
 type CompositeResourceClaimStatus struct {
@@ -272,6 +367,10 @@ func (CompositeResourceClaimStatus) IsConditionedStatus() {}
 type CompositeResourceConnection struct {
 	// Connected nodes.
 	Nodes []CompositeResource `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CompositeResourceEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
@@ -373,6 +472,10 @@ type CompositeResourceDefinition struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 	// The generated `CustomResourceDefinition` for this XRD
 	CompositeResourceCrd *CustomResourceDefinition `json:"compositeResourceCRD,omitempty"`
 	// The generated `CustomResourceDefinition` of this XRDs `CompositeClaim` if defined
@@ -392,6 +495,10 @@ func (CompositeResourceDefinition) IsKubernetesResource() {}
 type CompositeResourceDefinitionConnection struct {
 	// Connected nodes.
 	Nodes []CompositeResourceDefinition `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CompositeResourceDefinitionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
@@ -413,6 +520,15 @@ type CompositeResourceDefinitionControllerStatus struct {
 	CompositeResourceClaimType *TypeReference `json:"compositeResourceClaimType,omitempty"`
 }
 
+// A CompositeResourceDefinitionEdge represents a node and its position within a
+// CompositeResourceDefinitionConnection.
+type CompositeResourceDefinitionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node CompositeResourceDefinition `json:"node"`
+}
+
 // CompositeResourceDefinitionNames specifies the resource and kind names of the
 // defined composite resource or claim.
 type CompositeResourceDefinitionNames struct {
@@ -467,6 +583,15 @@ type CompositeResourceDefinitionVersion struct {
 	Schema *CompositeResourceValidation `json:"schema,omitempty"`
 }
 
+// A CompositeResourceEdge represents a node and its position within a
+// CompositeResourceConnection.
+type CompositeResourceEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node CompositeResource `json:"node"`
+}
+
 // A CompositeResourceClaimStatus represents the observed state of a composite
 // resource.
 type CompositeResourceStatus struct {
@@ -574,6 +699,12 @@ type Composition struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// Revisions of this composition.
+	Revisions CompositionRevisionConnection `json:"revisions"`
 }
 
 func (Composition) IsNode() {}
@@ -584,10 +715,169 @@ func (Composition) IsKubernetesResource() {}
 type CompositionConnection struct {
 	// Connected nodes.
 	Nodes []Composition `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CompositionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A CompositionEdge represents a node and its position within a
+// CompositionConnection.
+type CompositionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Composition `json:"node"`
+}
+
+// A CompositionRevision is an immutable snapshot of a Composition. Composite
+// resources may be pinned to a particular revision of their Composition.
+type CompositionRevision struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec CompositionRevisionSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *CompositionRevisionStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The composition this is a revision of.
+	Composition *Composition `json:"composition,omitempty"`
+}
+
+func (CompositionRevision) IsNode() {}
+
+func (CompositionRevision) IsKubernetesResource() {}
+
+// A CompositionRevisionConnection represents a connection to composition
+// revisions.
+type CompositionRevisionConnection struct {
+	// Connected nodes.
+	Nodes []CompositionRevision `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CompositionRevisionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A CompositionRevisionEdge represents a node and its position within a
+// CompositionRevisionConnection.
+type CompositionRevisionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node CompositionRevision `json:"node"`
+}
+
+// A CompositionRevisionSpec represents the desired state of a composition
+// revision.
+type CompositionRevisionSpec struct {
+	// CompositeTypeRef specifies the type of composite resource that this
+	// composition revision is compatible with.
+	CompositeTypeRef TypeReference `json:"compositeTypeRef"`
+	// WriteConnectionSecretsToNamespace specifies the namespace in which the
+	// connection secrets of composite resource dynamically provisioned using this
+	// composition revision will be created.
+	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
+	// Revision number. Newer revisions have larger numbers.
+	Revision int `json:"revision"`
+}
+
+// A CompositionRevisionStatus represents the observed state of a composition
+// revision.
+type CompositionRevisionStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+func (CompositionRevisionStatus) IsConditionedStatus() {}
+
 // A CompositionSpec represents the desired state of a composition.
 type CompositionSpec struct {
 	// CompositeTypeRef specifies the type of composite resource that this
@@ -597,6 +887,18 @@ type CompositionSpec struct {
 	// connection secrets of composite resource dynamically provisioned using this
 	// composition will be created.
 	WriteConnectionSecretsToNamespace *string `json:"writeConnectionSecretsToNamespace,omitempty"`
+	// Mode controls what type or 'mode' of composition will be used. Compositions
+	// in RESOURCES mode use resource templates, while compositions in PIPELINE mode
+	// use a pipeline of composition functions.
+	Mode *CompositionMode `json:"mode,omitempty"`
+	// Resources is a list of resource templates that will be used when a composite
+	// resource referring to this composition is created. Only used in RESOURCES
+	// mode.
+	Resources []ComposedTemplate `json:"resources,omitempty"`
+	// Pipeline is a list of composition function steps that will be used when a
+	// composite resource referring to this composition is created. Only used in
+	// PIPELINE mode.
+	Pipeline []PipelineStep `json:"pipeline,omitempty"`
 }
 
 // A CompositionStatus represents the observed state of a composition.
@@ -627,6 +929,21 @@ type Condition struct {
 	Message *string `json:"message,omitempty"`
 }
 
+// A `ConditionSummary` counts the resources with a particular type of condition
+// by the condition's status and reason.
+type ConditionSummary struct {
+	// The number of resources whose condition is `True`.
+	TrueCount int `json:"trueCount"`
+	// The number of resources whose condition is `False`.
+	FalseCount int `json:"falseCount"`
+	// The number of resources whose condition is `Unknown`.
+	UnknownCount int `json:"unknownCount"`
+	// The number of resources that don't have the condition.
+	AbsentCount int `json:"absentCount"`
+	// The number of resources by the condition's reason, most common first.
+	Reasons []ReasonCount `json:"reasons"`
+}
+
 // A ConfigMap holds configuration data.
 type ConfigMap struct {
 	// An opaque identifier that is unique across all types.
@@ -713,6 +1030,10 @@ type ConfigMap struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 }
 
 func (ConfigMap) IsNode() {}
@@ -807,10 +1128,20 @@ type Configuration struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 	// Revisions of this configuration.
 	Revisions ConfigurationRevisionConnection `json:"revisions"`
 	// The active revision of this configuration.
 	ActiveRevision *ConfigurationRevision `json:"activeRevision,omitempty"`
+	// The packages this configuration depends on, according to the package
+	// lock.
+	Dependencies []LockDependency `json:"dependencies,omitempty"`
+	// The packages that depend on this configuration, according to the package
+	// lock.
+	Dependents []LockPackage `json:"dependents,omitempty"`
 }
 
 func (Configuration) IsNode() {}
@@ -821,10 +1152,29 @@ func (Configuration) IsKubernetesResource() {}
 type ConfigurationConnection struct {
 	// Connected nodes.
 	Nodes []Configuration `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ConfigurationEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A ConfigurationEdge represents a node and its position within a
+// ConfigurationConnection.
+type ConfigurationEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Configuration `json:"node"`
+}
+
+// ConfigurationPayload is the result of a configuration mutation.
+type ConfigurationPayload struct {
+	// The mutated configuration. Null if the mutation failed.
+	Configuration *Configuration `json:"configuration,omitempty"`
+}
+
 // A ConfigurationRevision represents a revision or 'version' of a configuration.
 type ConfigurationRevision struct {
 	// An opaque identifier that is unique across all types.
@@ -913,6 +1263,16 @@ type ConfigurationRevision struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The packages this configuration revision depends on, according to the package
+	// lock.
+	Dependencies []LockDependency `json:"dependencies,omitempty"`
+	// The packages that depend on this configuration revision, according to the
+	// package lock.
+	Dependents []LockPackage `json:"dependents,omitempty"`
 }
 
 func (ConfigurationRevision) IsNode() {}
@@ -924,10 +1284,23 @@ func (ConfigurationRevision) IsKubernetesResource() {}
 type ConfigurationRevisionConnection struct {
 	// Connected nodes.
 	Nodes []ConfigurationRevision `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ConfigurationRevisionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A ConfigurationRevisionEdge represents a node and its position within a
+// ConfigurationRevisionConnection.
+type ConfigurationRevisionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node ConfigurationRevision `json:"node"`
+}
+
 // A ConfigurationRevisionSpec represents the desired state of a configuration
 // revision.
 type ConfigurationRevisionSpec struct {
@@ -1013,6 +1386,162 @@ type ConfigurationStatus struct {
 
 func (ConfigurationStatus) IsConditionedStatus() {}
 
+// A ContainerState represents the state of a container at a point in time.
+type ContainerState struct {
+	// Whether the container is waiting, running or terminated.
+	Status ContainerStateStatus `json:"status"`
+	// A brief, machine readable reason the container is in this state.
+	Reason *string `json:"reason,omitempty"`
+	// A human readable message explaining why the container is in this state.
+	Message *string `json:"message,omitempty"`
+	// The exit code of a terminated container.
+	ExitCode *int `json:"exitCode,omitempty"`
+	// The time at which the container started running.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// The time at which a terminated container finished running.
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+}
+
+// A ContainerStatus represents the observed state of a container.
+type ContainerStatus struct {
+	// The name of the container.
+	Name string `json:"name"`
+	// The image the container is running.
+	Image string `json:"image"`
+	// Whether the container is passing its readiness probe.
+	Ready bool `json:"ready"`
+	// Whether the container has started and passed its startup probe.
+	Started *bool `json:"started,omitempty"`
+	// The number of times the container has restarted.
+	RestartCount int `json:"restartCount"`
+	// The current state of the container.
+	State *ContainerState `json:"state,omitempty"`
+	// The state of the container when it last terminated.
+	LastTerminationState *ContainerState `json:"lastTerminationState,omitempty"`
+}
+
+// A ControllerConfig configures the Deployment of a provider or function package.
+// ControllerConfigs are deprecated in favor of DeploymentRuntimeConfigs.
+type ControllerConfig struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec ControllerConfigSpec `json:"spec"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+}
+
+func (ControllerConfig) IsNode() {}
+
+func (ControllerConfig) IsKubernetesResource() {}
+
+// A ControllerConfigSpec represents the desired state of a ControllerConfig.
+// Fields that aren't modelled may be read using `fieldPath`.
+type ControllerConfigSpec struct {
+	// The number of desired replicas of the package's Deployment.
+	Replicas *int `json:"replicas,omitempty"`
+	// The image that overrides the package's controller image.
+	Image *string `json:"image,omitempty"`
+	// The image pull policy of the package's controller container.
+	ImagePullPolicy *string `json:"imagePullPolicy,omitempty"`
+	// The name of the ServiceAccount the package's Deployment runs as.
+	ServiceAccountName *string `json:"serviceAccountName,omitempty"`
+	// The node selector of the package's Deployment.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// The priority class name of the package's Deployment.
+	PriorityClassName *string `json:"priorityClassName,omitempty"`
+	// The compute resource requirements of the package's controller container.
+	Resources []byte `json:"resources,omitempty"`
+	// The pod-level security context of the package's Deployment.
+	PodSecurityContext []byte `json:"podSecurityContext,omitempty"`
+	// The security context of the package's controller container.
+	SecurityContext []byte `json:"securityContext,omitempty"`
+	// Arguments supplied to the package's controller container.
+	Args []string `json:"args,omitempty"`
+}
+
 // CreateKubernetesResourceInput is the input required to create a Kubernetes
 // resource.
 type CreateKubernetesResourceInput struct {
@@ -1026,16 +1555,32 @@ type CreateKubernetesResourceInput struct {
 type CreateKubernetesResourcePayload struct {
 	// The created Kubernetes resource. Null if the create failed.
 	Resource KubernetesResource `json:"resource,omitempty"`
+	// The RFC 6902 JSON patch operations that would transform the current resource
+	// into the created resource. Only set for a dry run.
+	Diff []JSONPatchOperation `json:"diff,omitempty"`
 }
 
 // A `CrossplaneResourceTreeConnection` represents a connection to `CrossplaneResourceTreeNode`s
 type CrossplaneResourceTreeConnection struct {
 	// Connected nodes.
 	Nodes []CrossplaneResourceTreeNode `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CrossplaneResourceTreeEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A CrossplaneResourceTreeEdge represents a node and its position within a
+// CrossplaneResourceTreeConnection.
+type CrossplaneResourceTreeEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node CrossplaneResourceTreeNode `json:"node"`
+}
+
 // An `CrossplaneResourceTreeNode` is an `KubernetesResource` with a `ID` of its parent
 // `CrossplaneResource`.
 //
@@ -1043,6 +1588,10 @@ type CrossplaneResourceTreeConnection struct {
 type CrossplaneResourceTreeNode struct {
 	// The `ID` of the parent `KubernetesResource` (`NULL` is the root of the tree)
 	ParentID *ReferenceID `json:"parentId,omitempty"`
+	// How this node's resource relates to its parent (`NULL` is the root of the
+	// tree). If the parent was filtered out this is how the resource relates to
+	// that parent, not to the node's `parentId`.
+	Relation *CrossplaneResourceTreeRelation `json:"relation,omitempty"`
 	// The `KubernetesResource` object of this `CrossplaneResourceTreeNode`
 	Resource KubernetesResource `json:"resource"`
 }
@@ -1136,6 +1685,10 @@ type CustomResourceDefinition struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 	// Custom resources defined by this CRD
 	DefinedResources KubernetesResourceConnection `json:"definedResources"`
 }
@@ -1153,10 +1706,23 @@ func (CustomResourceDefinition) IsProviderConfigDefinition() {}
 type CustomResourceDefinitionConnection struct {
 	// Connected nodes.
 	Nodes []CustomResourceDefinition `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []CustomResourceDefinitionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A CustomResourceDefinitionEdge represents a node and its position within a
+// CustomResourceDefinitionConnection.
+type CustomResourceDefinitionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node CustomResourceDefinition `json:"node"`
+}
+
 // CustomResourceDefinitionNames specifies the resource and kind names of the
 // defined custom resource.
 type CustomResourceDefinitionNames struct {
@@ -1240,6 +1806,14 @@ type DefinedCompositeResourceClaimOptionsInput struct {
 	// If `true` return resources that have `Condition` `Ready` `True`.
 	// If `false` return resources that have `Condition` `Ready` `False` or `Condition` `Ready` not present
 	Ready *bool `json:"ready,omitempty"`
+	// Only return resources matching this label selector.
+	LabelSelector *LabelSelectorInput `json:"labelSelector,omitempty"`
+	// Only return resources matching this field selector, for example
+	// `metadata.name=example,status.phase!=Running`. Supports the `=`, `==` and
+	// `!=` operators against any field path.
+	FieldSelector *string `json:"fieldSelector,omitempty"`
+	// Only return resources matching this filter.
+	Where *ResourceFilter `json:"where,omitempty"`
 }
 
 // Options to filter or limit the defined composite resources
@@ -1250,16 +1824,28 @@ type DefinedCompositeResourceOptionsInput struct {
 	// If `true` return resources that have `Condition` `Ready` `True`.
 	// If `false` return resources that have `Condition` `Ready` `False` or `Condition` `Ready` not present
 	Ready *bool `json:"ready,omitempty"`
+	// Only return resources matching this label selector.
+	LabelSelector *LabelSelectorInput `json:"labelSelector,omitempty"`
+	// Only return resources matching this field selector, for example
+	// `metadata.name=example,status.phase!=Running`. Supports the `=`, `==` and
+	// `!=` operators against any field path.
+	FieldSelector *string `json:"fieldSelector,omitempty"`
+	// Only return resources matching this filter.
+	Where *ResourceFilter `json:"where,omitempty"`
 }
 
 // DeleteKubernetesResourcePayload is the result of deleting a Kubernetes resource.
 type DeleteKubernetesResourcePayload struct {
 	// The deleted Kubernetes resource. Null if the delete failed.
 	Resource KubernetesResource `json:"resource,omitempty"`
+	// The RFC 6902 JSON patch operations that would remove the current resource.
+	// Only set for a dry run.
+	Diff []JSONPatchOperation `json:"diff,omitempty"`
 }
 
-// An event pertaining to a Kubernetes resource.
-type Event struct {
+// A DeploymentRuntimeConfig configures the runtime of a provider or function
+// package, including the Deployment, Service and ServiceAccount that run it.
+type DeploymentRuntimeConfig struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1268,23 +1854,9 @@ type Event struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
-	// The Kubernetes resource this event pertains to.
-	InvolvedObject KubernetesResource `json:"involvedObject"`
-	// The type of event.
-	Type *EventType `json:"type,omitempty"`
-	// The reason the event was emitted.
-	Reason *string `json:"reason,omitempty"`
-	// Details about the event, if any.
-	Message *string `json:"message,omitempty"`
-	// The source of the event - e.g. a controller.
-	Source *EventSource `json:"source,omitempty"`
-	// The number of times this event has occurred.
-	Count *int `json:"count,omitempty"`
-	// The time at which this event was first recorded.
-	FirstTime *time.Time `json:"firstTime,omitempty"`
-	// The time at which this event was most recently recorded.
-	LastTime *time.Time `json:"lastTime,omitempty"`
-	// An unstructured JSON representation of the event.
+	// The desired state of this resource.
+	Spec DeploymentRuntimeConfigSpec `json:"spec"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
 	//
@@ -1355,32 +1927,34 @@ type Event struct {
 	// ```
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
-	PavedAccess       `json:"fieldPath"`
-	InvolvedObjectRef v11.ObjectReference `json:"-"`
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 }
 
-func (Event) IsNode() {}
+func (DeploymentRuntimeConfig) IsNode() {}
 
-// An EventConnection represents a connection to events.
-type EventConnection struct {
-	// Connected nodes.
-	Nodes []Event `json:"nodes,omitempty"`
-	// The total number of connected nodes.
-	TotalCount int `json:"totalCount"`
-}
+func (DeploymentRuntimeConfig) IsKubernetesResource() {}
 
-// An EventSource is the source of an event. Note that in this context 'source'
-// indicates the software or system that emitted the event, not the Kubernetes
-// resource it pertains to.
-type EventSource struct {
-	// The software component that emitted the event.
-	Component *string `json:"component,omitempty"`
+// A DeploymentRuntimeConfigSpec represents the desired state of a
+// DeploymentRuntimeConfig.
+type DeploymentRuntimeConfigSpec struct {
+	// A template for the Deployment that runs the package, including its metadata
+	// and Deployment spec.
+	DeploymentTemplate []byte `json:"deploymentTemplate,omitempty"`
+	// A template for the Service that exposes the package.
+	ServiceTemplate []byte `json:"serviceTemplate,omitempty"`
+	// A template for the ServiceAccount that the package runs as.
+	ServiceAccountTemplate []byte `json:"serviceAccountTemplate,omitempty"`
 }
 
-// A GenericResource represents a kind of Kubernetes resource that does not
-// correspond to a kind or class of resources that is more specifically modelled
-// by xgql.
-type GenericResource struct {
+// An EnvironmentConfig contains data that compositions may use to compose
+// resources. Its data may be read using `fieldPath`, for example `data.region`.
+type EnvironmentConfig struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1463,37 +2037,39 @@ type GenericResource struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
 }
 
-func (GenericResource) IsNode() {}
+func (EnvironmentConfig) IsNode() {}
 
-func (GenericResource) IsKubernetesResource() {}
+func (EnvironmentConfig) IsKubernetesResource() {}
 
-// A KubernetesResourceConnection represents a connection to Kubernetes resources.
-type KubernetesResourceConnection struct {
+// An EnvironmentConfigConnection represents a connection to environment configs.
+type EnvironmentConfigConnection struct {
 	// Connected nodes.
-	Nodes []KubernetesResource `json:"nodes,omitempty"`
+	Nodes []EnvironmentConfig `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []EnvironmentConfigEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
-// A LabelSelector matches a Kubernetes resource by labels.
-type LabelSelector struct {
-	// The labels to match on.
-	MatchLabels map[string]string `json:"matchLabels,omitempty"`
-}
-
-// `LocalObjectReference` contains a name to to let you inspect or modify the
-// locally referred object.
-type LocalObjectReference struct {
-	// Name of the referent.
-	Name string `json:"name"`
+// An EnvironmentConfigEdge represents a node and its position within an
+// EnvironmentConfigConnection.
+type EnvironmentConfigEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node EnvironmentConfig `json:"node"`
 }
 
-// A ManagedResource is a Kubernetes API representation of a resource in an
-// external system, such as a cloud provider's API. Crossplane providers add
-// support for new kinds of managed resource.
-type ManagedResource struct {
+// An event pertaining to a Kubernetes resource.
+type Event struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1502,11 +2078,23 @@ type ManagedResource struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
-	// The desired state of this resource.
-	Spec ManagedResourceSpec `json:"spec"`
-	// The observed state of this resource.
-	Status *ManagedResourceStatus `json:"status,omitempty"`
-	// An unstructured JSON representation of the underlying Kubernetes resource.
+	// The Kubernetes resource this event pertains to.
+	InvolvedObject KubernetesResource `json:"involvedObject"`
+	// The type of event.
+	Type *EventType `json:"type,omitempty"`
+	// The reason the event was emitted.
+	Reason *string `json:"reason,omitempty"`
+	// Details about the event, if any.
+	Message *string `json:"message,omitempty"`
+	// The source of the event - e.g. a controller.
+	Source *EventSource `json:"source,omitempty"`
+	// The number of times this event has occurred.
+	Count *int `json:"count,omitempty"`
+	// The time at which this event was first recorded.
+	FirstTime *time.Time `json:"firstTime,omitempty"`
+	// The time at which this event was most recently recorded.
+	LastTime *time.Time `json:"lastTime,omitempty"`
+	// An unstructured JSON representation of the event.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
 	//
@@ -1577,107 +2165,54 @@ type ManagedResource struct {
 	// ```
 	//
 	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
-	PavedAccess `json:"fieldPath"`
-	// Events pertaining to this resource.
-	Events EventConnection `json:"events"`
-	// The definition of this resource.
-	Definition ManagedResourceDefinition `json:"definition,omitempty"`
-}
-
-func (ManagedResource) IsNode() {}
-
-func (ManagedResource) IsKubernetesResource() {}
-
-// A ManagedResourceStatus represents the observed state of a managed resource.
-type ManagedResourceStatus struct {
-	// The observed condition of this resource.
-	Conditions []Condition `json:"conditions,omitempty"`
-}
-
-func (ManagedResourceStatus) IsConditionedStatus() {}
-
-// `ObjectReference` contains enough information to let you inspect or modify the referred object.
-type ObjectReference struct {
-	// Kind of the referent.
-	Kind *string `json:"kind,omitempty"`
-	// Namespace of the referent.
-	Namespace *string `json:"namespace,omitempty"`
-	// Name of the referent.
-	Name *string `json:"name,omitempty"`
+	PavedAccess       `json:"fieldPath"`
+	InvolvedObjectRef v11.ObjectReference `json:"-"`
 }
 
-// An owner of a Kubernetes resource.
-type Owner struct {
-	// The owner.
-	Resource KubernetesResource `json:"resource"`
-	// Whether the owner is the controller of the owned Kubernetes resource.
-	Controller *bool `json:"controller,omitempty"`
-}
+func (Event) IsNode() {}
 
-// An OwnerConnection represents a connection to an owner.
-type OwnerConnection struct {
+// An EventConnection represents a connection to events.
+type EventConnection struct {
 	// Connected nodes.
-	Nodes []Owner `json:"nodes,omitempty"`
+	Nodes []Event `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []EventEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
-// A Patch that should be applied to an unstructured input before it is submitted.
-type Patch struct {
-	// A field path references a field within a Kubernetes object via a simple
-	// string. API conventions describe the syntax as "standard JavaScript syntax for
-	// accessing that field, assuming the JSON object was transformed into a
-	// JavaScript object, without the leading dot, such as metadata.name".
-	//
-	// Valid examples:
-	//
-	// * metadata.name
-	// * spec.containers[0].name
-	// * data[.config.yml]
-	// * metadata.annotations['crossplane.io/external-name']
-	// * spec.items[0][8]
-	// * apiVersion
-	// * [42]
-	//
-	// Invalid examples:
-	//
-	// * .metadata.name - Leading period.
-	// * metadata..name - Double period.
-	// * metadata.name. - Trailing period.
-	// * spec.containers[] - Empty brackets.
-	// * spec.containers.[0].name - Period before open bracket.
-	//
-	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
-	FieldPath string `json:"fieldPath"`
-	// Unstructured JSON to be patched in at the suppled field path. This could be a
-	// string, an object, or any other valid JSON.
-	Unstructured []byte `json:"unstructured"`
+// An EventEdge represents a node and its position within an EventConnection.
+type EventEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Event `json:"node"`
 }
 
-// A PolicyRule holds information that describes a KubernetesRBAC policy rule.
-type PolicyRule struct {
-	// Verbs is a list of verbs that apply to ALL the resources specified by this
-	// rule. '*' represents all verbs.
-	Verbs []string `json:"verbs"`
-	// APIGroups is the name of the APIGroup that contains the resources. If multiple
-	// API groups are specified, any action requested against one of the enumerated
-	// resources in any API group will be allowed.
-	APIGroups []string `json:"apiGroups,omitempty"`
-	// Resources is a list of resources this rule applies to. '*' represents all
-	// resources.
-	Resources []string `json:"resources,omitempty"`
-	// ResourceNames is a list of names that the rule applies to. An empty set means
-	// that everything is allowed.
-	ResourceNames []string `json:"resourceNames,omitempty"`
-	// NonResourceURLs is a set of partial urls that a user should have access to.
-	// '*' is allowed, but only as the full, final step in the path. Rules can either
-	// apply to API resources (such as "pods" or "secrets") or non-resource URL paths
-	// (such as "/api"),  but not both.
-	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+// An EventSource is the source of an event. Note that in this context 'source'
+// indicates the software or system that emitted the event, not the Kubernetes
+// resource it pertains to.
+type EventSource struct {
+	// The software component that emitted the event.
+	Component *string `json:"component,omitempty"`
 }
 
-// A Provider extends Crossplane with support for new managed resources.
-type Provider struct {
+// A `FailingReason` is the number of resources with a condition of a particular
+// type and reason that isn't `True`.
+type FailingReason struct {
+	// The type of condition.
+	Type string `json:"type"`
+	// The reason.
+	Reason string `json:"reason"`
+	// The number of resources with a failing condition of this type and reason.
+	Count int `json:"count"`
+}
+
+// A Function extends Crossplane with a composition function, which may be used
+// by a composition's pipeline to compose resources.
+type Function struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1687,9 +2222,9 @@ type Provider struct {
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
 	// The desired state of this resource.
-	Spec ProviderSpec `json:"spec"`
+	Spec FunctionSpec `json:"spec"`
 	// The observed state of this resource.
-	Status *ProviderStatus `json:"status,omitempty"`
+	Status *FunctionStatus `json:"status,omitempty"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -1764,19 +2299,62 @@ type Provider struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
-	// Revisions of this provider.
-	Revisions ProviderRevisionConnection `json:"revisions"`
-	// The active revision of this provider.
-	ActiveRevision *ProviderRevision `json:"activeRevision,omitempty"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// Revisions of this function.
+	Revisions FunctionRevisionConnection `json:"revisions"`
+	// The active revision of this function.
+	ActiveRevision *FunctionRevision `json:"activeRevision,omitempty"`
+	// The Deployment that runs the active revision of this function, as configured by
+	// its runtime config.
+	Deployment KubernetesResource `json:"deployment,omitempty"`
 }
 
-func (Provider) IsNode() {}
+func (Function) IsNode() {}
 
-func (Provider) IsKubernetesResource() {}
+func (Function) IsKubernetesResource() {}
 
-// A ProviderConfig configures a provider, in that it provides configuration that
-// is relevant to all managed resources installed by a provider.
-type ProviderConfig struct {
+// A FunctionConnection represents a connection to functions.
+type FunctionConnection struct {
+	// Connected nodes.
+	Nodes []Function `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []FunctionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// FunctionCredentials are optional credentials that a composition function
+// needs.
+type FunctionCredentials struct {
+	// The name of these credentials.
+	Name string `json:"name"`
+	// The source of these credentials.
+	Source FunctionCredentialsSource `json:"source"`
+	// A reference to the secret containing these credentials, if any.
+	SecretRef *SecretReference `json:"secretRef,omitempty"`
+}
+
+// A FunctionEdge represents a node and its position within a FunctionConnection.
+type FunctionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Function `json:"node"`
+}
+
+// FunctionPayload is the result of a function mutation.
+type FunctionPayload struct {
+	// The mutated function. Null if the mutation failed.
+	Function *Function `json:"function,omitempty"`
+}
+
+// A FunctionRevision represents a revision or 'version' of a function.
+type FunctionRevision struct {
 	// An opaque identifier that is unique across all types.
 	ID ReferenceID `json:"id"`
 	// The underlying Kubernetes API version of this resource.
@@ -1785,8 +2363,10 @@ type ProviderConfig struct {
 	Kind string `json:"kind"`
 	// Metadata that is common to all Kubernetes API resources.
 	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec FunctionRevisionSpec `json:"spec"`
 	// The observed state of this resource.
-	Status *ProviderConfigStatus `json:"status,omitempty"`
+	Status *FunctionRevisionStatus `json:"status,omitempty"`
 	// An unstructured JSON representation of the underlying Kubernetes resource.
 	SkipUnstructured `json:"unstructured"`
 	// A JSON representation of a field within the underlying Kubernetes resource.
@@ -1861,38 +2441,1299 @@ type ProviderConfig struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
-	// The definition of this resource.
-	Definition ProviderConfigDefinition `json:"definition,omitempty"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The Deployment and Pods that run this function revision. Only active revisions
+	// have a runtime.
+	Runtime *PackageRuntime `json:"runtime,omitempty"`
 }
 
-func (ProviderConfig) IsNode() {}
+func (FunctionRevision) IsNode() {}
 
-func (ProviderConfig) IsKubernetesResource() {}
+func (FunctionRevision) IsKubernetesResource() {}
 
-// A reference to the ProviderConfig used by a particular managed resource.
-type ProviderConfigReference struct {
-	// Name of the provider config.
-	Name string `json:"name"`
+// A FunctionRevisionConnection represents a connection to function revisions.
+type FunctionRevisionConnection struct {
+	// Connected nodes.
+	Nodes []FunctionRevision `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []FunctionRevisionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
 }
 
-// A ProviderConfigStatus represents the observed state of a provider config.
-type ProviderConfigStatus struct {
+// A FunctionRevisionEdge represents a node and its position within a
+// FunctionRevisionConnection.
+type FunctionRevisionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node FunctionRevision `json:"node"`
+}
+
+// A FunctionRevisionSpec represents the desired state of a function revision.
+type FunctionRevisionSpec struct {
+	// Desired state of the function revision.
+	DesiredState PackageRevisionDesiredState `json:"desiredState"`
+	// Package image used by the install pod to extract package contents.
+	Package string `json:"package"`
+	// PackagePullPolicy defines the pull policy for the package. It is also applied
+	// to any images pulled for the package, such as a function's runtime image.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// Revision number. Indicates when the revision will be garbage collected based
+	// on the function's RevisionHistoryLimit.
+	Revision int `json:"revision"`
+	// IgnoreCrossplaneConstraints indicates to the package manager whether to honor
+	// Crossplane version constrains specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// SkipDependencyResolution indicates to the package manager whether to skip
+	// resolving dependencies for a package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+}
+
+// A FunctionRevisionStatus represents the observed state of a function revision.
+type FunctionRevisionStatus struct {
 	// The observed condition of this resource.
 	Conditions []Condition `json:"conditions,omitempty"`
-	// The number of managed resources currently using this provider config.
-	Users *int `json:"users,omitempty"`
+	// The number of known dependencies.
+	FoundDependencies *int `json:"foundDependencies,omitempty"`
+	// The number of installed dependencies.
+	InstalledDependencies *int `json:"installedDependencies,omitempty"`
+	// The number of invalid dependencies.
+	InvalidDependencies *int `json:"invalidDependencies,omitempty"`
+	// Permissions requested by this function revision.
+	PermissionRequests []PolicyRule `json:"permissionRequests,omitempty"`
+	// Objects owned by this function revision - i.e. objects that were created by
+	// this function revision or that would have been created if they did not already
+	// exist.
+	//
+	// In practice these objects are currently always a CustomResourceDefinition. We
+	// return an array of KubernetesResource here because doing so allows us to
+	// package different types in future without a breaking GraphQL schema change.
+	Objects KubernetesResourceConnection `json:"objects"`
+	// The gRPC endpoint where Crossplane will send requests to run this function.
+	Endpoint   *string             `json:"endpoint,omitempty"`
+	ObjectRefs []v1.TypedReference `json:"-"`
 }
 
-func (ProviderConfigStatus) IsConditionedStatus() {}
+func (FunctionRevisionStatus) IsConditionedStatus() {}
 
-// A ProviderConnection represents a connection to providers.
-type ProviderConnection struct {
-	// Connected nodes.
-	Nodes []Provider `json:"nodes,omitempty"`
+// A FunctionSpec represents the desired state of a function.
+type FunctionSpec struct {
+	// The name of the function package to pull from an OCI registry.
+	Package string `json:"package"`
+	// RevisionActivationPolicy specifies how the package controller should update
+	// from one revision to the next.
+	RevisionActivationPolicy *RevisionActivationPolicy `json:"revisionActivationPolicy,omitempty"`
+	// RevisionHistoryLimit dictates how the package controller cleans up old
+	// inactive package revisions. Defaults to 1. Can be disabled by explicitly
+	// setting to 0.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// PackagePullPolicy defines the pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// IgnoreCrossplaneConstraints indicates to the package manager whether to honor
+	// Crossplane version constraints specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// SkipDependencyResolution indicates to the package manager whether to skip
+	// resolving dependencies for a package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+	// A reference to the DeploymentRuntimeConfig used to configure the package
+	// runtime.
+	RuntimeConfigRef *RuntimeConfigReference `json:"runtimeConfigRef,omitempty"`
+	// The DeploymentRuntimeConfig used to configure the package runtime.
+	RuntimeConfig *DeploymentRuntimeConfig `json:"runtimeConfig,omitempty"`
+	// A reference to the ControllerConfig used to configure the package
+	// Deployment. Deprecated in favor of `runtimeConfigRef`.
+	ControllerConfigRef *LocalObjectReference `json:"controllerConfigRef,omitempty"`
+	// The ControllerConfig used to configure the package Deployment. Deprecated in
+	// favor of `runtimeConfig`.
+	ControllerConfig *ControllerConfig `json:"controllerConfig,omitempty"`
+}
+
+// A FunctionStatus represents the observed state of a function.
+type FunctionStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// CurrentRevision is the name of the current package revision. It will reflect
+	// the most up to date revision, whether it has been activated or not.
+	CurrentRevision *string `json:"currentRevision,omitempty"`
+	// CurrentIdentifier is the most recent package source that was used to produce a
+	// revision. The package manager uses this field to determine whether to check
+	// for package updates for a given source when packagePullPolicy is set to
+	// IfNotPresent.
+	CurrentIdentifier *string `json:"currentIdentifier,omitempty"`
+}
+
+func (FunctionStatus) IsConditionedStatus() {}
+
+// A GenericResource represents a kind of Kubernetes resource that does not
+// correspond to a kind or class of resources that is more specifically modelled
+// by xgql.
+type GenericResource struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+}
+
+func (GenericResource) IsNode() {}
+
+func (GenericResource) IsKubernetesResource() {}
+
+// A `HealthSummary` summarizes the health of several kinds of resource.
+type HealthSummary struct {
+	// A summary of the health of all claims.
+	Claims ResourceHealthSummary `json:"claims"`
+	// A summary of the health of all composite resources.
+	CompositeResources ResourceHealthSummary `json:"compositeResources"`
+	// A summary of the health of all managed resources.
+	ManagedResources ResourceHealthSummary `json:"managedResources"`
+	// A summary of the health of all providers.
+	Providers ResourceHealthSummary `json:"providers"`
+	// A summary of the health of all configurations.
+	Configurations ResourceHealthSummary `json:"configurations"`
+	// The most common reasons for `Ready`, `Synced` and `Healthy` conditions not
+	// to be `True`, across all kinds of resource, most common first.
+	TopFailingReasons []FailingReason `json:"topFailingReasons"`
+}
+
+// InstallConfigurationInput is the input required to install a configuration.
+type InstallConfigurationInput struct {
+	// The name of the configuration.
+	Name string `json:"name"`
+	// The name of the configuration package to pull from an OCI registry.
+	Package string `json:"package"`
+	// How the package controller should update from one revision to the next.
+	RevisionActivationPolicy *RevisionActivationPolicy `json:"revisionActivationPolicy,omitempty"`
+	// The number of inactive package revisions to keep. Must not be negative.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// The pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// Whether to ignore Crossplane version constraints specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// Whether to skip resolving dependencies for the package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+}
+
+// InstallFunctionInput is the input required to install a function.
+type InstallFunctionInput struct {
+	// The name of the function.
+	Name string `json:"name"`
+	// The name of the function package to pull from an OCI registry.
+	Package string `json:"package"`
+	// How the package controller should update from one revision to the next.
+	RevisionActivationPolicy *RevisionActivationPolicy `json:"revisionActivationPolicy,omitempty"`
+	// The number of inactive package revisions to keep. Must not be negative.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// The pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// Whether to ignore Crossplane version constraints specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// Whether to skip resolving dependencies for the package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+	// The DeploymentRuntimeConfig used to configure the package runtime.
+	RuntimeConfigRef *RuntimeConfigReferenceInput `json:"runtimeConfigRef,omitempty"`
+}
+
+// InstallProviderInput is the input required to install a provider.
+type InstallProviderInput struct {
+	// The name of the provider.
+	Name string `json:"name"`
+	// The name of the provider package to pull from an OCI registry.
+	Package string `json:"package"`
+	// How the package controller should update from one revision to the next.
+	RevisionActivationPolicy *RevisionActivationPolicy `json:"revisionActivationPolicy,omitempty"`
+	// The number of inactive package revisions to keep. Must not be negative.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// The pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// Whether to ignore Crossplane version constraints specified by the package.
+	IgnoreCrossplaneConstraints *bool `json:"ignoreCrossplaneConstraints,omitempty"`
+	// Whether to skip resolving dependencies for the package.
+	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+	// The DeploymentRuntimeConfig used to configure the package runtime.
+	RuntimeConfigRef *RuntimeConfigReferenceInput `json:"runtimeConfigRef,omitempty"`
+}
+
+// A JSONPatchOperation is an RFC 6902 JSON patch operation.
+type JSONPatchOperation struct {
+	// The operation - for example add, remove, replace, or move.
+	Op string `json:"op"`
+	// A JSON pointer to the field the operation applies to.
+	Path string `json:"path"`
+	// A JSON pointer to the field a move operation moves from.
+	From *string `json:"from,omitempty"`
+	// The value an add or replace operation sets, as raw JSON.
+	Value []byte `json:"value,omitempty"`
+}
+
+// A KubernetesResourceConnection represents a connection to Kubernetes resources.
+type KubernetesResourceConnection struct {
+	// Connected nodes.
+	Nodes []KubernetesResource `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []KubernetesResourceEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A KubernetesResourceEdge represents a node and its position within a
+// KubernetesResourceConnection.
+type KubernetesResourceEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node KubernetesResource `json:"node"`
+}
+
+// A LabelSelector matches a Kubernetes resource by labels.
+type LabelSelector struct {
+	// The labels to match on.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// A LabelSelectorInput selects Kubernetes resources by label. A resource must
+// satisfy every supplied requirement in order to be selected.
+type LabelSelectorInput struct {
+	// A label selector using the Kubernetes string syntax, for example
+	// `team=payments,environment in (production,staging),!deprecated`.
+	Selector *string `json:"selector,omitempty"`
+	// The labels to match on.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// Label selector requirements to match on.
+	MatchExpressions []LabelSelectorRequirementInput `json:"matchExpressions,omitempty"`
+}
+
+// A LabelSelectorRequirementInput is a label selector requirement that relates a
+// label key to a set of values.
+type LabelSelectorRequirementInput struct {
+	// The label key that the requirement applies to.
+	Key string `json:"key"`
+	// The operator representing the key's relationship to the values.
+	Operator LabelSelectorOperator `json:"operator"`
+	// The values of the requirement. Must be non-empty if the operator is `IN` or
+	// `NOT_IN`, and empty if the operator is `EXISTS` or `DOES_NOT_EXIST`.
+	Values []string `json:"values,omitempty"`
+}
+
+// `LocalObjectReference` contains a name to to let you inspect or modify the
+// locally referred object.
+type LocalObjectReference struct {
+	// Name of the referent.
+	Name string `json:"name"`
+}
+
+// A LockDependency is a dependency of a package recorded in a PackageLock.
+type LockDependency struct {
+	// The OCI image name of the package depended on, without a tag or digest.
+	Package string `json:"package"`
+	// The type of the package depended on.
+	Type *PackageType `json:"type,omitempty"`
+	// A semantic version range that the package depended on must satisfy.
+	Constraints string `json:"constraints"`
+	// The tag or digest of the package that the dependency resolved to, if the
+	// package manager has installed it.
+	Version *string `json:"version,omitempty"`
+}
+
+// A LockPackage is a package recorded in a PackageLock.
+type LockPackage struct {
+	// The name of the package revision that this package corresponds to.
+	Name string `json:"name"`
+	// The type of this package.
+	Type *PackageType `json:"type,omitempty"`
+	// The OCI image name of this package, without a tag or digest.
+	Source string `json:"source"`
+	// The tag or digest of the OCI image of this package.
+	Version string `json:"version"`
+	// The dependencies of this package.
+	Dependencies []LockDependency `json:"dependencies,omitempty"`
+}
+
+// A ManagedResource is a Kubernetes API representation of a resource in an
+// external system, such as a cloud provider's API. Crossplane providers add
+// support for new kinds of managed resource.
+type ManagedResource struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec ManagedResourceSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *ManagedResourceStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The definition of this resource.
+	Definition ManagedResourceDefinition `json:"definition,omitempty"`
+	// The ancestors of this resource, ordered from its parent to its root.
+	Ancestors KubernetesResourceConnection `json:"ancestors"`
+}
+
+func (ManagedResource) IsNode() {}
+
+func (ManagedResource) IsKubernetesResource() {}
+
+// A ManagedResourceConnection represents a connection to managed resources.
+type ManagedResourceConnection struct {
+	// Connected nodes.
+	Nodes []ManagedResource `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ManagedResourceEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A ManagedResourceEdge represents a node and its position within a
+// ManagedResourceConnection.
+type ManagedResourceEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node ManagedResource `json:"node"`
+}
+
+// A ManagedResourceStatus represents the observed state of a managed resource.
+type ManagedResourceStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+func (ManagedResourceStatus) IsConditionedStatus() {}
+
+// `ObjectReference` contains enough information to let you inspect or modify the referred object.
+type ObjectReference struct {
+	// Kind of the referent.
+	Kind *string `json:"kind,omitempty"`
+	// Namespace of the referent.
+	Namespace *string `json:"namespace,omitempty"`
+	// Name of the referent.
+	Name *string `json:"name,omitempty"`
+}
+
+// An OrderBy sorts the nodes of a connection by the value at a field path, for
+// example `metadata.creationTimestamp`. A bracketed name selects the element of
+// an array of objects whose `type` field matches, for example
+// `status.conditions[Ready].lastTransitionTime`. Nodes without a value at the
+// field path sort last, regardless of direction.
+type OrderBy struct {
+	// The path to the field to sort by.
+	Field string `json:"field"`
+	// The direction in which to sort.
+	Direction *OrderDirection `json:"direction,omitempty"`
+}
+
+// An owner of a Kubernetes resource.
+type Owner struct {
+	// The owner.
+	Resource KubernetesResource `json:"resource"`
+	// Whether the owner is the controller of the owned Kubernetes resource.
+	Controller *bool `json:"controller,omitempty"`
+}
+
+// An OwnerConnection represents a connection to an owner.
+type OwnerConnection struct {
+	// Connected nodes.
+	Nodes []Owner `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []OwnerEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// An OwnerEdge represents a node and its position within an OwnerConnection.
+type OwnerEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Owner `json:"node"`
+}
+
+// A PackageLock records the packages installed by the package manager, and how
+// their dependencies were resolved.
+type PackageLock struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The packages recorded in this lock.
+	Packages []LockPackage `json:"packages,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+}
+
+func (PackageLock) IsNode() {}
+
+func (PackageLock) IsKubernetesResource() {}
+
+// A PackageRuntime is the Deployment that runs a provider or function package
+// revision, and the Pods it manages.
+type PackageRuntime struct {
+	// The Deployment that runs the package revision.
+	Deployment KubernetesResource `json:"deployment"`
+	// The Pods that run the package revision.
+	Pods []Pod `json:"pods,omitempty"`
+}
+
+// PageInfo describes the page of a connection that was returned. Connections may
+// be paginated using the `first`, `after`, `last`, and `before` arguments per the
+// Relay cursor connections specification.
+//
+// https://relay.dev/graphql/connections.htm
+type PageInfo struct {
+	// Whether more edges exist after the returned page.
+	HasNextPage bool `json:"hasNextPage"`
+	// Whether more edges exist before the returned page.
+	HasPreviousPage bool `json:"hasPreviousPage"`
+	// The cursor of the first edge in the returned page, if any.
+	StartCursor *string `json:"startCursor,omitempty"`
+	// The cursor of the last edge in the returned page, if any.
+	EndCursor *string `json:"endCursor,omitempty"`
+}
+
+// A Patch that should be applied to an unstructured input before it is submitted.
+type Patch struct {
+	// A field path references a field within a Kubernetes object via a simple
+	// string. API conventions describe the syntax as "standard JavaScript syntax for
+	// accessing that field, assuming the JSON object was transformed into a
+	// JavaScript object, without the leading dot, such as metadata.name".
+	//
+	// Valid examples:
+	//
+	// * metadata.name
+	// * spec.containers[0].name
+	// * data[.config.yml]
+	// * metadata.annotations['crossplane.io/external-name']
+	// * spec.items[0][8]
+	// * apiVersion
+	// * [42]
+	//
+	// Invalid examples:
+	//
+	// * .metadata.name - Leading period.
+	// * metadata..name - Double period.
+	// * metadata.name. - Trailing period.
+	// * spec.containers[] - Empty brackets.
+	// * spec.containers.[0].name - Period before open bracket.
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	FieldPath string `json:"fieldPath"`
+	// Unstructured JSON to be patched in at the suppled field path. This could be a
+	// string, an object, or any other valid JSON.
+	Unstructured []byte `json:"unstructured"`
+}
+
+// PatchKubernetesResourcePayload is the result of patching a Kubernetes resource.
+type PatchKubernetesResourcePayload struct {
+	// The patched Kubernetes resource. Null if the patch failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// The RFC 6902 JSON patch operations that would transform the current resource
+	// into the patched resource. Only set for a dry run.
+	Diff []JSONPatchOperation `json:"diff,omitempty"`
+}
+
+// A PipelineStep runs a composition function in PIPELINE mode.
+type PipelineStep struct {
+	// A name that uniquely identifies this step within its pipeline.
+	Step string `json:"step"`
+	// A reference to the function this step runs.
+	FunctionRef LocalObjectReference `json:"functionRef"`
+	// The function this step runs.
+	Function *Function `json:"function,omitempty"`
+	// Optional input that is passed to the function.
+	Input []byte `json:"input,omitempty"`
+	// Credentials that are passed to the function.
+	Credentials []FunctionCredentials `json:"credentials,omitempty"`
+}
+
+// A Pod is a group of containers that run together on a Kubernetes node.
+type Pod struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The observed state of this resource.
+	Status *PodStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// Logs of one of this pod's containers. Logs are read from the API server using
+	// the caller's credentials each time they're requested. At most 1MiB of logs
+	// are returned.
+	Logs *string `json:"logs,omitempty"`
+}
+
+func (Pod) IsNode() {}
+
+func (Pod) IsKubernetesResource() {}
+
+// A PodStatus represents the observed state of a pod.
+type PodStatus struct {
+	// The phase of the pod's lifecycle, e.g. Pending, Running or Failed.
+	Phase *string `json:"phase,omitempty"`
+	// A brief, machine readable reason the pod is in its current state.
+	Reason *string `json:"reason,omitempty"`
+	// A human readable message explaining why the pod is in its current state.
+	Message *string `json:"message,omitempty"`
+	// The time at which the pod was acknowledged by its node.
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// The observed state of each of the pod's containers.
+	ContainerStatuses []ContainerStatus `json:"containerStatuses,omitempty"`
+}
+
+// A PolicyRule holds information that describes a KubernetesRBAC policy rule.
+type PolicyRule struct {
+	// Verbs is a list of verbs that apply to ALL the resources specified by this
+	// rule. '*' represents all verbs.
+	Verbs []string `json:"verbs"`
+	// APIGroups is the name of the APIGroup that contains the resources. If multiple
+	// API groups are specified, any action requested against one of the enumerated
+	// resources in any API group will be allowed.
+	APIGroups []string `json:"apiGroups,omitempty"`
+	// Resources is a list of resources this rule applies to. '*' represents all
+	// resources.
+	Resources []string `json:"resources,omitempty"`
+	// ResourceNames is a list of names that the rule applies to. An empty set means
+	// that everything is allowed.
+	ResourceNames []string `json:"resourceNames,omitempty"`
+	// NonResourceURLs is a set of partial urls that a user should have access to.
+	// '*' is allowed, but only as the full, final step in the path. Rules can either
+	// apply to API resources (such as "pods" or "secrets") or non-resource URL paths
+	// (such as "/api"),  but not both.
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// A Provider extends Crossplane with support for new managed resources.
+type Provider struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec ProviderSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *ProviderStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// Revisions of this provider.
+	Revisions ProviderRevisionConnection `json:"revisions"`
+	// The active revision of this provider.
+	ActiveRevision *ProviderRevision `json:"activeRevision,omitempty"`
+	// The Deployment that runs the active revision of this provider, as configured by
+	// its runtime config.
+	Deployment KubernetesResource `json:"deployment,omitempty"`
+	// The packages this provider depends on, according to the package
+	// lock.
+	Dependencies []LockDependency `json:"dependencies,omitempty"`
+	// The packages that depend on this provider, according to the package
+	// lock.
+	Dependents []LockPackage `json:"dependents,omitempty"`
+}
+
+func (Provider) IsNode() {}
+
+func (Provider) IsKubernetesResource() {}
+
+// A ProviderConfig configures a provider, in that it provides configuration that
+// is relevant to all managed resources installed by a provider.
+type ProviderConfig struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The observed state of this resource.
+	Status *ProviderConfigStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The definition of this resource.
+	Definition ProviderConfigDefinition `json:"definition,omitempty"`
+	// Usages of this provider config. Each usage records a managed resource that
+	// uses this provider config.
+	Usages ProviderConfigUsageConnection `json:"usages"`
+}
+
+func (ProviderConfig) IsNode() {}
+
+func (ProviderConfig) IsKubernetesResource() {}
+
+// A ProviderConfigConnection represents a connection to provider configs.
+type ProviderConfigConnection struct {
+	// Connected nodes.
+	Nodes []ProviderConfig `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ProviderConfigEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A ProviderConfigEdge represents a node and its position within a
+// ProviderConfigConnection.
+type ProviderConfigEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node ProviderConfig `json:"node"`
+}
+
+// A reference to the ProviderConfig used by a particular managed resource.
+type ProviderConfigReference struct {
+	// Name of the provider config.
+	Name string `json:"name"`
+}
+
+// A ProviderConfigStatus represents the observed state of a provider config.
+type ProviderConfigStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+	// The number of managed resources currently using this provider config.
+	Users *int `json:"users,omitempty"`
+}
+
+func (ProviderConfigStatus) IsConditionedStatus() {}
+
+// A ProviderConfigUsage records that a managed resource uses a provider config.
+// Providers create a usage for each managed resource that uses a provider config,
+// and will not delete a provider config while it has usages.
+type ProviderConfigUsage struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// A reference to the provider config that is used.
+	ProviderConfigRef ProviderConfigReference `json:"providerConfigRef"`
+	// A reference to the managed resource that uses the provider config.
+	ResourceRef TypedReference `json:"resourceRef"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The managed resource that uses the provider config.
+	Resource *ManagedResource `json:"resource,omitempty"`
+}
+
+func (ProviderConfigUsage) IsNode() {}
+
+func (ProviderConfigUsage) IsKubernetesResource() {}
+
+// A ProviderConfigUsageConnection represents a connection to provider config
+// usages.
+type ProviderConfigUsageConnection struct {
+	// Connected nodes.
+	Nodes []ProviderConfigUsage `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ProviderConfigUsageEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A ProviderConfigUsageEdge represents a node and its position within a
+// ProviderConfigUsageConnection.
+type ProviderConfigUsageEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node ProviderConfigUsage `json:"node"`
+}
+
+// A ProviderConnection represents a connection to providers.
+type ProviderConnection struct {
+	// Connected nodes.
+	Nodes []Provider `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ProviderEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A ProviderEdge represents a node and its position within a ProviderConnection.
+type ProviderEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Provider `json:"node"`
+}
+
+// ProviderPayload is the result of a provider mutation.
+type ProviderPayload struct {
+	// The mutated provider. Null if the mutation failed.
+	Provider *Provider `json:"provider,omitempty"`
+}
+
 // A ProviderRevision represents a revision or 'version' of a provider.
 type ProviderRevision struct {
 	// An opaque identifier that is unique across all types.
@@ -1981,6 +3822,19 @@ type ProviderRevision struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+	// The packages this provider revision depends on, according to the package
+	// lock.
+	Dependencies []LockDependency `json:"dependencies,omitempty"`
+	// The packages that depend on this provider revision, according to the package
+	// lock.
+	Dependents []LockPackage `json:"dependents,omitempty"`
+	// The Deployment and Pods that run this provider revision. Only active revisions
+	// have a runtime.
+	Runtime *PackageRuntime `json:"runtime,omitempty"`
 }
 
 func (ProviderRevision) IsNode() {}
@@ -1991,10 +3845,23 @@ func (ProviderRevision) IsKubernetesResource() {}
 type ProviderRevisionConnection struct {
 	// Connected nodes.
 	Nodes []ProviderRevision `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []ProviderRevisionEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
 	// The total number of connected nodes.
 	TotalCount int `json:"totalCount"`
 }
 
+// A ProviderRevisionEdge represents a node and its position within a
+// ProviderRevisionConnection.
+type ProviderRevisionEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node ProviderRevision `json:"node"`
+}
+
 // A ProviderRevisionSpec represents the desired state of a provider revision.
 type ProviderRevisionSpec struct {
 	// Desired state of the provider revision.
@@ -2061,6 +3928,17 @@ type ProviderSpec struct {
 	// SkipDependencyResolution indicates to the package manager whether to skip
 	// resolving dependencies for a package.
 	SkipDependencyResolution *bool `json:"skipDependencyResolution,omitempty"`
+	// A reference to the DeploymentRuntimeConfig used to configure the package
+	// runtime.
+	RuntimeConfigRef *RuntimeConfigReference `json:"runtimeConfigRef,omitempty"`
+	// The DeploymentRuntimeConfig used to configure the package runtime.
+	RuntimeConfig *DeploymentRuntimeConfig `json:"runtimeConfig,omitempty"`
+	// A reference to the ControllerConfig used to configure the package
+	// Deployment. Deprecated in favor of `runtimeConfigRef`.
+	ControllerConfigRef *LocalObjectReference `json:"controllerConfigRef,omitempty"`
+	// The ControllerConfig used to configure the package Deployment. Deprecated in
+	// favor of `runtimeConfig`.
+	ControllerConfig *ControllerConfig `json:"controllerConfig,omitempty"`
 }
 
 // A ProviderStatus represents the observed state of a provider.
@@ -2079,6 +3957,94 @@ type ProviderStatus struct {
 
 func (ProviderStatus) IsConditionedStatus() {}
 
+// A `ReasonCount` is the number of resources with a condition of a particular
+// reason.
+type ReasonCount struct {
+	// The reason.
+	Reason string `json:"reason"`
+	// The number of resources with a condition of this reason.
+	Count int `json:"count"`
+}
+
+// A ResourceFilter matches Kubernetes resources by the values at field paths
+// within them, for example `{field: "spec.forProvider.region", eq: "us-east-1"}`.
+// A resource matches the filter only if it matches every supplied predicate.
+type ResourceFilter struct {
+	// The path to a field within the resource, for example
+	// `status.atProvider.state`. Required by the `eq`, `ne`, `in`, `exists` and
+	// `regex` predicates. Wildcards are not supported.
+	Field *string `json:"field,omitempty"`
+	// The value of the field must equal this value.
+	Eq []byte `json:"eq,omitempty"`
+	// The field must not exist, or its value must not equal this value.
+	Ne []byte `json:"ne,omitempty"`
+	// The value of the field must equal one of these values.
+	In [][]byte `json:"in,omitempty"`
+	// The field must exist if true, or must not exist if false.
+	Exists *bool `json:"exists,omitempty"`
+	// The value of the field must be a string matching this regular expression.
+	Regex *string `json:"regex,omitempty"`
+	// The resource must match all of these filters.
+	And []ResourceFilter `json:"and,omitempty"`
+	// The resource must match at least one of these filters.
+	Or []ResourceFilter `json:"or,omitempty"`
+	// The resource must not match this filter.
+	Not *ResourceFilter `json:"not,omitempty"`
+}
+
+// A `ResourceHealth` explains the health of a `KubernetesResource`.
+type ResourceHealth struct {
+	// The status of the resource's `Ready` condition, if any.
+	Ready *ConditionStatus `json:"ready,omitempty"`
+	// The status of the resource's `Synced` condition, if any.
+	Synced *ConditionStatus `json:"synced,omitempty"`
+	// Whether the resource is healthy. A resource is healthy if its `Ready` and
+	// `Synced` conditions, if any, are `True`.
+	Healthy bool `json:"healthy"`
+	// The most relevant of the resource's conditions that isn't `True`, if any. A
+	// `Synced` condition is more relevant than a `Ready` condition, because a
+	// resource that can't be synced usually can't become ready either.
+	FailingCondition *Condition `json:"failingCondition,omitempty"`
+	// The most recent warning event pertaining to the resource, if any.
+	LatestWarningEvent *Event `json:"latestWarningEvent,omitempty"`
+	// The last time any of the resource's conditions transitioned, if any.
+	LastTransitionTime *time.Time `json:"lastTransitionTime,omitempty"`
+	// The number of seconds since `lastTransitionTime`.
+	SecondsSinceLastTransition *int `json:"secondsSinceLastTransition,omitempty"`
+	// Whether the resource is a root cause of its parent's unhealthiness. A root
+	// cause is an unhealthy resource whose parent, if any, is also unhealthy, and
+	// whose children are all healthy.
+	RootCause bool `json:"rootCause"`
+}
+
+// A `ResourceHealthSummary` summarizes the health of one kind of resource.
+type ResourceHealthSummary struct {
+	// The total number of resources.
+	Total int `json:"total"`
+	// A summary of the resources' `Ready` conditions.
+	Ready ConditionSummary `json:"ready"`
+	// A summary of the resources' `Synced` conditions.
+	Synced ConditionSummary `json:"synced"`
+	// A summary of the resources' `Healthy` conditions.
+	Healthy ConditionSummary `json:"healthy"`
+}
+
+// A RuntimeConfigReference references a DeploymentRuntimeConfig.
+type RuntimeConfigReference struct {
+	// The API version of the referenced runtime config.
+	APIVersion *string `json:"apiVersion,omitempty"`
+	// The kind of the referenced runtime config.
+	Kind *string `json:"kind,omitempty"`
+	// The name of the referenced runtime config.
+	Name string `json:"name"`
+}
+
+// RuntimeConfigReferenceInput references a DeploymentRuntimeConfig.
+type RuntimeConfigReferenceInput struct {
+	// The name of the referenced DeploymentRuntimeConfig.
+	Name string `json:"name"`
+}
+
 // A Secret holds secret data.
 type Secret struct {
 	// An opaque identifier that is unique across all types.
@@ -2167,42 +4133,459 @@ type Secret struct {
 	PavedAccess `json:"fieldPath"`
 	// Events pertaining to this resource.
 	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+}
+
+func (Secret) IsNode() {}
+
+func (Secret) IsKubernetesResource() {}
+
+// A `SecretReference` is a reference to a secret in an arbitrary namespace.
+type SecretReference struct {
+	// Name of the `Secret`.
+	Name string `json:"name"`
+	// Namespace of the `Secret`.
+	Namespace string `json:"namespace"`
+}
+
+// A `TraceConnection` represents a connection to `TraceNode`s.
+type TraceConnection struct {
+	// Connected nodes.
+	Nodes []TraceNode `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []TraceEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A `TraceEdge` represents a node and its position within a `TraceConnection`.
+type TraceEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node TraceNode `json:"node"`
+}
+
+// A `TraceNode` is a `CrossplaneResourceTreeNode` with an explanation of the
+// health of its resource.
+//
+// Note: A `NULL` `parentId` represents the root of the trace.
+type TraceNode struct {
+	// The `ID` of the parent `KubernetesResource` (`NULL` is the root of the trace)
+	ParentID *ReferenceID `json:"parentId,omitempty"`
+	// The `KubernetesResource` object of this `TraceNode`
+	Resource KubernetesResource `json:"resource"`
+	// The health of the resource.
+	Health ResourceHealth `json:"health"`
+}
+
+// A TypeReference references a type of Kubernetes resource by API version and
+// kind.
+type TypeReference struct {
+	// The Kubernetes API version of the referenced resource.
+	APIVersion string `json:"apiVersion"`
+	// The Kubernetes API kind of the referenced resource.
+	Kind string `json:"kind"`
+}
+
+// A `TypedReference` refers to an object by its API version, kind, and name.
+type TypedReference struct {
+	// The Kubernetes API version of the referent.
+	APIVersion string `json:"apiVersion"`
+	// The Kubernetes API kind of the referent.
+	Kind string `json:"kind"`
+	// Name of the referent.
+	Name string `json:"name"`
+	// UID of the referent.
+	UID *string `json:"uid,omitempty"`
+}
+
+// UpdateConfigurationPackageInput is the input required to update the package of
+// a configuration. Optional fields that are omitted are left unchanged.
+type UpdateConfigurationPackageInput struct {
+	// The name of the configuration package to pull from an OCI registry.
+	Package string `json:"package"`
+	// The pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// The number of inactive package revisions to keep. Must not be negative.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+}
+
+// UpdateFunctionPackageInput is the input required to update the package of a
+// function. Optional fields that are omitted are left unchanged.
+type UpdateFunctionPackageInput struct {
+	// The name of the function package to pull from an OCI registry.
+	Package string `json:"package"`
+	// The pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// The number of inactive package revisions to keep. Must not be negative.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// The DeploymentRuntimeConfig used to configure the package runtime.
+	RuntimeConfigRef *RuntimeConfigReferenceInput `json:"runtimeConfigRef,omitempty"`
+}
+
+// UpdateKubernetesResourceInput is the input required to update a Kubernetes
+// resource.
+type UpdateKubernetesResourceInput struct {
+	// The Kubernetes resource to be updated, as raw JSON.
+	Unstructured []byte `json:"unstructured"`
+	// Patches that should be applied to the Kubernetes resource before updating.
+	Patches []Patch `json:"patches,omitempty"`
+	// The resource version the resource must have for the update to succeed. This
+	// overrides any resource version in the supplied JSON. The update fails with a
+	// CONFLICT error if the resource has changed since this version was read. The
+	// error includes the current resource in its 'current' extension.
+	ExpectedResourceVersion *string `json:"expectedResourceVersion,omitempty"`
+}
+
+// UpdateKubernetesResourcePayload is the result of updating a Kubernetes resource.
+type UpdateKubernetesResourcePayload struct {
+	// The updated Kubernetes resource. Null if the update failed.
+	Resource KubernetesResource `json:"resource,omitempty"`
+	// The RFC 6902 JSON patch operations that would transform the current resource
+	// into the updated resource. Only set for a dry run.
+	Diff []JSONPatchOperation `json:"diff,omitempty"`
+}
+
+// UpdateProviderPackageInput is the input required to update the package of a
+// provider. Optional fields that are omitted are left unchanged.
+type UpdateProviderPackageInput struct {
+	// The name of the provider package to pull from an OCI registry.
+	Package string `json:"package"`
+	// The pull policy for the package.
+	PackagePullPolicy *PackagePullPolicy `json:"packagePullPolicy,omitempty"`
+	// The number of inactive package revisions to keep. Must not be negative.
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+	// The DeploymentRuntimeConfig used to configure the package runtime.
+	RuntimeConfigRef *RuntimeConfigReferenceInput `json:"runtimeConfigRef,omitempty"`
+}
+
+// A Usage blocks deletion of a resource while it is in use, either by another
+// resource or for the supplied reason.
+type Usage struct {
+	// An opaque identifier that is unique across all types.
+	ID ReferenceID `json:"id"`
+	// The underlying Kubernetes API version of this resource.
+	APIVersion string `json:"apiVersion"`
+	// The underlying Kubernetes API kind of this resource.
+	Kind string `json:"kind"`
+	// Metadata that is common to all Kubernetes API resources.
+	Metadata ObjectMeta `json:"metadata"`
+	// The desired state of this resource.
+	Spec UsageSpec `json:"spec"`
+	// The observed state of this resource.
+	Status *UsageStatus `json:"status,omitempty"`
+	// An unstructured JSON representation of the underlying Kubernetes resource.
+	SkipUnstructured `json:"unstructured"`
+	// A JSON representation of a field within the underlying Kubernetes resource.
+	//
+	// API conventions describe the syntax as:
+	// > standard JavaScript syntax for accessing that field, assuming the JSON
+	// > object was transformed into a JavaScript object, without the leading dot,
+	// > such as `metadata.name`.
+	//
+	// Valid examples:
+	//
+	// * `metadata.name`
+	// * `spec.containers[0].name`
+	// * `data[.config.yml]`
+	// * `metadata.annotations['crossplane.io/external-name']`
+	// * `spec.items[0][8]`
+	// * `apiVersion`
+	// * `[42]`
+	// * `spec.containers[*].args[*]` - Supports wildcard expansion.
+	//
+	// Invalid examples:
+	//
+	// * `.metadata.name` - Leading period.
+	// * `metadata..name` - Double period.
+	// * `metadata.name.` - Trailing period.
+	// * `spec.containers[]` - Empty brackets.
+	// * `spec.containers.[0].name` - Period before open bracket.
+	//
+	// Wildcards support:
+	//
+	// For an object with the following data:
+	//
+	// ```json
+	// {
+	//   "spec": {
+	//     "containers": [
+	//       {
+	//         "name": "cool",
+	//         "image": "latest",
+	//         "args": [
+	//           "start",
+	//           "now",
+	//           "debug"
+	//         ]
+	//       }
+	//     ]
+	//   }
+	// }
+	// ```
+	//
+	// The wildcard `spec.containers[*].args[*]` will be expanded to:
+	//
+	// ```json
+	// [
+	//   "spec.containers[0].args[0]",
+	//   "spec.containers[0].args[1]",
+	//   "spec.containers[0].args[2]",
+	// ]
+	// ```
+	//
+	// And the following result will be returned:
+	//
+	// ```json
+	// [
+	//   "start",
+	//   "now",
+	//   "debug"
+	// ]
+	// ```
+	//
+	// https://github.com/kubernetes/community/blob/61f3d0/contributors/devel/sig-architecture/api-conventions.md#selecting-fields
+	PavedAccess `json:"fieldPath"`
+	// Events pertaining to this resource.
+	Events EventConnection `json:"events"`
+	// Usages that block deletion of this resource because other resources use it.
+	UsedBy UsageConnection `json:"usedBy"`
+	// Usages that record this resource using other resources.
+	Uses UsageConnection `json:"uses"`
+}
+
+func (Usage) IsNode() {}
+
+func (Usage) IsKubernetesResource() {}
+
+// A UsageConnection represents a connection to usages.
+type UsageConnection struct {
+	// Connected nodes.
+	Nodes []Usage `json:"nodes,omitempty"`
+	// Connected edges.
+	Edges []UsageEdge `json:"edges,omitempty"`
+	// Information to aid in pagination.
+	PageInfo PageInfo `json:"pageInfo"`
+	// The total number of connected nodes.
+	TotalCount int `json:"totalCount"`
+}
+
+// A UsageEdge represents a node and its position within a UsageConnection.
+type UsageEdge struct {
+	// An opaque cursor that identifies this edge's position in its connection.
+	Cursor string `json:"cursor"`
+	// The connected node.
+	Node Usage `json:"node"`
+}
+
+// A UsageResource identifies a resource that is part of a usage, either by name
+// or by selector.
+type UsageResource struct {
+	// The API version of the resource.
+	APIVersion *string `json:"apiVersion,omitempty"`
+	// The kind of the resource.
+	Kind *string `json:"kind,omitempty"`
+	// A reference to the resource by name.
+	ResourceRef *UsageResourceRef `json:"resourceRef,omitempty"`
+	// A selector for the resource. Ignored if `resourceRef` is set.
+	ResourceSelector *UsageResourceSelector `json:"resourceSelector,omitempty"`
+	// The resource, if it has been resolved and exists.
+	Resource KubernetesResource `json:"resource,omitempty"`
+}
+
+// A UsageResourceRef references a resource by name.
+type UsageResourceRef struct {
+	// The name of the resource.
+	Name string `json:"name"`
+}
+
+// A UsageResourceSelector selects a resource by labels.
+type UsageResourceSelector struct {
+	// The labels to match on.
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// Select a resource with the same controller reference as the usage.
+	MatchControllerRef *bool `json:"matchControllerRef,omitempty"`
+}
+
+// A UsageSpec represents the desired state of a usage.
+type UsageSpec struct {
+	// The resource that is being used.
+	Of UsageResource `json:"of"`
+	// The resource that is using the other resource, if any.
+	By *UsageResource `json:"by,omitempty"`
+	// The reason deletion of the used resource is blocked, if any.
+	Reason *string `json:"reason,omitempty"`
+	// Whether deletion of the used resource will be replayed when this usage is
+	// deleted, if deletion of the used resource was previously attempted.
+	ReplayDeletion *bool `json:"replayDeletion,omitempty"`
+}
+
+// A UsageStatus represents the observed state of a usage.
+type UsageStatus struct {
+	// The observed condition of this resource.
+	Conditions []Condition `json:"conditions,omitempty"`
+}
+
+func (UsageStatus) IsConditionedStatus() {}
+
+// A ComposedTemplatePatchType determines how a patch copies data.
+type ComposedTemplatePatchType string
+
+const (
+	// Copy from a field of the composite resource to the composed resource.
+	ComposedTemplatePatchTypeFromCompositeFieldPath ComposedTemplatePatchType = "FROM_COMPOSITE_FIELD_PATH"
+	// Copy from a field of the environment to the composed resource.
+	ComposedTemplatePatchTypeFromEnvironmentFieldPath ComposedTemplatePatchType = "FROM_ENVIRONMENT_FIELD_PATH"
+	// Apply the patches of a patch set.
+	ComposedTemplatePatchTypePatchSet ComposedTemplatePatchType = "PATCH_SET"
+	// Copy from a field of the composed resource to the composite resource.
+	ComposedTemplatePatchTypeToCompositeFieldPath ComposedTemplatePatchType = "TO_COMPOSITE_FIELD_PATH"
+	// Copy from a field of the composed resource to the environment.
+	ComposedTemplatePatchTypeToEnvironmentFieldPath ComposedTemplatePatchType = "TO_ENVIRONMENT_FIELD_PATH"
+	// Combine fields of the environment into a field of the composed resource.
+	ComposedTemplatePatchTypeCombineFromEnvironment ComposedTemplatePatchType = "COMBINE_FROM_ENVIRONMENT"
+	// Combine fields of the composite resource into a field of the composed resource.
+	ComposedTemplatePatchTypeCombineFromComposite ComposedTemplatePatchType = "COMBINE_FROM_COMPOSITE"
+	// Combine fields of the composed resource into a field of the composite resource.
+	ComposedTemplatePatchTypeCombineToComposite ComposedTemplatePatchType = "COMBINE_TO_COMPOSITE"
+	// Combine fields of the composed resource into a field of the environment.
+	ComposedTemplatePatchTypeCombineToEnvironment ComposedTemplatePatchType = "COMBINE_TO_ENVIRONMENT"
+)
+
+var AllComposedTemplatePatchType = []ComposedTemplatePatchType{
+	ComposedTemplatePatchTypeFromCompositeFieldPath,
+	ComposedTemplatePatchTypeFromEnvironmentFieldPath,
+	ComposedTemplatePatchTypePatchSet,
+	ComposedTemplatePatchTypeToCompositeFieldPath,
+	ComposedTemplatePatchTypeToEnvironmentFieldPath,
+	ComposedTemplatePatchTypeCombineFromEnvironment,
+	ComposedTemplatePatchTypeCombineFromComposite,
+	ComposedTemplatePatchTypeCombineToComposite,
+	ComposedTemplatePatchTypeCombineToEnvironment,
+}
+
+func (e ComposedTemplatePatchType) IsValid() bool {
+	switch e {
+	case ComposedTemplatePatchTypeFromCompositeFieldPath, ComposedTemplatePatchTypeFromEnvironmentFieldPath, ComposedTemplatePatchTypePatchSet, ComposedTemplatePatchTypeToCompositeFieldPath, ComposedTemplatePatchTypeToEnvironmentFieldPath, ComposedTemplatePatchTypeCombineFromEnvironment, ComposedTemplatePatchTypeCombineFromComposite, ComposedTemplatePatchTypeCombineToComposite, ComposedTemplatePatchTypeCombineToEnvironment:
+		return true
+	}
+	return false
+}
+
+func (e ComposedTemplatePatchType) String() string {
+	return string(e)
+}
+
+func (e *ComposedTemplatePatchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ComposedTemplatePatchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ComposedTemplatePatchType", str)
+	}
+	return nil
+}
+
+func (e ComposedTemplatePatchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A CompositionMode determines what mode of composition is used.
+type CompositionMode string
+
+const (
+	// Compose resources using resource templates.
+	CompositionModeResources CompositionMode = "RESOURCES"
+	// Compose resources using a pipeline of composition functions.
+	CompositionModePipeline CompositionMode = "PIPELINE"
+)
+
+var AllCompositionMode = []CompositionMode{
+	CompositionModeResources,
+	CompositionModePipeline,
+}
+
+func (e CompositionMode) IsValid() bool {
+	switch e {
+	case CompositionModeResources, CompositionModePipeline:
+		return true
+	}
+	return false
+}
+
+func (e CompositionMode) String() string {
+	return string(e)
 }
 
-func (Secret) IsNode() {}
+func (e *CompositionMode) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
 
-func (Secret) IsKubernetesResource() {}
+	*e = CompositionMode(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CompositionMode", str)
+	}
+	return nil
+}
 
-// A `SecretReference` is a reference to a secret in an arbitrary namespace.
-type SecretReference struct {
-	// Name of the `Secret`.
-	Name string `json:"name"`
-	// Namespace of the `Secret`.
-	Namespace string `json:"namespace"`
+func (e CompositionMode) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-// A TypeReference references a type of Kubernetes resource by API version and
-// kind.
-type TypeReference struct {
-	// The Kubernetes API version of the referenced resource.
-	APIVersion string `json:"apiVersion"`
-	// The Kubernetes API kind of the referenced resource.
-	Kind string `json:"kind"`
+// A CompositionUpdatePolicy indicates how a composite resource should update to
+// new revisions of its composition.
+type CompositionUpdatePolicy string
+
+const (
+	// Automatically update to the latest revision of the composition.
+	CompositionUpdatePolicyAutomatic CompositionUpdatePolicy = "AUTOMATIC"
+	// Require a user to manually update to new revisions of the composition.
+	CompositionUpdatePolicyManual CompositionUpdatePolicy = "MANUAL"
+)
+
+var AllCompositionUpdatePolicy = []CompositionUpdatePolicy{
+	CompositionUpdatePolicyAutomatic,
+	CompositionUpdatePolicyManual,
 }
 
-// UpdateKubernetesResourceInput is the input required to update a Kubernetes
-// resource.
-type UpdateKubernetesResourceInput struct {
-	// The Kubernetes resource to be updated, as raw JSON.
-	Unstructured []byte `json:"unstructured"`
-	// Patches that should be applied to the Kubernetes resource before updating.
-	Patches []Patch `json:"patches,omitempty"`
+func (e CompositionUpdatePolicy) IsValid() bool {
+	switch e {
+	case CompositionUpdatePolicyAutomatic, CompositionUpdatePolicyManual:
+		return true
+	}
+	return false
 }
 
-// UpdateKubernetesResourcePayload is the result of updating a Kubernetes resource.
-type UpdateKubernetesResourcePayload struct {
-	// The updated Kubernetes resource. Null if the update failed.
-	Resource KubernetesResource `json:"resource,omitempty"`
+func (e CompositionUpdatePolicy) String() string {
+	return string(e)
+}
+
+func (e *CompositionUpdatePolicy) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CompositionUpdatePolicy(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CompositionUpdatePolicy", str)
+	}
+	return nil
+}
+
+func (e CompositionUpdatePolicy) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
 // A ConditionStatus represensts the status of a condition.
@@ -2252,6 +4635,119 @@ func (e ConditionStatus) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// A ContainerStateStatus is the status of a container.
+type ContainerStateStatus string
+
+const (
+	// The container is waiting to start running.
+	ContainerStateStatusWaiting ContainerStateStatus = "WAITING"
+	// The container is running.
+	ContainerStateStatusRunning ContainerStateStatus = "RUNNING"
+	// The container has terminated.
+	ContainerStateStatusTerminated ContainerStateStatus = "TERMINATED"
+)
+
+var AllContainerStateStatus = []ContainerStateStatus{
+	ContainerStateStatusWaiting,
+	ContainerStateStatusRunning,
+	ContainerStateStatusTerminated,
+}
+
+func (e ContainerStateStatus) IsValid() bool {
+	switch e {
+	case ContainerStateStatusWaiting, ContainerStateStatusRunning, ContainerStateStatusTerminated:
+		return true
+	}
+	return false
+}
+
+func (e ContainerStateStatus) String() string {
+	return string(e)
+}
+
+func (e *ContainerStateStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ContainerStateStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ContainerStateStatus", str)
+	}
+	return nil
+}
+
+func (e ContainerStateStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A `CrossplaneResourceTreeRelation` describes how a `CrossplaneResourceTreeNode`
+// relates to its parent.
+//
+// Note: A resource related to several resources in the tree, for example a
+// `ProviderConfig` used by several `ManagedResource`s, appears in the tree only
+// once, as a child of the first resource that relates to it.
+type CrossplaneResourceTreeRelation string
+
+const (
+	// The resource is the `CompositeResource` of its parent claim.
+	CrossplaneResourceTreeRelationCompositeResource CrossplaneResourceTreeRelation = "COMPOSITE_RESOURCE"
+	// The resource is composed by its parent `CompositeResource`.
+	CrossplaneResourceTreeRelationComposedResource CrossplaneResourceTreeRelation = "COMPOSED_RESOURCE"
+	// The resource is the `ProviderConfig` used by its parent `ManagedResource`.
+	CrossplaneResourceTreeRelationProviderConfig CrossplaneResourceTreeRelation = "PROVIDER_CONFIG"
+	// The resource is the `Secret` its parent writes its connection details to.
+	CrossplaneResourceTreeRelationConnectionSecret CrossplaneResourceTreeRelation = "CONNECTION_SECRET"
+	// The resource is the `Composition` used by its parent `CompositeResource`.
+	CrossplaneResourceTreeRelationComposition CrossplaneResourceTreeRelation = "COMPOSITION"
+	// The resource is the `CompositionRevision` used by its parent
+	// `CompositeResource`.
+	CrossplaneResourceTreeRelationCompositionRevision CrossplaneResourceTreeRelation = "COMPOSITION_REVISION"
+	// The resource is an `EnvironmentConfig` used by its parent
+	// `CompositeResource`.
+	CrossplaneResourceTreeRelationEnvironmentConfig CrossplaneResourceTreeRelation = "ENVIRONMENT_CONFIG"
+)
+
+var AllCrossplaneResourceTreeRelation = []CrossplaneResourceTreeRelation{
+	CrossplaneResourceTreeRelationCompositeResource,
+	CrossplaneResourceTreeRelationComposedResource,
+	CrossplaneResourceTreeRelationProviderConfig,
+	CrossplaneResourceTreeRelationConnectionSecret,
+	CrossplaneResourceTreeRelationComposition,
+	CrossplaneResourceTreeRelationCompositionRevision,
+	CrossplaneResourceTreeRelationEnvironmentConfig,
+}
+
+func (e CrossplaneResourceTreeRelation) IsValid() bool {
+	switch e {
+	case CrossplaneResourceTreeRelationCompositeResource, CrossplaneResourceTreeRelationComposedResource, CrossplaneResourceTreeRelationProviderConfig, CrossplaneResourceTreeRelationConnectionSecret, CrossplaneResourceTreeRelationComposition, CrossplaneResourceTreeRelationCompositionRevision, CrossplaneResourceTreeRelationEnvironmentConfig:
+		return true
+	}
+	return false
+}
+
+func (e CrossplaneResourceTreeRelation) String() string {
+	return string(e)
+}
+
+func (e *CrossplaneResourceTreeRelation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CrossplaneResourceTreeRelation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CrossplaneResourceTreeRelation", str)
+	}
+	return nil
+}
+
+func (e CrossplaneResourceTreeRelation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // A DeletionPolicy specifies what will happen to the underlying external resource
 // when this managed resource is deleted - either "Delete" or "Orphan" the external
 // resource.
@@ -2344,6 +4840,145 @@ func (e EventType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// A FunctionCredentialsSource determines where a function's credentials are
+// loaded from.
+type FunctionCredentialsSource string
+
+const (
+	// The function doesn't need credentials.
+	FunctionCredentialsSourceNone FunctionCredentialsSource = "NONE"
+	// The function's credentials are loaded from a secret.
+	FunctionCredentialsSourceSecret FunctionCredentialsSource = "SECRET"
+)
+
+var AllFunctionCredentialsSource = []FunctionCredentialsSource{
+	FunctionCredentialsSourceNone,
+	FunctionCredentialsSourceSecret,
+}
+
+func (e FunctionCredentialsSource) IsValid() bool {
+	switch e {
+	case FunctionCredentialsSourceNone, FunctionCredentialsSourceSecret:
+		return true
+	}
+	return false
+}
+
+func (e FunctionCredentialsSource) String() string {
+	return string(e)
+}
+
+func (e *FunctionCredentialsSource) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = FunctionCredentialsSource(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid FunctionCredentialsSource", str)
+	}
+	return nil
+}
+
+func (e FunctionCredentialsSource) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A LabelSelectorOperator relates a label key to a set of values.
+type LabelSelectorOperator string
+
+const (
+	// The label's value must be one of the supplied values.
+	LabelSelectorOperatorIn LabelSelectorOperator = "IN"
+	// The label's value must not be one of the supplied values.
+	LabelSelectorOperatorNotIn LabelSelectorOperator = "NOT_IN"
+	// The label must exist.
+	LabelSelectorOperatorExists LabelSelectorOperator = "EXISTS"
+	// The label must not exist.
+	LabelSelectorOperatorDoesNotExist LabelSelectorOperator = "DOES_NOT_EXIST"
+)
+
+var AllLabelSelectorOperator = []LabelSelectorOperator{
+	LabelSelectorOperatorIn,
+	LabelSelectorOperatorNotIn,
+	LabelSelectorOperatorExists,
+	LabelSelectorOperatorDoesNotExist,
+}
+
+func (e LabelSelectorOperator) IsValid() bool {
+	switch e {
+	case LabelSelectorOperatorIn, LabelSelectorOperatorNotIn, LabelSelectorOperatorExists, LabelSelectorOperatorDoesNotExist:
+		return true
+	}
+	return false
+}
+
+func (e LabelSelectorOperator) String() string {
+	return string(e)
+}
+
+func (e *LabelSelectorOperator) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LabelSelectorOperator(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LabelSelectorOperator", str)
+	}
+	return nil
+}
+
+func (e LabelSelectorOperator) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// OrderDirection is the direction in which nodes are sorted.
+type OrderDirection string
+
+const (
+	// Sort in ascending order.
+	OrderDirectionAsc OrderDirection = "ASC"
+	// Sort in descending order.
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // A PackagePullPolicy represents when to pull a package OCI image from a registry.
 type PackagePullPolicy string
 
@@ -2436,6 +5071,101 @@ func (e PackageRevisionDesiredState) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+// A PackageType is the type of a package.
+type PackageType string
+
+const (
+	// A configuration package.
+	PackageTypeConfiguration PackageType = "CONFIGURATION"
+	// A provider package.
+	PackageTypeProvider PackageType = "PROVIDER"
+	// A function package.
+	PackageTypeFunction PackageType = "FUNCTION"
+)
+
+var AllPackageType = []PackageType{
+	PackageTypeConfiguration,
+	PackageTypeProvider,
+	PackageTypeFunction,
+}
+
+func (e PackageType) IsValid() bool {
+	switch e {
+	case PackageTypeConfiguration, PackageTypeProvider, PackageTypeFunction:
+		return true
+	}
+	return false
+}
+
+func (e PackageType) String() string {
+	return string(e)
+}
+
+func (e *PackageType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PackageType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PackageType", str)
+	}
+	return nil
+}
+
+func (e PackageType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// A PatchType is a type of patch that may be applied to a Kubernetes resource.
+type PatchType string
+
+const (
+	// An RFC 6902 JSON patch - i.e. a list of operations.
+	PatchTypeJSONPatch PatchType = "JSON_PATCH"
+	// An RFC 7386 JSON merge patch - i.e. a partial object.
+	PatchTypeMergePatch PatchType = "MERGE_PATCH"
+	// A Kubernetes strategic merge patch. Only supported by built-in types, not by
+	// custom resources.
+	PatchTypeStrategicMerge PatchType = "STRATEGIC_MERGE"
+)
+
+var AllPatchType = []PatchType{
+	PatchTypeJSONPatch,
+	PatchTypeMergePatch,
+	PatchTypeStrategicMerge,
+}
+
+func (e PatchType) IsValid() bool {
+	switch e {
+	case PatchTypeJSONPatch, PatchTypeMergePatch, PatchTypeStrategicMerge:
+		return true
+	}
+	return false
+}
+
+func (e PatchType) String() string {
+	return string(e)
+}
+
+func (e *PatchType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PatchType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PatchType", str)
+	}
+	return nil
+}
+
+func (e PatchType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 // ResourceScope defines the scopes available to custom resources.
 type ResourceScope string
 
@@ -0,0 +1,227 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+)
+
+// GetResourceReference returns a reference to the supplied resource, or nil if
+// the resource doesn't support paved access.
+func GetResourceReference(kr KubernetesResource) *corev1.ObjectReference {
+	p, ok := kr.(paved)
+	if !ok || p.paved() == nil {
+		return nil
+	}
+	u := &kunstructured.Unstructured{Object: p.paved().UnstructuredContent()}
+	return &corev1.ObjectReference{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Namespace:  u.GetNamespace(),
+		Name:       u.GetName(),
+		UID:        u.GetUID(),
+	}
+}
+
+// GetResourceConditions returns the conditions of the supplied resource. Any
+// resource may have conditions, so the conditions are read from the resource's
+// status.conditions field rather than its typed model.
+func GetResourceConditions(kr KubernetesResource) []Condition {
+	p, ok := kr.(paved)
+	if !ok || p.paved() == nil {
+		return nil
+	}
+	c := []xpv1.Condition{}
+	if err := p.paved().GetValueInto("status.conditions", &c); err != nil {
+		return nil
+	}
+	return GetConditions(c)
+}
+
+// GetResourceHealth returns the health of the supplied resource, which has the
+// supplied conditions. The latest warning event pertaining to the resource, if
+// any, is included. The returned health is never a root cause; see
+// SetRootCauses.
+func GetResourceHealth(conditions []Condition, warning *Event, now time.Time) ResourceHealth {
+	out := ResourceHealth{Healthy: true, LatestWarningEvent: warning}
+
+	var ready, synced, other *Condition
+	for i := range conditions {
+		c := &conditions[i]
+
+		switch c.Type {
+		case string(xpv1.TypeReady):
+			out.Ready = &c.Status
+			ready = c
+		case string(xpv1.TypeSynced):
+			out.Synced = &c.Status
+			synced = c
+		default:
+			if other == nil && c.Status != ConditionStatusTrue {
+				other = c
+			}
+		}
+
+		if out.LastTransitionTime == nil || c.LastTransitionTime.After(*out.LastTransitionTime) {
+			t := c.LastTransitionTime
+			out.LastTransitionTime = &t
+		}
+	}
+
+	// A resource that can't be synced usually can't become ready either, so
+	// the Synced condition is the most relevant.
+	for _, c := range []*Condition{synced, ready} {
+		if c != nil && c.Status != ConditionStatusTrue {
+			out.Healthy = false
+			if out.FailingCondition == nil {
+				out.FailingCondition = c
+			}
+		}
+	}
+	if out.FailingCondition == nil {
+		out.FailingCondition = other
+	}
+
+	if out.LastTransitionTime != nil {
+		s := int(now.Sub(*out.LastTransitionTime).Seconds())
+		out.SecondsSinceLastTransition = &s
+	}
+
+	return out
+}
+
+// SetRootCauses marks each of the supplied nodes that is a root cause of its
+// parent's unhealthiness. A root cause is an unhealthy node whose parent, if
+// any, is also unhealthy, and whose children are all healthy.
+func SetRootCauses(nodes []TraceNode) {
+	healthy := make(map[ReferenceID]bool, len(nodes))
+	unhealthyChildren := make(map[ReferenceID]bool, len(nodes))
+	for _, n := range nodes {
+		healthy[resourceID(n.Resource)] = n.Health.Healthy
+		if n.ParentID != nil && !n.Health.Healthy {
+			unhealthyChildren[*n.ParentID] = true
+		}
+	}
+
+	for i := range nodes {
+		n := &nodes[i]
+		switch {
+		case n.Health.Healthy:
+			continue
+		case unhealthyChildren[resourceID(n.Resource)]:
+			continue
+		case n.ParentID != nil && healthy[*n.ParentID]:
+			continue
+		}
+		n.Health.RootCause = true
+	}
+}
+
+// GetResourceHealthSummary summarizes the health of the supplied resources.
+func GetResourceHealthSummary(in []ConditionedModel) ResourceHealthSummary {
+	return ResourceHealthSummary{
+		Total:   len(in),
+		Ready:   getConditionSummary(in, string(xpv1.TypeReady)),
+		Synced:  getConditionSummary(in, string(xpv1.TypeSynced)),
+		Healthy: getConditionSummary(in, string(pkgv1.TypeHealthy)),
+	}
+}
+
+func getConditionSummary(in []ConditionedModel, t string) ConditionSummary {
+	out := ConditionSummary{}
+	reasons := map[string]int{}
+	for _, m := range in {
+		c := getCondition(m, t)
+		if c == nil {
+			out.AbsentCount++
+			continue
+		}
+		switch c.Status {
+		case ConditionStatusTrue:
+			out.TrueCount++
+		case ConditionStatusFalse:
+			out.FalseCount++
+		default:
+			out.UnknownCount++
+		}
+		if c.Reason != "" {
+			reasons[c.Reason]++
+		}
+	}
+
+	out.Reasons = make([]ReasonCount, 0, len(reasons))
+	for r, n := range reasons {
+		out.Reasons = append(out.Reasons, ReasonCount{Reason: r, Count: n})
+	}
+	sort.Slice(out.Reasons, func(i, j int) bool {
+		if out.Reasons[i].Count != out.Reasons[j].Count {
+			return out.Reasons[i].Count > out.Reasons[j].Count
+		}
+		return out.Reasons[i].Reason < out.Reasons[j].Reason
+	})
+	return out
+}
+
+// GetTopFailingReasons returns up to n of the most common reasons for the
+// Ready, Synced and Healthy conditions of the supplied resources not to be
+// True, most common first.
+func GetTopFailingReasons(in []ConditionedModel, n int) []FailingReason {
+	type key struct{ t, reason string }
+	counts := map[key]int{}
+	for _, m := range in {
+		for _, t := range []string{string(xpv1.TypeReady), string(xpv1.TypeSynced), string(pkgv1.TypeHealthy)} {
+			c := getCondition(m, t)
+			if c == nil || c.Status == ConditionStatusTrue || c.Reason == "" {
+				continue
+			}
+			counts[key{t: t, reason: c.Reason}]++
+		}
+	}
+
+	out := make([]FailingReason, 0, len(counts))
+	for k, c := range counts {
+		out = append(out, FailingReason{Type: k.t, Reason: k.reason, Count: c})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		switch {
+		case out[i].Count != out[j].Count:
+			return out[i].Count > out[j].Count
+		case out[i].Type != out[j].Type:
+			return out[i].Type < out[j].Type
+		}
+		return out[i].Reason < out[j].Reason
+	})
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+func getCondition(m ConditionedModel, t string) *Condition {
+	cs := m.GetConditions()
+	for i := range cs {
+		if cs[i].Type == t {
+			return &cs[i]
+		}
+	}
+	return nil
+}
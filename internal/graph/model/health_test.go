@@ -0,0 +1,269 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+func TestGetResourceHealth(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)
+	earlier := now.Add(-1 * time.Minute)
+	later := now.Add(-30 * time.Second)
+
+	warning := &Event{Message: ptr.To("boom")}
+
+	cases := map[string]struct {
+		reason     string
+		conditions []Condition
+		warning    *Event
+		want       ResourceHealth
+	}{
+		"NoConditions": {
+			reason: "A resource without conditions should be healthy.",
+			want:   ResourceHealth{Healthy: true},
+		},
+		"Healthy": {
+			reason: "A resource whose Ready and Synced conditions are True should be healthy.",
+			conditions: []Condition{
+				{Type: "Synced", Status: ConditionStatusTrue, LastTransitionTime: earlier},
+				{Type: "Ready", Status: ConditionStatusTrue, LastTransitionTime: later},
+			},
+			want: ResourceHealth{
+				Ready:                      ptr.To(ConditionStatusTrue),
+				Synced:                     ptr.To(ConditionStatusTrue),
+				Healthy:                    true,
+				LastTransitionTime:         &later,
+				SecondsSinceLastTransition: ptr.To(30),
+			},
+		},
+		"NotSynced": {
+			reason: "A Synced condition that isn't True should be more relevant than a Ready condition that isn't True.",
+			conditions: []Condition{
+				{Type: "Ready", Status: ConditionStatusFalse, LastTransitionTime: later},
+				{Type: "Synced", Status: ConditionStatusFalse, LastTransitionTime: earlier, Message: ptr.To("boom")},
+			},
+			warning: warning,
+			want: ResourceHealth{
+				Ready:                      ptr.To(ConditionStatusFalse),
+				Synced:                     ptr.To(ConditionStatusFalse),
+				FailingCondition:           &Condition{Type: "Synced", Status: ConditionStatusFalse, LastTransitionTime: earlier, Message: ptr.To("boom")},
+				LatestWarningEvent:         warning,
+				LastTransitionTime:         &later,
+				SecondsSinceLastTransition: ptr.To(30),
+			},
+		},
+		"OtherCondition": {
+			reason: "A condition of another type that isn't True should fail a resource that is otherwise healthy, but not make it unhealthy.",
+			conditions: []Condition{
+				{Type: "Healthy", Status: ConditionStatusUnknown, LastTransitionTime: earlier},
+			},
+			want: ResourceHealth{
+				Healthy:                    true,
+				FailingCondition:           &Condition{Type: "Healthy", Status: ConditionStatusUnknown, LastTransitionTime: earlier},
+				LastTransitionTime:         &earlier,
+				SecondsSinceLastTransition: ptr.To(60),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetResourceHealth(tc.conditions, tc.warning, now)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Event{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetResourceHealth(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetResourceConditions(t *testing.T) {
+	r := GenericResource{PavedAccess: PavedAccess{fieldpath.Pave(map[string]any{
+		"status": map[string]any{
+			"conditions": []any{
+				map[string]any{"type": "Ready", "status": "False", "reason": "Creating"},
+			},
+		},
+	})}}
+
+	want := []Condition{{Type: "Ready", Status: ConditionStatusFalse, Reason: "Creating"}}
+	if diff := cmp.Diff(want, GetResourceConditions(r)); diff != "" {
+		t.Errorf("\nGetResourceConditions(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestSetRootCauses(t *testing.T) {
+	node := func(name string, parent *string, healthy bool) TraceNode {
+		n := TraceNode{Resource: GenericResource{ID: ReferenceID{Name: name}}, Health: ResourceHealth{Healthy: healthy}}
+		if parent != nil {
+			n.ParentID = &ReferenceID{Name: *parent}
+		}
+		return n
+	}
+	causes := func(nodes []TraceNode) []string {
+		out := []string{}
+		for _, n := range nodes {
+			if n.Health.RootCause {
+				out = append(out, n.Resource.(GenericResource).ID.Name)
+			}
+		}
+		return out
+	}
+
+	cases := map[string]struct {
+		reason string
+		nodes  []TraceNode
+		want   []string
+	}{
+		"AllHealthy": {
+			reason: "A tree of healthy nodes has no root causes.",
+			nodes: []TraceNode{
+				node("claim", nil, true),
+				node("xr", ptr.To("claim"), true),
+			},
+			want: []string{},
+		},
+		"UnhealthyLeaves": {
+			reason: "Unhealthy leaves of unhealthy parents are root causes, while unhealthy parents with unhealthy children are not.",
+			nodes: []TraceNode{
+				node("claim", nil, false),
+				node("xr", ptr.To("claim"), false),
+				node("bucket", ptr.To("xr"), false),
+				node("policy", ptr.To("xr"), true),
+				node("role", ptr.To("xr"), false),
+			},
+			want: []string{"bucket", "role"},
+		},
+		"UnhealthyRoot": {
+			reason: "An unhealthy root whose children are all healthy is a root cause.",
+			nodes: []TraceNode{
+				node("claim", nil, false),
+				node("xr", ptr.To("claim"), true),
+			},
+			want: []string{"claim"},
+		},
+		"HealthyParent": {
+			reason: "An unhealthy node whose parent is healthy is not a root cause of its parent's unhealthiness.",
+			nodes: []TraceNode{
+				node("xr", nil, true),
+				node("bucket", ptr.To("xr"), false),
+			},
+			want: []string{},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			SetRootCauses(tc.nodes)
+			if diff := cmp.Diff(tc.want, causes(tc.nodes)); diff != "" {
+				t.Errorf("\n%s\nSetRootCauses(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetResourceHealthSummary(t *testing.T) {
+	mr := func(conditions ...Condition) ConditionedModel {
+		return &ManagedResource{Status: &ManagedResourceStatus{Conditions: conditions}}
+	}
+
+	in := []ConditionedModel{
+		mr(Condition{Type: "Ready", Status: ConditionStatusTrue, Reason: "Available"}, Condition{Type: "Synced", Status: ConditionStatusTrue, Reason: "ReconcileSuccess"}),
+		mr(Condition{Type: "Ready", Status: ConditionStatusFalse, Reason: "Creating"}, Condition{Type: "Synced", Status: ConditionStatusFalse, Reason: "ReconcileError"}),
+		mr(Condition{Type: "Ready", Status: ConditionStatusFalse, Reason: "Creating"}),
+		mr(Condition{Type: "Ready", Status: ConditionStatusUnknown}),
+		&ManagedResource{},
+	}
+
+	want := ResourceHealthSummary{
+		Total: 5,
+		Ready: ConditionSummary{
+			TrueCount:    1,
+			FalseCount:   2,
+			UnknownCount: 1,
+			AbsentCount:  1,
+			Reasons:      []ReasonCount{{Reason: "Creating", Count: 2}, {Reason: "Available", Count: 1}},
+		},
+		Synced: ConditionSummary{
+			TrueCount:   1,
+			FalseCount:  1,
+			AbsentCount: 3,
+			Reasons:     []ReasonCount{{Reason: "ReconcileError", Count: 1}, {Reason: "ReconcileSuccess", Count: 1}},
+		},
+		Healthy: ConditionSummary{
+			AbsentCount: 5,
+			Reasons:     []ReasonCount{},
+		},
+	}
+	if diff := cmp.Diff(want, GetResourceHealthSummary(in)); diff != "" {
+		t.Errorf("\nGetResourceHealthSummary(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestGetTopFailingReasons(t *testing.T) {
+	in := []ConditionedModel{
+		&CompositeResource{Status: &CompositeResourceStatus{Conditions: []Condition{
+			{Type: "Ready", Status: ConditionStatusFalse, Reason: "Creating"},
+			{Type: "Synced", Status: ConditionStatusFalse, Reason: "ReconcileError"},
+		}}},
+		&ManagedResource{Status: &ManagedResourceStatus{Conditions: []Condition{
+			{Type: "Ready", Status: ConditionStatusFalse, Reason: "Creating"},
+			{Type: "Synced", Status: ConditionStatusTrue, Reason: "ReconcileSuccess"},
+		}}},
+		&Provider{Status: &ProviderStatus{Conditions: []Condition{
+			{Type: "Healthy", Status: ConditionStatusUnknown, Reason: "UnknownHealth"},
+			{Type: "Installed", Status: ConditionStatusFalse, Reason: "Inactive"},
+		}}},
+	}
+
+	cases := map[string]struct {
+		reason string
+		n      int
+		want   []FailingReason
+	}{
+		"All": {
+			reason: "We should count reasons for Ready, Synced and Healthy conditions that aren't True, most common first.",
+			n:      10,
+			want: []FailingReason{
+				{Type: "Ready", Reason: "Creating", Count: 2},
+				{Type: "Healthy", Reason: "UnknownHealth", Count: 1},
+				{Type: "Synced", Reason: "ReconcileError", Count: 1},
+			},
+		},
+		"Top": {
+			reason: "We should return at most n reasons.",
+			n:      1,
+			want: []FailingReason{
+				{Type: "Ready", Reason: "Creating", Count: 2},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetTopFailingReasons(in, tc.n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGetTopFailingReasons(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+// Order errors.
+const (
+	errFmtOrderField  = "cannot parse order field %q"
+	errOrderUnpavable = "cannot order node without paved access"
+)
+
+// order sorts the supplied nodes by the values at the field paths of the
+// supplied orders, in order of precedence. obj returns the object whose fields
+// should be sorted by for each node. Nodes that sort equally retain their
+// existing relative order, so callers should sort nodes by their default order
+// first.
+func order[N any](nodes []N, o []OrderBy, obj func(N) any) error {
+	if len(o) == 0 {
+		return nil
+	}
+
+	paths := make([]fieldpath.Segments, len(o))
+	for i := range o {
+		s, err := fieldpath.Parse(o[i].Field)
+		if err != nil {
+			return errors.Wrapf(err, errFmtOrderField, o[i].Field)
+		}
+		paths[i] = s
+	}
+
+	keys := make([][]any, len(nodes))
+	for i := range nodes {
+		p, ok := obj(nodes[i]).(paved)
+		if !ok {
+			return errors.New(errOrderUnpavable)
+		}
+		keys[i] = make([]any, len(paths))
+		for j := range paths {
+			keys[i][j] = lookup(p.paved().UnstructuredContent(), paths[j])
+		}
+	}
+
+	idx := make([]int, len(nodes))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		for j := range o {
+			ka, kb := keys[idx[a]][j], keys[idx[b]][j]
+			c := compare(ka, kb)
+			if c == 0 {
+				continue
+			}
+			// Missing values sort last regardless of direction.
+			if ka != nil && kb != nil && o[j].Direction != nil && *o[j].Direction == OrderDirectionDesc {
+				c = -c
+			}
+			return c < 0
+		}
+		return false
+	})
+
+	sorted := make([]N, len(nodes))
+	for i, j := range idx {
+		sorted[i] = nodes[j]
+	}
+	copy(nodes, sorted)
+	return nil
+}
+
+// lookup returns the value at the supplied path, or nil if there is no such
+// value. Unlike fieldpath.Paved it supports selecting an element of an array
+// of objects by its type field, e.g. status.conditions[Ready].
+func lookup(v any, path fieldpath.Segments) any {
+	for _, s := range path {
+		switch s.Type {
+		case fieldpath.SegmentField:
+			switch t := v.(type) {
+			case map[string]any:
+				v = t[s.Field]
+			case []any:
+				v = nil
+				for _, e := range t {
+					if m, ok := e.(map[string]any); ok && m["type"] == s.Field {
+						v = m
+						break
+					}
+				}
+			default:
+				return nil
+			}
+		case fieldpath.SegmentIndex:
+			t, ok := v.([]any)
+			if !ok || s.Index >= uint(len(t)) {
+				return nil
+			}
+			v = t[s.Index]
+		}
+	}
+	return v
+}
+
+// compare returns a negative number if a sorts before b, a positive number if
+// a sorts after b, and zero if they sort equally. Nil values sort last.
+func compare(a, b any) int { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a long but simple type switch.
+
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return 1
+	case b == nil:
+		return -1
+	}
+
+	if fa, ok := number(a); ok {
+		if fb, ok := number(b); ok {
+			switch {
+			case fa < fb:
+				return -1
+			case fa > fb:
+				return 1
+			}
+			return 0
+		}
+	}
+
+	switch ta := a.(type) {
+	case string:
+		if tb, ok := b.(string); ok {
+			return strings.Compare(ta, tb)
+		}
+	case bool:
+		if tb, ok := b.(bool); ok {
+			switch {
+			case ta == tb:
+				return 0
+			case !ta:
+				return -1
+			}
+			return 1
+		}
+	}
+
+	// Values of different or complex types are compared by their JSON
+	// representations, which is arbitrary but deterministic.
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return strings.Compare(string(ja), string(jb))
+}
+
+func number(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *KubernetesResourceConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n KubernetesResource) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *EventConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Event) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *OwnerConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Owner) any { return n.Resource })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CrossplaneResourceTreeConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n CrossplaneResourceTreeNode) any { return n.Resource })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ProviderConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Provider) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ProviderRevisionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n ProviderRevision) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *FunctionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Function) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *FunctionRevisionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n FunctionRevision) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CustomResourceDefinitionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n CustomResourceDefinition) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ConfigurationConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Configuration) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ConfigurationRevisionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n ConfigurationRevision) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CompositeResourceDefinitionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n CompositeResourceDefinition) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CompositionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Composition) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CompositionRevisionConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n CompositionRevision) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *EnvironmentConfigConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n EnvironmentConfig) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *UsageConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n Usage) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CompositeResourceConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n CompositeResource) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *CompositeResourceClaimConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n CompositeResourceClaim) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ManagedResourceConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n ManagedResource) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ProviderConfigConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n ProviderConfig) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *ProviderConfigUsageConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n ProviderConfigUsage) any { return n })
+}
+
+// Order sorts the connection's nodes by the supplied orders.
+func (c *TraceConnection) Order(o []OrderBy) error {
+	return order(c.Nodes, o, func(n TraceNode) any { return n.Resource })
+}
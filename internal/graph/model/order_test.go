@@ -0,0 +1,117 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+)
+
+func TestKubernetesResourceConnectionOrder(t *testing.T) {
+	resource := func(name string, obj map[string]any) KubernetesResource {
+		obj["metadata"] = map[string]any{"name": name}
+		return GenericResource{ID: ReferenceID{Name: name}, PavedAccess: PavedAccess{fieldpath.Pave(obj)}}
+	}
+	ready := func(at string) map[string]any {
+		return map[string]any{"status": map[string]any{"conditions": []any{
+			map[string]any{"type": "Synced", "lastTransitionTime": "2024-01-01T00:00:00Z"},
+			map[string]any{"type": "Ready", "lastTransitionTime": at},
+		}}}
+	}
+
+	a := resource("a", ready("2024-03-01T00:00:00Z"))
+	b := resource("b", ready("2024-02-01T00:00:00Z"))
+	c := resource("c", map[string]any{"spec": map[string]any{"replicas": int64(10)}})
+	d := resource("d", map[string]any{"spec": map[string]any{"replicas": int64(9)}})
+
+	names := func(nodes []KubernetesResource) []string {
+		out := make([]string, len(nodes))
+		for i := range nodes {
+			out[i] = nodes[i].(GenericResource).ID.Name
+		}
+		return out
+	}
+
+	cases := map[string]struct {
+		reason  string
+		nodes   []KubernetesResource
+		o       []OrderBy
+		want    []string
+		wantErr bool
+	}{
+		"NoOrder": {
+			reason: "Nodes should retain their existing order when no order is supplied.",
+			nodes:  []KubernetesResource{c, a, b},
+			want:   []string{"c", "a", "b"},
+		},
+		"Ascending": {
+			reason: "Nodes should be sorted in ascending order by default.",
+			nodes:  []KubernetesResource{c, b, a},
+			o:      []OrderBy{{Field: "metadata.name"}},
+			want:   []string{"a", "b", "c"},
+		},
+		"Descending": {
+			reason: "Nodes should be sorted in descending order when requested.",
+			nodes:  []KubernetesResource{a, c, b},
+			o:      []OrderBy{{Field: "metadata.name", Direction: ptr.To(OrderDirectionDesc)}},
+			want:   []string{"c", "b", "a"},
+		},
+		"Numeric": {
+			reason: "Numbers should be sorted by value, not lexically.",
+			nodes:  []KubernetesResource{c, d},
+			o:      []OrderBy{{Field: "spec.replicas"}},
+			want:   []string{"d", "c"},
+		},
+		"ConditionByType": {
+			reason: "A bracketed name should select the array element with that type, and missing values should sort last.",
+			nodes:  []KubernetesResource{c, a, d, b},
+			o:      []OrderBy{{Field: "status.conditions[Ready].lastTransitionTime", Direction: ptr.To(OrderDirectionDesc)}},
+			want:   []string{"a", "b", "c", "d"},
+		},
+		"Precedence": {
+			reason: "Later orders should only apply to nodes that sort equally by earlier orders.",
+			nodes:  []KubernetesResource{a, d, b, c},
+			o: []OrderBy{
+				{Field: "status.conditions[Ready].lastTransitionTime"},
+				{Field: "metadata.name", Direction: ptr.To(OrderDirectionDesc)},
+			},
+			want: []string{"b", "a", "d", "c"},
+		},
+		"InvalidField": {
+			reason:  "A field that can't be parsed should return an error.",
+			nodes:   []KubernetesResource{a, b},
+			o:       []OrderBy{{Field: "metadata..name"}},
+			want:    []string{"a", "b"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &KubernetesResourceConnection{Nodes: tc.nodes, TotalCount: len(tc.nodes)}
+			err := c.Order(tc.o)
+			if diff := cmp.Diff(tc.wantErr, err != nil); diff != "" {
+				t.Errorf("\n%s\nc.Order(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, names(c.Nodes)); diff != "" {
+				t.Errorf("\n%s\nc.Order(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 )
 
 // GetRevisionActivationPolicy from the supplied Crossplane policy.
@@ -111,6 +113,8 @@ func GetProvider(p *pkgv1.Provider) Provider {
 			PackagePullPolicy:           GetPackagePullPolicy(p.Spec.PackagePullPolicy),
 			IgnoreCrossplaneConstraints: p.Spec.IgnoreCrossplaneConstraints,
 			SkipDependencyResolution:    p.Spec.SkipDependencyResolution,
+			RuntimeConfigRef:            GetRuntimeConfigReference(p.Spec.RuntimeConfigReference),
+			ControllerConfigRef:         GetControllerConfigReference(p.Spec.ControllerConfigReference),
 		},
 		Status: GetProviderStatus(p.Status),
 		PavedAccess: PavedAccess{
@@ -173,6 +177,96 @@ func GetProviderRevision(pr *pkgv1.ProviderRevision) ProviderRevision {
 	}
 }
 
+// GetFunctionStatus from the supplied Kubernetes status.
+func GetFunctionStatus(in pkgv1.FunctionStatus) *FunctionStatus {
+	out := &FunctionStatus{Conditions: GetConditions(in.Conditions)}
+	if in.CurrentRevision != "" {
+		out.CurrentRevision = &in.CurrentRevision
+	}
+	if in.CurrentIdentifier != "" {
+		out.CurrentIdentifier = &in.CurrentIdentifier
+	}
+	if cmp.Equal(out, &FunctionStatus{}) {
+		return nil
+	}
+	return out
+}
+
+// GetFunction from the supplied Kubernetes function.
+func GetFunction(f *pkgv1.Function) Function {
+	return Function{
+		ID: ReferenceID{
+			APIVersion: f.APIVersion,
+			Kind:       f.Kind,
+			Name:       f.GetName(),
+		},
+
+		APIVersion: f.APIVersion,
+		Kind:       f.Kind,
+		Metadata:   GetObjectMeta(f),
+		Spec: FunctionSpec{
+			Package:                     f.Spec.Package,
+			RevisionActivationPolicy:    GetRevisionActivationPolicy(f.Spec.RevisionActivationPolicy),
+			RevisionHistoryLimit:        getIntPtr(f.Spec.RevisionHistoryLimit),
+			PackagePullPolicy:           GetPackagePullPolicy(f.Spec.PackagePullPolicy),
+			IgnoreCrossplaneConstraints: f.Spec.IgnoreCrossplaneConstraints,
+			SkipDependencyResolution:    f.Spec.SkipDependencyResolution,
+			RuntimeConfigRef:            GetRuntimeConfigReference(f.Spec.RuntimeConfigReference),
+			ControllerConfigRef:         GetControllerConfigReference(f.Spec.ControllerConfigReference),
+		},
+		Status: GetFunctionStatus(f.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(f),
+		},
+	}
+}
+
+// GetFunctionRevisionStatus from the supplied Crossplane function revision.
+func GetFunctionRevisionStatus(in pkgv1.FunctionRevisionStatus) *FunctionRevisionStatus {
+	out := &FunctionRevisionStatus{
+		Conditions:            GetConditions(in.Conditions),
+		ObjectRefs:            in.ObjectRefs,
+		FoundDependencies:     getIntPtr(&in.FoundDependencies),
+		InstalledDependencies: getIntPtr(&in.InstalledDependencies),
+		InvalidDependencies:   getIntPtr(&in.InvalidDependencies),
+		PermissionRequests:    GetPolicyRules(in.PermissionRequests),
+	}
+	if in.Endpoint != "" {
+		out.Endpoint = &in.Endpoint
+	}
+	if cmp.Equal(out, &FunctionRevisionStatus{}) {
+		return nil
+	}
+	return out
+}
+
+// GetFunctionRevision from the supplied Crossplane function revision.
+func GetFunctionRevision(fr *pkgv1.FunctionRevision) FunctionRevision {
+	return FunctionRevision{
+		ID: ReferenceID{
+			APIVersion: fr.APIVersion,
+			Kind:       fr.Kind,
+			Name:       fr.GetName(),
+		},
+
+		APIVersion: fr.APIVersion,
+		Kind:       fr.Kind,
+		Metadata:   GetObjectMeta(fr),
+		Spec: FunctionRevisionSpec{
+			DesiredState:                GetPackageRevisionDesiredState(fr.Spec.DesiredState),
+			Package:                     fr.Spec.Package,
+			PackagePullPolicy:           GetPackagePullPolicy(fr.Spec.PackagePullPolicy),
+			Revision:                    int(fr.Spec.Revision),
+			IgnoreCrossplaneConstraints: fr.Spec.IgnoreCrossplaneConstraints,
+			SkipDependencyResolution:    fr.Spec.SkipDependencyResolution,
+		},
+		Status: GetFunctionRevisionStatus(fr.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(fr),
+		},
+	}
+}
+
 // GetConfigurationStatus from the supplied Kubernetes status.
 func GetConfigurationStatus(in pkgv1.ConfigurationStatus) *ConfigurationStatus {
 	out := &ConfigurationStatus{Conditions: GetConditions(in.Conditions)}
@@ -257,3 +351,171 @@ func GetConfigurationRevision(cr *pkgv1.ConfigurationRevision) ConfigurationRevi
 		},
 	}
 }
+
+// GetRuntimeConfigReference from the supplied Crossplane reference.
+func GetRuntimeConfigReference(in *pkgv1.RuntimeConfigReference) *RuntimeConfigReference {
+	if in == nil {
+		return nil
+	}
+	return &RuntimeConfigReference{
+		APIVersion: in.APIVersion,
+		Kind:       in.Kind,
+		Name:       in.Name,
+	}
+}
+
+// GetControllerConfigReference from the supplied Crossplane reference.
+func GetControllerConfigReference(in *pkgv1.ControllerConfigReference) *LocalObjectReference {
+	if in == nil {
+		return nil
+	}
+	return &LocalObjectReference{Name: in.Name}
+}
+
+// GetDeploymentRuntimeConfig from the supplied Crossplane runtime config.
+func GetDeploymentRuntimeConfig(rc *pkgv1beta1.DeploymentRuntimeConfig) DeploymentRuntimeConfig {
+	out := DeploymentRuntimeConfig{
+		ID: ReferenceID{
+			APIVersion: rc.APIVersion,
+			Kind:       rc.Kind,
+			Name:       rc.GetName(),
+		},
+		APIVersion: rc.APIVersion,
+		Kind:       rc.Kind,
+		Metadata:   GetObjectMeta(rc),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(rc),
+		},
+	}
+	if rc.Spec.DeploymentTemplate != nil {
+		out.Spec.DeploymentTemplate = mustMarshal(rc.Spec.DeploymentTemplate)
+	}
+	if rc.Spec.ServiceTemplate != nil {
+		out.Spec.ServiceTemplate = mustMarshal(rc.Spec.ServiceTemplate)
+	}
+	if rc.Spec.ServiceAccountTemplate != nil {
+		out.Spec.ServiceAccountTemplate = mustMarshal(rc.Spec.ServiceAccountTemplate)
+	}
+	return out
+}
+
+// GetControllerConfigSpec from the supplied Crossplane spec.
+func GetControllerConfigSpec(in pkgv1alpha1.ControllerConfigSpec) ControllerConfigSpec {
+	out := ControllerConfigSpec{
+		Image:              in.Image,
+		ServiceAccountName: in.ServiceAccountName,
+		NodeSelector:       in.NodeSelector,
+		PriorityClassName:  in.PriorityClassName,
+		Args:               in.Args,
+	}
+	if in.Replicas != nil {
+		r := int(*in.Replicas)
+		out.Replicas = &r
+	}
+	if in.ImagePullPolicy != nil {
+		p := string(*in.ImagePullPolicy)
+		out.ImagePullPolicy = &p
+	}
+	if in.ResourceRequirements != nil {
+		out.Resources = mustMarshal(in.ResourceRequirements)
+	}
+	if in.PodSecurityContext != nil {
+		out.PodSecurityContext = mustMarshal(in.PodSecurityContext)
+	}
+	if in.SecurityContext != nil {
+		out.SecurityContext = mustMarshal(in.SecurityContext)
+	}
+	return out
+}
+
+// GetControllerConfig from the supplied Crossplane controller config.
+func GetControllerConfig(cc *pkgv1alpha1.ControllerConfig) ControllerConfig {
+	return ControllerConfig{
+		ID: ReferenceID{
+			APIVersion: cc.APIVersion,
+			Kind:       cc.Kind,
+			Name:       cc.GetName(),
+		},
+		APIVersion: cc.APIVersion,
+		Kind:       cc.Kind,
+		Metadata:   GetObjectMeta(cc),
+		Spec:       GetControllerConfigSpec(cc.Spec),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(cc),
+		},
+	}
+}
+
+// GetPackageType from the supplied Crossplane package type.
+func GetPackageType(in pkgv1beta1.PackageType) *PackageType {
+	switch in {
+	case pkgv1beta1.ConfigurationPackageType:
+		out := PackageTypeConfiguration
+		return &out
+	case pkgv1beta1.ProviderPackageType:
+		out := PackageTypeProvider
+		return &out
+	case pkgv1beta1.FunctionPackageType:
+		out := PackageTypeFunction
+		return &out
+	}
+	return nil
+}
+
+// GetLockPackage from the supplied Crossplane lock package. Each dependency is
+// resolved to the version of the package in the supplied lock that has the
+// dependency's source, if any.
+func GetLockPackage(in pkgv1beta1.LockPackage, l *pkgv1beta1.Lock) LockPackage {
+	out := LockPackage{
+		Name:    in.Name,
+		Type:    GetPackageType(in.Type),
+		Source:  in.Source,
+		Version: in.Version,
+	}
+	if in.Dependencies == nil {
+		return out
+	}
+
+	versions := make(map[string]string, len(l.Packages))
+	for _, p := range l.Packages {
+		versions[p.Source] = p.Version
+	}
+
+	out.Dependencies = make([]LockDependency, len(in.Dependencies))
+	for i, d := range in.Dependencies {
+		out.Dependencies[i] = LockDependency{
+			Package:     d.Package,
+			Type:        GetPackageType(d.Type),
+			Constraints: d.Constraints,
+		}
+		if v, ok := versions[d.Package]; ok {
+			out.Dependencies[i].Version = &v
+		}
+	}
+	return out
+}
+
+// GetPackageLock from the supplied Crossplane lock.
+func GetPackageLock(l *pkgv1beta1.Lock) PackageLock {
+	out := PackageLock{
+		ID: ReferenceID{
+			APIVersion: l.APIVersion,
+			Kind:       l.Kind,
+			Name:       l.GetName(),
+		},
+		APIVersion: l.APIVersion,
+		Kind:       l.Kind,
+		Metadata:   GetObjectMeta(l),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(l),
+		},
+	}
+	if l.Packages == nil {
+		return out
+	}
+	out.Packages = make([]LockPackage, len(l.Packages))
+	for i := range l.Packages {
+		out.Packages[i] = GetLockPackage(l.Packages[i], l)
+	}
+	return out
+}
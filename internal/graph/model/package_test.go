@@ -21,11 +21,14 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 )
 
 func TestGetProvider(t *testing.T) {
@@ -61,6 +64,14 @@ func TestGetProvider(t *testing.T) {
 						IgnoreCrossplaneConstraints: ptr.To(true),
 						SkipDependencyResolution:    ptr.To(true),
 					},
+					PackageRuntimeSpec: pkgv1.PackageRuntimeSpec{
+						RuntimeConfigReference: &pkgv1.RuntimeConfigReference{
+							APIVersion: ptr.To("pkg.crossplane.io/v1beta1"),
+							Kind:       ptr.To("DeploymentRuntimeConfig"),
+							Name:       "coolruntime",
+						},
+						ControllerConfigReference: &pkgv1.ControllerConfigReference{Name: "coolconfig"},
+					},
 				},
 				Status: pkgv1.ProviderStatus{
 					ConditionedStatus: xpv1.ConditionedStatus{
@@ -90,6 +101,12 @@ func TestGetProvider(t *testing.T) {
 					PackagePullPolicy:           &mppp,
 					IgnoreCrossplaneConstraints: ptr.To(true),
 					SkipDependencyResolution:    ptr.To(true),
+					RuntimeConfigRef: &RuntimeConfigReference{
+						APIVersion: ptr.To("pkg.crossplane.io/v1beta1"),
+						Kind:       ptr.To("DeploymentRuntimeConfig"),
+						Name:       "coolruntime",
+					},
+					ControllerConfigRef: &LocalObjectReference{Name: "coolconfig"},
 				},
 				Status: &ProviderStatus{
 					Conditions:        []Condition{{}},
@@ -222,6 +239,218 @@ func TestGetProviderRevision(t *testing.T) {
 	}
 }
 
+func TestGetFunction(t *testing.T) {
+	rap := pkgv1.ManualActivation
+	lim := int64(42)
+	ppp := corev1.PullAlways
+
+	mrap := RevisionActivationPolicyManual
+	mlim := 42
+	mppp := PackagePullPolicyAlways
+
+	cases := map[string]struct {
+		reason string
+		cfg    *pkgv1.Function
+		want   Function
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			cfg: &pkgv1.Function{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: pkgv1.FunctionSpec{
+					PackageSpec: pkgv1.PackageSpec{
+						Package:                     "coolthing:v1",
+						RevisionActivationPolicy:    &rap,
+						RevisionHistoryLimit:        &lim,
+						PackagePullPolicy:           &ppp,
+						IgnoreCrossplaneConstraints: ptr.To(true),
+						SkipDependencyResolution:    ptr.To(true),
+					},
+					PackageRuntimeSpec: pkgv1.PackageRuntimeSpec{
+						RuntimeConfigReference: &pkgv1.RuntimeConfigReference{
+							APIVersion: ptr.To("pkg.crossplane.io/v1beta1"),
+							Kind:       ptr.To("DeploymentRuntimeConfig"),
+							Name:       "coolruntime",
+						},
+						ControllerConfigReference: &pkgv1.ControllerConfigReference{Name: "coolconfig"},
+					},
+				},
+				Status: pkgv1.FunctionStatus{
+					ConditionedStatus: xpv1.ConditionedStatus{
+						Conditions: []xpv1.Condition{{}},
+					},
+					PackageStatus: pkgv1.PackageStatus{
+						CurrentRevision:   "8",
+						CurrentIdentifier: "coolthing:v1",
+					},
+				},
+			},
+			want: Function{
+				ID: ReferenceID{
+					APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+				Kind:       pkgv1.FunctionKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: FunctionSpec{
+					Package:                     "coolthing:v1",
+					RevisionActivationPolicy:    &mrap,
+					RevisionHistoryLimit:        &mlim,
+					PackagePullPolicy:           &mppp,
+					IgnoreCrossplaneConstraints: ptr.To(true),
+					SkipDependencyResolution:    ptr.To(true),
+					RuntimeConfigRef: &RuntimeConfigReference{
+						APIVersion: ptr.To("pkg.crossplane.io/v1beta1"),
+						Kind:       ptr.To("DeploymentRuntimeConfig"),
+						Name:       "coolruntime",
+					},
+					ControllerConfigRef: &LocalObjectReference{Name: "coolconfig"},
+				},
+				Status: &FunctionStatus{
+					Conditions:        []Condition{{}},
+					CurrentRevision:   ptr.To("8"),
+					CurrentIdentifier: ptr.To("coolthing:v1"),
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			cfg:    &pkgv1.Function{},
+			want: Function{
+				Metadata: ObjectMeta{},
+				Spec:     FunctionSpec{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetFunction(tc.cfg)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Function{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetFunction(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetFunctionRevision(t *testing.T) {
+	ppp := corev1.PullAlways
+	mppp := PackagePullPolicyAlways
+	found := 42
+	installed := 32
+	invalid := 10
+
+	cases := map[string]struct {
+		reason string
+		cfg    *pkgv1.FunctionRevision
+		want   FunctionRevision
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			cfg: &pkgv1.FunctionRevision{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1.FunctionRevisionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionRevisionKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: pkgv1.FunctionRevisionSpec{
+					PackageRevisionSpec: pkgv1.PackageRevisionSpec{
+						DesiredState:                pkgv1.PackageRevisionActive,
+						Package:                     "coolthing:v1",
+						PackagePullPolicy:           &ppp,
+						Revision:                    42,
+						IgnoreCrossplaneConstraints: ptr.To(true),
+						SkipDependencyResolution:    ptr.To(true),
+					},
+				},
+				Status: pkgv1.FunctionRevisionStatus{
+					PackageRevisionStatus: pkgv1.PackageRevisionStatus{
+						ConditionedStatus: xpv1.ConditionedStatus{
+							Conditions: []xpv1.Condition{{}},
+						},
+						ObjectRefs:            []xpv1.TypedReference{{Name: "coolcrd"}},
+						FoundDependencies:     int64(found),
+						InstalledDependencies: int64(installed),
+						InvalidDependencies:   int64(invalid),
+						PermissionRequests: []rbacv1.PolicyRule{{
+							Verbs:           []string{"verb"},
+							APIGroups:       []string{"group"},
+							Resources:       []string{"resources"},
+							ResourceNames:   []string{"resourceNames"},
+							NonResourceURLs: []string{"nonResourceURLs"},
+						}},
+					},
+					Endpoint: "dns:///coolfunction.crossplane-system:9443",
+				},
+			},
+			want: FunctionRevision{
+				ID: ReferenceID{
+					APIVersion: pkgv1.FunctionRevisionGroupVersionKind.GroupVersion().String(),
+					Kind:       pkgv1.FunctionRevisionKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1.FunctionRevisionGroupVersionKind.GroupVersion().String(),
+				Kind:       pkgv1.FunctionRevisionKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: FunctionRevisionSpec{
+					DesiredState:                PackageRevisionDesiredStateActive,
+					Package:                     "coolthing:v1",
+					PackagePullPolicy:           &mppp,
+					Revision:                    42,
+					IgnoreCrossplaneConstraints: ptr.To(true),
+					SkipDependencyResolution:    ptr.To(true),
+				},
+				Status: &FunctionRevisionStatus{
+					Conditions:            []Condition{{}},
+					ObjectRefs:            []xpv1.TypedReference{{Name: "coolcrd"}},
+					FoundDependencies:     &found,
+					InstalledDependencies: &installed,
+					InvalidDependencies:   &invalid,
+					PermissionRequests: []PolicyRule{{
+						Verbs:           []string{"verb"},
+						APIGroups:       []string{"group"},
+						Resources:       []string{"resources"},
+						ResourceNames:   []string{"resourceNames"},
+						NonResourceURLs: []string{"nonResourceURLs"},
+					}},
+					Endpoint: ptr.To("dns:///coolfunction.crossplane-system:9443"),
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			cfg:    &pkgv1.FunctionRevision{},
+			want: FunctionRevision{
+				Metadata: ObjectMeta{},
+				Spec:     FunctionRevisionSpec{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetFunctionRevision(tc.cfg)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(FunctionRevision{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetFunctionRevision(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestGetConfiguration(t *testing.T) {
 	rap := pkgv1.ManualActivation
 	lim := int64(42)
@@ -413,3 +642,228 @@ func TestGetConfigurationRevision(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDeploymentRuntimeConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		rc     *pkgv1beta1.DeploymentRuntimeConfig
+		want   DeploymentRuntimeConfig
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			rc: &pkgv1beta1.DeploymentRuntimeConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+					Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: pkgv1beta1.DeploymentRuntimeConfigSpec{
+					DeploymentTemplate: &pkgv1beta1.DeploymentTemplate{
+						Metadata: &pkgv1beta1.ObjectMeta{Name: ptr.To("cooldeployment")},
+					},
+					ServiceTemplate: &pkgv1beta1.ServiceTemplate{
+						Metadata: &pkgv1beta1.ObjectMeta{Name: ptr.To("coolservice")},
+					},
+					ServiceAccountTemplate: &pkgv1beta1.ServiceAccountTemplate{
+						Metadata: &pkgv1beta1.ObjectMeta{Name: ptr.To("coolsa")},
+					},
+				},
+			},
+			want: DeploymentRuntimeConfig{
+				ID: ReferenceID{
+					APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+					Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+				Kind:       pkgv1beta1.DeploymentRuntimeConfigKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: DeploymentRuntimeConfigSpec{
+					DeploymentTemplate:     []byte(`{"metadata":{"name":"cooldeployment"}}`),
+					ServiceTemplate:        []byte(`{"metadata":{"name":"coolservice"}}`),
+					ServiceAccountTemplate: []byte(`{"metadata":{"name":"coolsa"}}`),
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			rc:     &pkgv1beta1.DeploymentRuntimeConfig{},
+			want: DeploymentRuntimeConfig{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetDeploymentRuntimeConfig(tc.rc)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(DeploymentRuntimeConfig{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetDeploymentRuntimeConfig(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetControllerConfig(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cc     *pkgv1alpha1.ControllerConfig
+		want   ControllerConfig
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			cc: &pkgv1alpha1.ControllerConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1alpha1.SchemeGroupVersion.String(),
+					Kind:       pkgv1alpha1.ControllerConfigKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cool",
+				},
+				Spec: pkgv1alpha1.ControllerConfigSpec{
+					Replicas:           ptr.To[int32](2),
+					Image:              ptr.To("coolimage:v1"),
+					ImagePullPolicy:    ptr.To(corev1.PullAlways),
+					ServiceAccountName: ptr.To("coolsa"),
+					NodeSelector:       map[string]string{"cool": "true"},
+					PriorityClassName:  ptr.To("coolpriority"),
+					ResourceRequirements: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+					},
+					PodSecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: ptr.To(true)},
+					SecurityContext:    &corev1.SecurityContext{Privileged: ptr.To(false)},
+					Args:               []string{"--debug"},
+				},
+			},
+			want: ControllerConfig{
+				ID: ReferenceID{
+					APIVersion: pkgv1alpha1.SchemeGroupVersion.String(),
+					Kind:       pkgv1alpha1.ControllerConfigKind,
+					Name:       "cool",
+				},
+				APIVersion: pkgv1alpha1.SchemeGroupVersion.String(),
+				Kind:       pkgv1alpha1.ControllerConfigKind,
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				Spec: ControllerConfigSpec{
+					Replicas:           ptr.To(2),
+					Image:              ptr.To("coolimage:v1"),
+					ImagePullPolicy:    ptr.To("Always"),
+					ServiceAccountName: ptr.To("coolsa"),
+					NodeSelector:       map[string]string{"cool": "true"},
+					PriorityClassName:  ptr.To("coolpriority"),
+					Resources:          []byte(`{"limits":{"cpu":"1"}}`),
+					PodSecurityContext: []byte(`{"runAsNonRoot":true}`),
+					SecurityContext:    []byte(`{"privileged":false}`),
+					Args:               []string{"--debug"},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			cc:     &pkgv1alpha1.ControllerConfig{},
+			want: ControllerConfig{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetControllerConfig(tc.cc)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(ControllerConfig{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetControllerConfig(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGetPackageLock(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		l      *pkgv1beta1.Lock
+		want   PackageLock
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model, and dependencies resolved to the versions of locked packages",
+			l: &pkgv1beta1.Lock{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+					Kind:       pkgv1beta1.LockKind,
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "lock",
+				},
+				Packages: []pkgv1beta1.LockPackage{
+					{
+						Name:    "cool-config-abcdef",
+						Type:    pkgv1beta1.ConfigurationPackageType,
+						Source:  "example.org/cool-config",
+						Version: "v1.0.0",
+						Dependencies: []pkgv1beta1.Dependency{
+							{Package: "example.org/cool-provider", Type: pkgv1beta1.ProviderPackageType, Constraints: ">=v0.1.0"},
+							{Package: "example.org/missing-function", Type: pkgv1beta1.FunctionPackageType, Constraints: "v0.2.0"},
+						},
+					},
+					{
+						Name:    "cool-provider-123456",
+						Type:    pkgv1beta1.ProviderPackageType,
+						Source:  "example.org/cool-provider",
+						Version: "v0.3.0",
+					},
+				},
+			},
+			want: PackageLock{
+				ID: ReferenceID{
+					APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+					Kind:       pkgv1beta1.LockKind,
+					Name:       "lock",
+				},
+				APIVersion: pkgv1beta1.SchemeGroupVersion.String(),
+				Kind:       pkgv1beta1.LockKind,
+				Metadata: ObjectMeta{
+					Name: "lock",
+				},
+				Packages: []LockPackage{
+					{
+						Name:    "cool-config-abcdef",
+						Type:    ptr.To(PackageTypeConfiguration),
+						Source:  "example.org/cool-config",
+						Version: "v1.0.0",
+						Dependencies: []LockDependency{
+							{Package: "example.org/cool-provider", Type: ptr.To(PackageTypeProvider), Constraints: ">=v0.1.0", Version: ptr.To("v0.3.0")},
+							{Package: "example.org/missing-function", Type: ptr.To(PackageTypeFunction), Constraints: "v0.2.0"},
+						},
+					},
+					{
+						Name:    "cool-provider-123456",
+						Type:    ptr.To(PackageTypeProvider),
+						Source:  "example.org/cool-provider",
+						Version: "v0.3.0",
+					},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			l:      &pkgv1beta1.Lock{},
+			want: PackageLock{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetPackageLock(tc.l)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(PackageLock{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetPackageLock(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
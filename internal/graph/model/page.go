@@ -0,0 +1,340 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+)
+
+// Pagination errors.
+const (
+	errNegativeFirst = "first must not be negative"
+	errNegativeLast  = "last must not be negative"
+	errParseCursor   = "cannot parse cursor"
+	errFmtNoCursor   = "cannot find cursor %q in connection"
+)
+
+// PageArgs select a page of a connection per the Relay cursor connections
+// specification. The zero value selects every node in the connection.
+// https://relay.dev/graphql/connections.htm#sec-Arguments
+type PageArgs struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// A cursor identifies an edge's position within a connection. We use the
+// ReferenceID of the edge's node, which is opaque to clients and stable for as
+// long as the node exists. Because connections are sorted before they're
+// paginated the position of a node, and therefore its cursor, is deterministic.
+func cursor(id ReferenceID) string {
+	return id.String()
+}
+
+// index returns the index of the node identified by the supplied cursor.
+func index(n int, id func(i int) ReferenceID, c string) (int, error) {
+	want, err := ParseReferenceID(c)
+	if err != nil {
+		return 0, errors.Wrap(err, errParseCursor)
+	}
+	for i := 0; i < n; i++ {
+		if id(i) == want {
+			return i, nil
+		}
+	}
+	return 0, errors.Errorf(errFmtNoCursor, c)
+}
+
+// bounds returns the start (inclusive) and end (exclusive) indices of the page
+// selected from an ordered list of n nodes, where id returns the ID of the node
+// at index i.
+func (a PageArgs) bounds(n int, id func(i int) ReferenceID) (int, int, error) {
+	start, end := 0, n
+
+	if a.After != nil {
+		i, err := index(n, id, *a.After)
+		if err != nil {
+			return 0, 0, err
+		}
+		start = i + 1
+	}
+
+	if a.Before != nil {
+		i, err := index(n, id, *a.Before)
+		if err != nil {
+			return 0, 0, err
+		}
+		end = i
+	}
+
+	// The before cursor precedes the after cursor; the page is empty.
+	if end < start {
+		end = start
+	}
+
+	if a.First != nil {
+		if *a.First < 0 {
+			return 0, 0, errors.New(errNegativeFirst)
+		}
+		if end-start > *a.First {
+			end = start + *a.First
+		}
+	}
+
+	if a.Last != nil {
+		if *a.Last < 0 {
+			return 0, 0, errors.New(errNegativeLast)
+		}
+		if end-start > *a.Last {
+			start = end - *a.Last
+		}
+	}
+
+	return start, end, nil
+}
+
+// page returns the nodes and edges of the page selected by the supplied
+// arguments, along with information about the page.
+func page[N, E any](nodes []N, a PageArgs, id func(N) ReferenceID, edge func(cursor string, node N) E) ([]N, []E, PageInfo, error) {
+	n := len(nodes)
+	start, end, err := a.bounds(n, func(i int) ReferenceID { return id(nodes[i]) })
+	if err != nil {
+		return nil, nil, PageInfo{}, err
+	}
+
+	nodes = nodes[start:end]
+	edges := make([]E, len(nodes))
+	for i := range nodes {
+		edges[i] = edge(cursor(id(nodes[i])), nodes[i])
+	}
+
+	info := PageInfo{
+		HasPreviousPage: start > 0,
+		HasNextPage:     end < n,
+	}
+	if len(nodes) > 0 {
+		first, last := cursor(id(nodes[0])), cursor(id(nodes[len(nodes)-1]))
+		info.StartCursor = &first
+		info.EndCursor = &last
+	}
+
+	return nodes, edges, info, nil
+}
+
+func nodeID[N identifiable](n N) ReferenceID { return n.id() }
+
+func resourceID(r KubernetesResource) ReferenceID { return r.(identifiable).id() }
+
+// GetResourceID returns the ID of the supplied resource.
+func GetResourceID(r KubernetesResource) ReferenceID { return resourceID(r) }
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *KubernetesResourceConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, resourceID, func(c string, n KubernetesResource) KubernetesResourceEdge {
+		return KubernetesResourceEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *EventConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, func(n Event) ReferenceID { return n.ID }, func(c string, n Event) EventEdge {
+		return EventEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *OwnerConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, func(n Owner) ReferenceID { return resourceID(n.Resource) }, func(c string, n Owner) OwnerEdge {
+		return OwnerEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CrossplaneResourceTreeConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, func(n CrossplaneResourceTreeNode) ReferenceID { return resourceID(n.Resource) }, func(c string, n CrossplaneResourceTreeNode) CrossplaneResourceTreeEdge {
+		return CrossplaneResourceTreeEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ProviderConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[Provider], func(c string, n Provider) ProviderEdge {
+		return ProviderEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ProviderRevisionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[ProviderRevision], func(c string, n ProviderRevision) ProviderRevisionEdge {
+		return ProviderRevisionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *FunctionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[Function], func(c string, n Function) FunctionEdge {
+		return FunctionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *FunctionRevisionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[FunctionRevision], func(c string, n FunctionRevision) FunctionRevisionEdge {
+		return FunctionRevisionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CustomResourceDefinitionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[CustomResourceDefinition], func(c string, n CustomResourceDefinition) CustomResourceDefinitionEdge {
+		return CustomResourceDefinitionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ConfigurationConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[Configuration], func(c string, n Configuration) ConfigurationEdge {
+		return ConfigurationEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ConfigurationRevisionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[ConfigurationRevision], func(c string, n ConfigurationRevision) ConfigurationRevisionEdge {
+		return ConfigurationRevisionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CompositeResourceDefinitionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[CompositeResourceDefinition], func(c string, n CompositeResourceDefinition) CompositeResourceDefinitionEdge {
+		return CompositeResourceDefinitionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CompositionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[Composition], func(c string, n Composition) CompositionEdge {
+		return CompositionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CompositionRevisionConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[CompositionRevision], func(c string, n CompositionRevision) CompositionRevisionEdge {
+		return CompositionRevisionEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *EnvironmentConfigConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[EnvironmentConfig], func(c string, n EnvironmentConfig) EnvironmentConfigEdge {
+		return EnvironmentConfigEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *UsageConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[Usage], func(c string, n Usage) UsageEdge {
+		return UsageEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CompositeResourceConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[CompositeResource], func(c string, n CompositeResource) CompositeResourceEdge {
+		return CompositeResourceEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *CompositeResourceClaimConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[CompositeResourceClaim], func(c string, n CompositeResourceClaim) CompositeResourceClaimEdge {
+		return CompositeResourceClaimEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ManagedResourceConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[ManagedResource], func(c string, n ManagedResource) ManagedResourceEdge {
+		return ManagedResourceEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ProviderConfigConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[ProviderConfig], func(c string, n ProviderConfig) ProviderConfigEdge {
+		return ProviderConfigEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *ProviderConfigUsageConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, nodeID[ProviderConfigUsage], func(c string, n ProviderConfigUsage) ProviderConfigUsageEdge {
+		return ProviderConfigUsageEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
+
+// Paginate the connection, replacing its nodes with the requested page.
+func (c *TraceConnection) Paginate(a PageArgs) error {
+	n, e, i, err := page(c.Nodes, a, func(n TraceNode) ReferenceID { return resourceID(n.Resource) }, func(c string, n TraceNode) TraceEdge {
+		return TraceEdge{Cursor: c, Node: n}
+	})
+	c.Nodes, c.Edges, c.PageInfo = n, e, i
+	return err
+}
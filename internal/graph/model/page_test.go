@@ -0,0 +1,204 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+type paginator interface{ Paginate(a PageArgs) error }
+
+var (
+	_ paginator = &KubernetesResourceConnection{}
+	_ paginator = &EventConnection{}
+	_ paginator = &OwnerConnection{}
+	_ paginator = &CrossplaneResourceTreeConnection{}
+	_ paginator = &ProviderConnection{}
+	_ paginator = &ProviderRevisionConnection{}
+	_ paginator = &FunctionConnection{}
+	_ paginator = &FunctionRevisionConnection{}
+	_ paginator = &CustomResourceDefinitionConnection{}
+	_ paginator = &ConfigurationConnection{}
+	_ paginator = &ConfigurationRevisionConnection{}
+	_ paginator = &CompositeResourceDefinitionConnection{}
+	_ paginator = &CompositionConnection{}
+	_ paginator = &CompositionRevisionConnection{}
+	_ paginator = &EnvironmentConfigConnection{}
+	_ paginator = &UsageConnection{}
+	_ paginator = &CompositeResourceConnection{}
+	_ paginator = &CompositeResourceClaimConnection{}
+	_ paginator = &ManagedResourceConnection{}
+	_ paginator = &ProviderConfigConnection{}
+	_ paginator = &ProviderConfigUsageConnection{}
+	_ paginator = &TraceConnection{}
+)
+
+func TestPaginate(t *testing.T) {
+	a := Provider{ID: ReferenceID{Name: "a"}}
+	b := Provider{ID: ReferenceID{Name: "b"}}
+	c := Provider{ID: ReferenceID{Name: "c"}}
+	d := Provider{ID: ReferenceID{Name: "d"}}
+
+	ea := ProviderEdge{Cursor: a.ID.String(), Node: a}
+	eb := ProviderEdge{Cursor: b.ID.String(), Node: b}
+	ec := ProviderEdge{Cursor: c.ID.String(), Node: c}
+	ed := ProviderEdge{Cursor: d.ID.String(), Node: d}
+
+	z := ReferenceID{Name: "z"}
+
+	_, parseErr := ParseReferenceID("=")
+
+	type want struct {
+		conn ProviderConnection
+		err  error
+	}
+	cases := map[string]struct {
+		reason string
+		args   PageArgs
+		want   want
+	}{
+		"NoArgs": {
+			reason: "Omitting all arguments should return every node.",
+			args:   PageArgs{},
+			want: want{
+				conn: ProviderConnection{
+					Nodes:      []Provider{a, b, c, d},
+					Edges:      []ProviderEdge{ea, eb, ec, ed},
+					TotalCount: 4,
+					PageInfo: PageInfo{
+						StartCursor: ptr.To(ea.Cursor),
+						EndCursor:   ptr.To(ed.Cursor),
+					},
+				},
+			},
+		},
+		"First": {
+			reason: "Supplying first should return the first n nodes.",
+			args:   PageArgs{First: ptr.To(2)},
+			want: want{
+				conn: ProviderConnection{
+					Nodes:      []Provider{a, b},
+					Edges:      []ProviderEdge{ea, eb},
+					TotalCount: 4,
+					PageInfo: PageInfo{
+						HasNextPage: true,
+						StartCursor: ptr.To(ea.Cursor),
+						EndCursor:   ptr.To(eb.Cursor),
+					},
+				},
+			},
+		},
+		"FirstAfter": {
+			reason: "Supplying first and after should return the first n nodes after the cursor.",
+			args:   PageArgs{First: ptr.To(2), After: ptr.To(eb.Cursor)},
+			want: want{
+				conn: ProviderConnection{
+					Nodes:      []Provider{c, d},
+					Edges:      []ProviderEdge{ec, ed},
+					TotalCount: 4,
+					PageInfo: PageInfo{
+						HasPreviousPage: true,
+						StartCursor:     ptr.To(ec.Cursor),
+						EndCursor:       ptr.To(ed.Cursor),
+					},
+				},
+			},
+		},
+		"LastBefore": {
+			reason: "Supplying last and before should return the last n nodes before the cursor.",
+			args:   PageArgs{Last: ptr.To(2), Before: ptr.To(ed.Cursor)},
+			want: want{
+				conn: ProviderConnection{
+					Nodes:      []Provider{b, c},
+					Edges:      []ProviderEdge{eb, ec},
+					TotalCount: 4,
+					PageInfo: PageInfo{
+						HasNextPage:     true,
+						HasPreviousPage: true,
+						StartCursor:     ptr.To(eb.Cursor),
+						EndCursor:       ptr.To(ec.Cursor),
+					},
+				},
+			},
+		},
+		"Empty": {
+			reason: "A before cursor that precedes the after cursor should return an empty page.",
+			args:   PageArgs{After: ptr.To(ec.Cursor), Before: ptr.To(eb.Cursor)},
+			want: want{
+				conn: ProviderConnection{
+					Nodes:      []Provider{},
+					Edges:      []ProviderEdge{},
+					TotalCount: 4,
+					PageInfo: PageInfo{
+						HasNextPage:     true,
+						HasPreviousPage: true,
+					},
+				},
+			},
+		},
+		"NegativeFirst": {
+			reason: "A negative first argument should return an error.",
+			args:   PageArgs{First: ptr.To(-1)},
+			want: want{
+				conn: ProviderConnection{TotalCount: 4},
+				err:  errors.New(errNegativeFirst),
+			},
+		},
+		"NegativeLast": {
+			reason: "A negative last argument should return an error.",
+			args:   PageArgs{Last: ptr.To(-1)},
+			want: want{
+				conn: ProviderConnection{TotalCount: 4},
+				err:  errors.New(errNegativeLast),
+			},
+		},
+		"MalformedCursor": {
+			reason: "A cursor that cannot be parsed should return an error.",
+			args:   PageArgs{After: ptr.To("=")},
+			want: want{
+				conn: ProviderConnection{TotalCount: 4},
+				err:  errors.Wrap(parseErr, errParseCursor),
+			},
+		},
+		"UnknownCursor": {
+			reason: "A cursor that does not identify a node in the connection should return an error.",
+			args:   PageArgs{Before: ptr.To(z.String())},
+			want: want{
+				conn: ProviderConnection{TotalCount: 4},
+				err:  errors.Errorf(errFmtNoCursor, z.String()),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			conn := &ProviderConnection{Nodes: []Provider{a, b, c, d}, TotalCount: 4}
+			err := conn.Paginate(tc.args)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nPaginate(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.conn, *conn, cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nPaginate(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
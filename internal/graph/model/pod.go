@@ -0,0 +1,124 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// GetContainerState from the supplied Kubernetes container state. Returns nil
+// if the state is unknown.
+func GetContainerState(in corev1.ContainerState) *ContainerState {
+	switch {
+	case in.Waiting != nil:
+		out := &ContainerState{Status: ContainerStateStatusWaiting}
+		if in.Waiting.Reason != "" {
+			out.Reason = ptr.To(in.Waiting.Reason)
+		}
+		if in.Waiting.Message != "" {
+			out.Message = ptr.To(in.Waiting.Message)
+		}
+		return out
+	case in.Running != nil:
+		out := &ContainerState{Status: ContainerStateStatusRunning}
+		if !in.Running.StartedAt.IsZero() {
+			out.StartedAt = ptr.To(in.Running.StartedAt.Time)
+		}
+		return out
+	case in.Terminated != nil:
+		out := &ContainerState{
+			Status:   ContainerStateStatusTerminated,
+			ExitCode: ptr.To(int(in.Terminated.ExitCode)),
+		}
+		if in.Terminated.Reason != "" {
+			out.Reason = ptr.To(in.Terminated.Reason)
+		}
+		if in.Terminated.Message != "" {
+			out.Message = ptr.To(in.Terminated.Message)
+		}
+		if !in.Terminated.StartedAt.IsZero() {
+			out.StartedAt = ptr.To(in.Terminated.StartedAt.Time)
+		}
+		if !in.Terminated.FinishedAt.IsZero() {
+			out.FinishedAt = ptr.To(in.Terminated.FinishedAt.Time)
+		}
+		return out
+	}
+	return nil
+}
+
+// GetContainerStatuses from the supplied Kubernetes container statuses.
+func GetContainerStatuses(in []corev1.ContainerStatus) []ContainerStatus {
+	if in == nil {
+		return nil
+	}
+
+	out := make([]ContainerStatus, len(in))
+	for i := range in {
+		out[i] = ContainerStatus{
+			Name:                 in[i].Name,
+			Image:                in[i].Image,
+			Ready:                in[i].Ready,
+			Started:              in[i].Started,
+			RestartCount:         int(in[i].RestartCount),
+			State:                GetContainerState(in[i].State),
+			LastTerminationState: GetContainerState(in[i].LastTerminationState),
+		}
+	}
+	return out
+}
+
+// GetPodStatus from the supplied Kubernetes pod status.
+func GetPodStatus(in corev1.PodStatus) *PodStatus {
+	out := &PodStatus{ContainerStatuses: GetContainerStatuses(in.ContainerStatuses)}
+	if in.Phase != "" {
+		out.Phase = ptr.To(string(in.Phase))
+	}
+	if in.Reason != "" {
+		out.Reason = ptr.To(in.Reason)
+	}
+	if in.Message != "" {
+		out.Message = ptr.To(in.Message)
+	}
+	if in.StartTime != nil {
+		out.StartTime = ptr.To(in.StartTime.Time)
+	}
+	if cmp.Equal(out, &PodStatus{}) {
+		return nil
+	}
+	return out
+}
+
+// GetPod from the supplied Kubernetes Pod.
+func GetPod(p *corev1.Pod) Pod {
+	return Pod{
+		ID: ReferenceID{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+			Namespace:  p.GetNamespace(),
+			Name:       p.GetName(),
+		},
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       "Pod",
+		Metadata:   GetObjectMeta(p),
+		Status:     GetPodStatus(p.Status),
+		PavedAccess: PavedAccess{
+			Paved: paveObject(p),
+		},
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetPod(t *testing.T) {
+	started := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	finished := started.Add(1 * time.Minute)
+
+	cases := map[string]struct {
+		reason string
+		p      *corev1.Pod
+		want   Pod
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			p: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "crossplane-system",
+					Name:      "cool",
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodRunning,
+					Reason:    "CoolReason",
+					Message:   "cool message",
+					StartTime: &metav1.Time{Time: started},
+					ContainerStatuses: []corev1.ContainerStatus{
+						{
+							Name:         "package-runtime",
+							Image:        "example.org/cool-provider:v0.1.0",
+							Ready:        true,
+							Started:      ptr.To(true),
+							RestartCount: 2,
+							State: corev1.ContainerState{
+								Running: &corev1.ContainerStateRunning{StartedAt: metav1.Time{Time: finished}},
+							},
+							LastTerminationState: corev1.ContainerState{
+								Terminated: &corev1.ContainerStateTerminated{
+									ExitCode:   1,
+									Reason:     "Error",
+									Message:    "boom",
+									StartedAt:  metav1.Time{Time: started},
+									FinishedAt: metav1.Time{Time: finished},
+								},
+							},
+						},
+						{
+							Name:  "sidecar",
+							Image: "example.org/sidecar:v0.1.0",
+							State: corev1.ContainerState{
+								Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off"},
+							},
+						},
+					},
+				},
+			},
+			want: Pod{
+				ID: ReferenceID{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Pod",
+					Namespace:  "crossplane-system",
+					Name:       "cool",
+				},
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Pod",
+				Metadata: ObjectMeta{
+					Namespace: ptr.To("crossplane-system"),
+					Name:      "cool",
+				},
+				Status: &PodStatus{
+					Phase:     ptr.To("Running"),
+					Reason:    ptr.To("CoolReason"),
+					Message:   ptr.To("cool message"),
+					StartTime: &started,
+					ContainerStatuses: []ContainerStatus{
+						{
+							Name:         "package-runtime",
+							Image:        "example.org/cool-provider:v0.1.0",
+							Ready:        true,
+							Started:      ptr.To(true),
+							RestartCount: 2,
+							State: &ContainerState{
+								Status:    ContainerStateStatusRunning,
+								StartedAt: &finished,
+							},
+							LastTerminationState: &ContainerState{
+								Status:     ContainerStateStatusTerminated,
+								Reason:     ptr.To("Error"),
+								Message:    ptr.To("boom"),
+								ExitCode:   ptr.To(1),
+								StartedAt:  &started,
+								FinishedAt: &finished,
+							},
+						},
+						{
+							Name:  "sidecar",
+							Image: "example.org/sidecar:v0.1.0",
+							State: &ContainerState{
+								Status:  ContainerStateStatusWaiting,
+								Reason:  ptr.To("CrashLoopBackOff"),
+								Message: ptr.To("back-off"),
+							},
+						},
+					},
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			p:      &corev1.Pod{},
+			want: Pod{
+				ID: ReferenceID{
+					APIVersion: corev1.SchemeGroupVersion.String(),
+					Kind:       "Pod",
+				},
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "Pod",
+				Metadata:   ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetPod(tc.p)
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(Pod{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetPod(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
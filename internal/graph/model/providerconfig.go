@@ -57,3 +57,34 @@ func GetProviderConfig(u *kunstructured.Unstructured) ProviderConfig {
 		},
 	}
 }
+
+// GetProviderConfigUsage from the supplied Crossplane ProviderConfigUsage.
+func GetProviderConfigUsage(u *kunstructured.Unstructured) ProviderConfigUsage {
+	pcu := &unstructured.ProviderConfigUsage{Unstructured: *u}
+	ref := pcu.GetResourceReference()
+	out := ProviderConfigUsage{
+		ID: ReferenceID{
+			APIVersion: pcu.GetAPIVersion(),
+			Kind:       pcu.GetKind(),
+			Name:       pcu.GetName(),
+		},
+
+		APIVersion:        pcu.GetAPIVersion(),
+		Kind:              pcu.GetKind(),
+		Metadata:          GetObjectMeta(pcu),
+		ProviderConfigRef: ProviderConfigReference{Name: pcu.GetProviderConfigReference().Name},
+		ResourceRef: TypedReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+		},
+		PavedAccess: PavedAccess{
+			Paved: fieldpath.Pave(u.Object),
+		},
+	}
+	if ref.UID != "" {
+		uid := string(ref.UID)
+		out.ResourceRef.UID = &uid
+	}
+	return out
+}
@@ -81,3 +81,67 @@ func TestGetProviderConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProviderConfigUsage(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		u      *kunstructured.Unstructured
+		want   ProviderConfigUsage
+	}{
+		"Full": {
+			reason: "All supported fields should be converted to our model",
+			u: func() *kunstructured.Unstructured {
+				pcu := &unstructured.ProviderConfigUsage{Unstructured: kunstructured.Unstructured{}}
+
+				pcu.SetAPIVersion("example.org/v1")
+				pcu.SetKind("ProviderConfigUsage")
+				pcu.SetName("cool")
+				pcu.SetProviderConfigReference(xpv1.Reference{Name: "default"})
+				pcu.SetResourceReference(xpv1.TypedReference{
+					APIVersion: "example.org/v1",
+					Kind:       "Bucket",
+					Name:       "bucket",
+					UID:        "no-you-id",
+				})
+
+				return pcu.GetUnstructured()
+			}(),
+			want: ProviderConfigUsage{
+				ID: ReferenceID{
+					APIVersion: "example.org/v1",
+					Kind:       "ProviderConfigUsage",
+					Name:       "cool",
+				},
+				APIVersion: "example.org/v1",
+				Kind:       "ProviderConfigUsage",
+				Metadata: ObjectMeta{
+					Name: "cool",
+				},
+				ProviderConfigRef: ProviderConfigReference{Name: "default"},
+				ResourceRef: TypedReference{
+					APIVersion: "example.org/v1",
+					Kind:       "Bucket",
+					Name:       "bucket",
+					UID:        func() *string { s := "no-you-id"; return &s }(),
+				},
+			},
+		},
+		"Empty": {
+			reason: "Absent optional fields should be absent in our model",
+			u:      &kunstructured.Unstructured{Object: make(map[string]interface{})},
+			want: ProviderConfigUsage{
+				Metadata: ObjectMeta{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := GetProviderConfigUsage(tc.u)
+
+			if diff := cmp.Diff(tc.want, got, cmpopts.IgnoreFields(ProviderConfigUsage{}, "PavedAccess"), cmp.AllowUnexported(ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\nGetProviderConfigUsage(...): -want, +got\n:%s", tc.reason, diff)
+			}
+		})
+	}
+}
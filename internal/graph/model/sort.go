@@ -24,17 +24,27 @@ type identifiable interface{ id() ReferenceID }
 // their ID available for sorting.
 func (r ManagedResource) id() ReferenceID             { return r.ID }
 func (r ProviderConfig) id() ReferenceID              { return r.ID }
+func (r ProviderConfigUsage) id() ReferenceID         { return r.ID }
 func (r CompositeResource) id() ReferenceID           { return r.ID }
 func (r CompositeResourceClaim) id() ReferenceID      { return r.ID }
 func (r Provider) id() ReferenceID                    { return r.ID }
 func (r ProviderRevision) id() ReferenceID            { return r.ID }
+func (r Function) id() ReferenceID                    { return r.ID }
+func (r FunctionRevision) id() ReferenceID            { return r.ID }
 func (r Configuration) id() ReferenceID               { return r.ID }
 func (r ConfigurationRevision) id() ReferenceID       { return r.ID }
 func (r CompositeResourceDefinition) id() ReferenceID { return r.ID }
 func (r Composition) id() ReferenceID                 { return r.ID }
+func (r CompositionRevision) id() ReferenceID         { return r.ID }
+func (r EnvironmentConfig) id() ReferenceID           { return r.ID }
+func (r Usage) id() ReferenceID                       { return r.ID }
+func (r DeploymentRuntimeConfig) id() ReferenceID     { return r.ID }
+func (r ControllerConfig) id() ReferenceID            { return r.ID }
+func (r PackageLock) id() ReferenceID                 { return r.ID }
 func (r CustomResourceDefinition) id() ReferenceID    { return r.ID }
 func (r Secret) id() ReferenceID                      { return r.ID }
 func (r ConfigMap) id() ReferenceID                   { return r.ID }
+func (r Pod) id() ReferenceID                         { return r.ID }
 func (r GenericResource) id() ReferenceID             { return r.ID }
 
 func (c *KubernetesResourceConnection) Len() int { return c.TotalCount }
@@ -69,6 +79,22 @@ func (c *ProviderRevisionConnection) Swap(i, j int) {
 	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
 }
 
+func (c *FunctionConnection) Len() int { return c.TotalCount }
+func (c *FunctionConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *FunctionConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *FunctionRevisionConnection) Len() int { return c.TotalCount }
+func (c *FunctionRevisionConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *FunctionRevisionConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
 func (c *CustomResourceDefinitionConnection) Len() int { return c.TotalCount }
 func (c *CustomResourceDefinitionConnection) Less(i, j int) bool {
 	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
@@ -109,6 +135,30 @@ func (c *CompositionConnection) Swap(i, j int) {
 	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
 }
 
+func (c *CompositionRevisionConnection) Len() int { return c.TotalCount }
+func (c *CompositionRevisionConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *CompositionRevisionConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *EnvironmentConfigConnection) Len() int { return c.TotalCount }
+func (c *EnvironmentConfigConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *EnvironmentConfigConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *UsageConnection) Len() int { return c.TotalCount }
+func (c *UsageConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *UsageConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
 func (c *CompositeResourceConnection) Len() int { return c.TotalCount }
 func (c *CompositeResourceConnection) Less(i, j int) bool {
 	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
@@ -124,3 +174,35 @@ func (c *CompositeResourceClaimConnection) Less(i, j int) bool {
 func (c *CompositeResourceClaimConnection) Swap(i, j int) {
 	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
 }
+
+func (c *ManagedResourceConnection) Len() int { return c.TotalCount }
+func (c *ManagedResourceConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *ManagedResourceConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *ProviderConfigConnection) Len() int { return c.TotalCount }
+func (c *ProviderConfigConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *ProviderConfigConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *ProviderConfigUsageConnection) Len() int { return c.TotalCount }
+func (c *ProviderConfigUsageConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].ID) < join(c.Nodes[j].ID)
+}
+func (c *ProviderConfigUsageConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
+
+func (c *OwnerConnection) Len() int { return c.TotalCount }
+func (c *OwnerConnection) Less(i, j int) bool {
+	return join(c.Nodes[i].Resource.(identifiable).id()) < join(c.Nodes[j].Resource.(identifiable).id())
+}
+func (c *OwnerConnection) Swap(i, j int) {
+	c.Nodes[i], c.Nodes[j] = c.Nodes[j], c.Nodes[i]
+}
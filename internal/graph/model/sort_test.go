@@ -25,17 +25,27 @@ import (
 var (
 	_ identifiable = ManagedResource{}
 	_ identifiable = ProviderConfig{}
+	_ identifiable = ProviderConfigUsage{}
 	_ identifiable = CompositeResource{}
 	_ identifiable = CompositeResourceClaim{}
 	_ identifiable = Provider{}
 	_ identifiable = ProviderRevision{}
+	_ identifiable = Function{}
+	_ identifiable = FunctionRevision{}
 	_ identifiable = Configuration{}
 	_ identifiable = ConfigurationRevision{}
 	_ identifiable = CompositeResourceDefinition{}
 	_ identifiable = Composition{}
+	_ identifiable = CompositionRevision{}
+	_ identifiable = EnvironmentConfig{}
+	_ identifiable = Usage{}
+	_ identifiable = DeploymentRuntimeConfig{}
+	_ identifiable = ControllerConfig{}
+	_ identifiable = PackageLock{}
 	_ identifiable = CustomResourceDefinition{}
 	_ identifiable = Secret{}
 	_ identifiable = ConfigMap{}
+	_ identifiable = Pod{}
 	_ identifiable = GenericResource{}
 )
 
@@ -44,13 +54,22 @@ var (
 	_ sort.Interface = &EventConnection{}
 	_ sort.Interface = &ProviderConnection{}
 	_ sort.Interface = &ProviderRevisionConnection{}
+	_ sort.Interface = &FunctionConnection{}
+	_ sort.Interface = &FunctionRevisionConnection{}
 	_ sort.Interface = &CustomResourceDefinitionConnection{}
 	_ sort.Interface = &ConfigurationConnection{}
 	_ sort.Interface = &ConfigurationRevisionConnection{}
 	_ sort.Interface = &CompositionConnection{}
+	_ sort.Interface = &CompositionRevisionConnection{}
+	_ sort.Interface = &EnvironmentConfigConnection{}
+	_ sort.Interface = &UsageConnection{}
 	_ sort.Interface = &CompositeResourceDefinitionConnection{}
 	_ sort.Interface = &CompositeResourceConnection{}
 	_ sort.Interface = &CompositeResourceClaimConnection{}
+	_ sort.Interface = &ManagedResourceConnection{}
+	_ sort.Interface = &ProviderConfigConnection{}
+	_ sort.Interface = &ProviderConfigUsageConnection{}
+	_ sort.Interface = &OwnerConnection{}
 )
 
 func TestSort(t *testing.T) {
@@ -127,6 +146,38 @@ func TestSort(t *testing.T) {
 				},
 			},
 		},
+		"FunctionConnection": {
+			conn: &FunctionConnection{
+				TotalCount: 2,
+				Nodes: []Function{
+					{ID: ReferenceID{Name: "b"}},
+					{ID: ReferenceID{Name: "a"}},
+				},
+			},
+			want: &FunctionConnection{
+				TotalCount: 2,
+				Nodes: []Function{
+					{ID: ReferenceID{Name: "a"}},
+					{ID: ReferenceID{Name: "b"}},
+				},
+			},
+		},
+		"FunctionRevisionConnection": {
+			conn: &FunctionRevisionConnection{
+				TotalCount: 2,
+				Nodes: []FunctionRevision{
+					{ID: ReferenceID{Name: "b"}},
+					{ID: ReferenceID{Name: "a"}},
+				},
+			},
+			want: &FunctionRevisionConnection{
+				TotalCount: 2,
+				Nodes: []FunctionRevision{
+					{ID: ReferenceID{Name: "a"}},
+					{ID: ReferenceID{Name: "b"}},
+				},
+			},
+		},
 		"CustomResourceDefinitionConnection": {
 			conn: &CustomResourceDefinitionConnection{
 				TotalCount: 2,
@@ -191,6 +242,54 @@ func TestSort(t *testing.T) {
 				},
 			},
 		},
+		"CompositionRevisionConnection": {
+			conn: &CompositionRevisionConnection{
+				TotalCount: 2,
+				Nodes: []CompositionRevision{
+					{ID: ReferenceID{Name: "b"}},
+					{ID: ReferenceID{Name: "a"}},
+				},
+			},
+			want: &CompositionRevisionConnection{
+				TotalCount: 2,
+				Nodes: []CompositionRevision{
+					{ID: ReferenceID{Name: "a"}},
+					{ID: ReferenceID{Name: "b"}},
+				},
+			},
+		},
+		"EnvironmentConfigConnection": {
+			conn: &EnvironmentConfigConnection{
+				TotalCount: 2,
+				Nodes: []EnvironmentConfig{
+					{ID: ReferenceID{Name: "b"}},
+					{ID: ReferenceID{Name: "a"}},
+				},
+			},
+			want: &EnvironmentConfigConnection{
+				TotalCount: 2,
+				Nodes: []EnvironmentConfig{
+					{ID: ReferenceID{Name: "a"}},
+					{ID: ReferenceID{Name: "b"}},
+				},
+			},
+		},
+		"UsageConnection": {
+			conn: &UsageConnection{
+				TotalCount: 2,
+				Nodes: []Usage{
+					{ID: ReferenceID{Name: "b"}},
+					{ID: ReferenceID{Name: "a"}},
+				},
+			},
+			want: &UsageConnection{
+				TotalCount: 2,
+				Nodes: []Usage{
+					{ID: ReferenceID{Name: "a"}},
+					{ID: ReferenceID{Name: "b"}},
+				},
+			},
+		},
 		"CompositeResourceDefinitionConnection": {
 			conn: &CompositeResourceDefinitionConnection{
 				TotalCount: 2,
@@ -239,6 +338,22 @@ func TestSort(t *testing.T) {
 				},
 			},
 		},
+		"OwnerConnection": {
+			conn: &OwnerConnection{
+				TotalCount: 2,
+				Nodes: []Owner{
+					{Resource: GenericResource{ID: ReferenceID{Name: "b"}}},
+					{Resource: GenericResource{ID: ReferenceID{Name: "a"}}},
+				},
+			},
+			want: &OwnerConnection{
+				TotalCount: 2,
+				Nodes: []Owner{
+					{Resource: GenericResource{ID: ReferenceID{Name: "a"}}},
+					{Resource: GenericResource{ID: ReferenceID{Name: "b"}}},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -15,6 +15,8 @@
 package model
 
 import (
+	"encoding/json"
+
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -42,3 +44,14 @@ func getIntPtr(i *int64) *int {
 	out := int(*i)
 	return &out
 }
+
+// mustMarshal returns the supplied value as JSON bytes. It panics if the value
+// cannot be marshalled as JSON, which _should_ only happen if this program is
+// fundamentally broken.
+func mustMarshal(v any) []byte {
+	out, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
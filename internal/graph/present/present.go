@@ -16,6 +16,8 @@ package present
 
 import (
 	"context"
+	"regexp"
+	"strconv"
 	"syscall"
 
 	"github.com/99designs/gqlgen/graphql"
@@ -45,6 +47,14 @@ const (
 
 	// Type is the error type, if any.
 	Type = "type"
+
+	// Conflicts lists the fields of an applied object that are managed by
+	// another field manager, if any.
+	Conflicts = "conflicts"
+
+	// Current is the current state of a resource that a mutation conflicted
+	// with, if known.
+	Current = "current"
 )
 
 // An ErrorCode indicates the type of error.
@@ -57,6 +67,10 @@ const (
 	// ErrorRetryable is an error class that indicates to the caller that they
 	// are safe to retry the operation.
 	ErrorRetryable ErrorCode = "RETRYABLE_ERROR"
+	// ErrorConflict is an error class that indicates to the caller that their
+	// mutation conflicted with the current state of a resource, for example
+	// because it was modified since they read it.
+	ErrorConflict ErrorCode = "CONFLICT"
 )
 
 // An ErrorSource indicates where an error originated.
@@ -71,6 +85,58 @@ const (
 	ErrorSourceUnknown   ErrorSource = "Unknown"
 )
 
+// A ConflictError is returned when a mutation conflicts with the current state
+// of a resource. It includes the current state of the resource, so the caller
+// can rebase their mutation on it.
+type ConflictError struct {
+	// Err is the underlying conflict error.
+	Err error
+
+	// Current is the current state of the resource, as unstructured JSON.
+	Current map[string]interface{}
+}
+
+func (e *ConflictError) Error() string { return e.Err.Error() }
+
+func (e *ConflictError) Unwrap() error { return e.Err }
+
+// A FieldConflict is a field of an applied object that is managed by another
+// field manager.
+type FieldConflict struct {
+	// Field is the path of the conflicting field, e.g. .spec.replicas.
+	Field string `json:"field"`
+
+	// Manager is the field manager that manages the conflicting field.
+	Manager string `json:"manager"`
+
+	// Message describes the conflict.
+	Message string `json:"message"`
+}
+
+// The API server describes a field manager conflict as e.g. 'conflict with
+// "kubectl" using apps/v1'.
+var conflictManager = regexp.MustCompile(`^conflict with ("(?:[^"\\]|\\.)*")`)
+
+// getConflicts returns the field manager conflicts described by the supplied
+// status, if any.
+func getConflicts(s metav1.Status) []FieldConflict {
+	if s.Reason != metav1.StatusReasonConflict || s.Details == nil {
+		return nil
+	}
+	var out []FieldConflict
+	for _, c := range s.Details.Causes {
+		if c.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		fc := FieldConflict{Field: c.Field, Message: c.Message}
+		if m := conflictManager.FindStringSubmatch(c.Message); m != nil {
+			fc.Manager, _ = strconv.Unquote(m[1])
+		}
+		out = append(out, fc)
+	}
+	return out
+}
+
 type serverError struct {
 	Code   ErrorCode
 	Reason string
@@ -187,11 +253,24 @@ func Error(ctx context.Context, err error) *gqlerror.Error {
 		if s.Status().Reason == metav1.StatusReasonTimeout {
 			cerr = wrap(cerr, errRBAC)
 		}
-		return Extend(ctx, cerr, map[string]interface{}{
+		ext := map[string]interface{}{
 			Source: ErrorSourceAPIServer,
 			Reason: s.Status().Reason,
 			Code:   s.Status().Code,
-		})
+		}
+		if s.Status().Reason == metav1.StatusReasonConflict {
+			ext[Code] = ErrorConflict
+		}
+		// Server-side apply fails with a conflict if it would change fields
+		// managed by another field manager, unless it's forced.
+		if c := getConflicts(s.Status()); len(c) > 0 {
+			ext[Conflicts] = c
+		}
+		var ce *ConflictError
+		if errors.As(cerr, &ce) && ce.Current != nil {
+			ext[Current] = ce.Current
+		}
+		return Extend(ctx, cerr, ext)
 	default:
 		return Extend(ctx, cerr, map[string]interface{}{Source: ErrorSourceUnknown})
 	}
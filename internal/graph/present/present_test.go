@@ -25,6 +25,8 @@ import (
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestError(t *testing.T) {
@@ -32,6 +34,11 @@ func TestError(t *testing.T) {
 	errNetwork := syscall.ECONNREFUSED
 	errNoKindMatch := &meta.NoKindMatchError{}
 	errBoom := errors.New("boom")
+	errConflict := kerrors.NewApplyConflict([]metav1.StatusCause{
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "kubectl" using apps/v1`, Field: ".spec.replicas"},
+		{Type: metav1.CauseTypeFieldManagerConflict, Message: `conflict with "crossplane" with subresource "status"`, Field: ".status.phase"},
+	}, "Apply failed with 2 conflicts")
+	errOtherConflict := kerrors.NewConflict(schema.GroupResource{}, "cool", errBoom)
 
 	gerrTimeout := gqlerror.WrapPath(nil, errTimeout)
 	gerrNetwork := gqlerror.WrapPath(nil, errNetwork)
@@ -94,6 +101,56 @@ func TestError(t *testing.T) {
 				},
 			},
 		},
+		"ApplyConflictError": {
+			reason: "Server-side apply conflicts should include the conflicting fields and their managers.",
+			args: args{
+				ctx: context.Background(),
+				err: errConflict,
+			},
+			want: &gqlerror.Error{
+				Message: errConflict.Error(),
+				Extensions: map[string]interface{}{
+					Code:   ErrorConflict,
+					Source: ErrorSourceAPIServer,
+					Reason: errConflict.Status().Reason,
+					Conflicts: []FieldConflict{
+						{Field: ".spec.replicas", Manager: "kubectl", Message: `conflict with "kubectl" using apps/v1`},
+						{Field: ".status.phase", Manager: "crossplane", Message: `conflict with "crossplane" with subresource "status"`},
+					},
+				},
+			},
+		},
+		"ResourceConflictError": {
+			reason: "Conflicts with the current state of a resource should include the current state, if known.",
+			args: args{
+				ctx: context.Background(),
+				err: &ConflictError{Err: errOtherConflict, Current: map[string]interface{}{"kind": "Example"}},
+			},
+			want: &gqlerror.Error{
+				Message: errOtherConflict.Error(),
+				Extensions: map[string]interface{}{
+					Code:    ErrorConflict,
+					Source:  ErrorSourceAPIServer,
+					Reason:  errOtherConflict.Status().Reason,
+					Current: map[string]interface{}{"kind": "Example"},
+				},
+			},
+		},
+		"OtherConflictError": {
+			reason: "Conflicts that aren't caused by field managers shouldn't include conflicting fields.",
+			args: args{
+				ctx: context.Background(),
+				err: errOtherConflict,
+			},
+			want: &gqlerror.Error{
+				Message: errOtherConflict.Error(),
+				Extensions: map[string]interface{}{
+					Code:   ErrorConflict,
+					Source: ErrorSourceAPIServer,
+					Reason: errOtherConflict.Status().Reason,
+				},
+			},
+		},
 		"OtherGQLError": {
 			reason: "Regular GQL errors should be returned unchanged.",
 			args: args{
@@ -18,16 +18,21 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -35,21 +40,36 @@ import (
 )
 
 const (
-	errListResources = "cannot list defined resources"
+	errListResources    = "cannot list defined resources"
+	errListCompRevs     = "cannot list composition revisions"
+	errListEnvConfigs   = "cannot list environment configs"
+	errGetEnvConfig     = "cannot get environment config"
+	errGetFunction      = "cannot get function"
+	errFmtListDefinedBy = "cannot list resources defined by composite resource definition %q"
 )
 
 type xrd struct {
 	clients ClientCache
 }
 
-func (r *xrd) Events(ctx context.Context, obj *model.CompositeResourceDefinition) (model.EventConnection, error) {
+func (r *xrd) Events(ctx context.Context, obj *model.CompositeResourceDefinition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *xrd) UsedBy(ctx context.Context, obj *model.CompositeResourceDefinition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *xrd) Uses(ctx context.Context, obj *model.CompositeResourceDefinition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 func (r *xrd) getCrd(ctx context.Context, group string, names *model.CompositeResourceDefinitionNames) (*model.CustomResourceDefinition, error) {
@@ -86,7 +106,7 @@ func (r *xrd) CompositeResourceClaimCrd(ctx context.Context, obj *model.Composit
 	return r.getCrd(ctx, obj.Spec.Group, obj.Spec.ClaimNames)
 }
 
-func (r *xrd) DefinedCompositeResources(ctx context.Context, obj *model.CompositeResourceDefinition, version *string, options *model.DefinedCompositeResourceOptionsInput) (model.CompositeResourceConnection, error) {
+func (r *xrd) DefinedCompositeResources(ctx context.Context, obj *model.CompositeResourceDefinition, version *string, options *model.DefinedCompositeResourceOptionsInput, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositeResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -96,6 +116,26 @@ func (r *xrd) DefinedCompositeResources(ctx context.Context, obj *model.Composit
 
 	options.DeprecationPatch(version)
 
+	lopts := []client.ListOption{}
+	if options.LabelSelector != nil {
+		ls, err := options.LabelSelector.AsSelector()
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errLabelSelector))
+			return model.CompositeResourceConnection{}, nil
+		}
+		lopts = append(lopts, client.MatchingLabelsSelector{Selector: ls})
+	}
+	fs, err := fields.ParseSelector(ptr.Deref(options.FieldSelector, ""))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFieldSelector))
+		return model.CompositeResourceConnection{}, nil
+	}
+	where, err := options.Where.Compile()
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFilter))
+		return model.CompositeResourceConnection{}, nil
+	}
+
 	creds, _ := auth.FromContext(ctx)
 	c, err := r.clients.Get(creds)
 	if err != nil {
@@ -111,19 +151,88 @@ func (r *xrd) DefinedCompositeResources(ctx context.Context, obj *model.Composit
 		gv.Version = pickXRDVersion(obj.Spec.Versions)
 	}
 
+	in, err := listDefined(ctx, c, gv, obj.Spec.Names, fs, where, lopts...)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return model.CompositeResourceConnection{}, nil
+	}
+
+	out := getCompositeResourceConnection(in, options)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CompositeResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositeResourceConnection{}, nil
+	}
+	return out, nil
+}
+
+// listDefined lists the resources of the kind with the supplied names at the
+// supplied group and version, filtered by the supplied field selector and
+// filter.
+func listDefined(ctx context.Context, c client.Client, gv schema.GroupVersion, names model.CompositeResourceDefinitionNames, fs fields.Selector, where *model.ResourceMatcher, lopts ...client.ListOption) (*kunstructured.UnstructuredList, error) {
 	in := &kunstructured.UnstructuredList{}
 	in.SetAPIVersion(gv.String())
-	in.SetKind(obj.Spec.Names.Kind + "List")
-	if lk := obj.Spec.Names.ListKind; lk != nil && *lk != "" {
+	in.SetKind(names.Kind + "List")
+	if lk := names.ListKind; lk != nil && *lk != "" {
 		in.SetKind(*lk)
 	}
 
-	if err := c.List(ctx, in); err != nil {
-		graphql.AddError(ctx, errors.Wrap(err, errListResources))
-		return model.CompositeResourceConnection{}, nil
+	if err := c.List(ctx, in, lopts...); err != nil {
+		return nil, errors.Wrap(err, errListResources)
 	}
+	filterFields(in, fs)
+	filterWhere(in, where)
+	return in, nil
+}
+
+// listAllDefined concurrently lists the resources of the kinds defined by all
+// of the supplied XRDs that are established, using the kind names returned by
+// the supplied function. XRDs for which it returns nil are skipped. An error
+// listing the resources defined by one XRD is added to the GraphQL context,
+// but does not prevent the resources defined by other XRDs being returned.
+func listAllDefined(ctx context.Context, c client.Client, xrds []extv1.CompositeResourceDefinition, names func(m *model.CompositeResourceDefinition) *model.CompositeResourceDefinitionNames, fs fields.Selector, where *model.ResourceMatcher, lopts ...client.ListOption) []kunstructured.Unstructured {
+	out := make([]kunstructured.Unstructured, 0)
+
+	// Collect all concurrently.
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i := range xrds {
+		xrd := &xrds[i]
+
+		// This XRD's kinds aren't served yet, so there's nothing to list.
+		if xrd.Status.GetCondition(extv1.TypeEstablished).Status != corev1.ConditionTrue {
+			continue
+		}
 
-	return getCompositeResourceConnection(in, options), nil
+		m := model.GetCompositeResourceDefinition(xrd)
+		n := names(&m)
+		if n == nil {
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			gv := schema.GroupVersion{Group: m.Spec.Group, Version: pickXRDVersion(m.Spec.Versions)}
+			in, err := listDefined(ctx, c, gv, *n, fs, where, lopts...)
+			if err != nil {
+				graphql.AddError(ctx, errors.Wrapf(err, errFmtListDefinedBy, m.Metadata.Name))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			out = append(out, in.Items...)
+		}()
+	}
+	wg.Wait()
+
+	return out
 }
 
 /*
@@ -148,7 +257,7 @@ func getCompositeResourceConnection(in *kunstructured.UnstructuredList, options
 	return *out
 }
 
-func (r *xrd) DefinedCompositeResourceClaims(ctx context.Context, obj *model.CompositeResourceDefinition, version *string, namespace *string, options *model.DefinedCompositeResourceClaimOptionsInput) (model.CompositeResourceClaimConnection, error) {
+func (r *xrd) DefinedCompositeResourceClaims(ctx context.Context, obj *model.CompositeResourceDefinition, version *string, namespace *string, options *model.DefinedCompositeResourceClaimOptionsInput, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositeResourceClaimConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -167,6 +276,24 @@ func (r *xrd) DefinedCompositeResourceClaims(ctx context.Context, obj *model.Com
 	if options.Namespace != nil {
 		lopts = []client.ListOption{client.InNamespace(*options.Namespace)}
 	}
+	if options.LabelSelector != nil {
+		ls, err := options.LabelSelector.AsSelector()
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errLabelSelector))
+			return model.CompositeResourceClaimConnection{}, nil
+		}
+		lopts = append(lopts, client.MatchingLabelsSelector{Selector: ls})
+	}
+	fs, err := fields.ParseSelector(ptr.Deref(options.FieldSelector, ""))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFieldSelector))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+	where, err := options.Where.Compile()
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFilter))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
 
 	creds, _ := auth.FromContext(ctx)
 	c, err := r.clients.Get(creds)
@@ -183,19 +310,22 @@ func (r *xrd) DefinedCompositeResourceClaims(ctx context.Context, obj *model.Com
 		gv.Version = pickXRDVersion(obj.Spec.Versions)
 	}
 
-	in := &kunstructured.UnstructuredList{}
-	in.SetAPIVersion(gv.String())
-	in.SetKind(obj.Spec.ClaimNames.Kind + "List")
-	if lk := obj.Spec.ClaimNames.ListKind; lk != nil && *lk != "" {
-		in.SetKind(*lk)
+	in, err := listDefined(ctx, c, gv, *obj.Spec.ClaimNames, fs, where, lopts...)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return model.CompositeResourceClaimConnection{}, nil
 	}
 
-	if err := c.List(ctx, in, lopts...); err != nil {
-		graphql.AddError(ctx, errors.Wrap(err, errListResources))
+	out := getCompositeResourceClaimConnection(in, options)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
 		return model.CompositeResourceClaimConnection{}, nil
 	}
-
-	return getCompositeResourceClaimConnection(in, options), nil
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+	return out, nil
 }
 
 /*
@@ -227,17 +357,23 @@ func getCompositeResourceClaimConnection(in *kunstructured.UnstructuredList, opt
  * If false is passed then ready state `True` is excluded
  */
 func readyMatches(ready *bool, m model.ConditionedModel) bool {
-	if ready == nil {
+	return conditionMatches("Ready", ready, m)
+}
+
+// conditionMatches returns true if the supplied model's condition of the
+// supplied type has the wanted status. A model without the condition is
+// treated as if the condition were false.
+func conditionMatches(t string, want *bool, m model.ConditionedModel) bool {
+	if want == nil {
 		return true
 	}
 
 	for _, c := range m.GetConditions() {
-		if c.Type == "Ready" {
-			return (c.Status == model.ConditionStatusTrue) == *ready
+		if c.Type == t {
+			return (c.Status == model.ConditionStatusTrue) == *want
 		}
 	}
-	return !*ready
-
+	return !*want
 }
 
 // TODO(negz): Try to pick the 'highest' version (e.g. v2 > v1 > v1beta1),
@@ -321,12 +457,166 @@ type composition struct {
 	clients ClientCache
 }
 
-func (r *composition) Events(ctx context.Context, obj *model.Composition) (model.EventConnection, error) {
+func (r *composition) Events(ctx context.Context, obj *model.Composition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *composition) UsedBy(ctx context.Context, obj *model.Composition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *composition) Uses(ctx context.Context, obj *model.Composition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *composition) Revisions(ctx context.Context, obj *model.Composition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositionRevisionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.CompositionRevisionConnection{}, nil
+	}
+
+	in := &extv1.CompositionRevisionList{}
+	if err := c.List(ctx, in, client.MatchingLabels{extv1.LabelCompositionName: obj.Metadata.Name}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListCompRevs))
+		return model.CompositionRevisionConnection{}, nil
+	}
+
+	out := &model.CompositionRevisionConnection{
+		Nodes: make([]model.CompositionRevision, 0, len(in.Items)),
+	}
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetCompositionRevision(&in.Items[i]))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CompositionRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositionRevisionConnection{}, nil
+	}
+	return *out, nil
+}
+
+type compositionRevision struct {
+	clients ClientCache
+}
+
+func (r *compositionRevision) Events(ctx context.Context, obj *model.CompositionRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositionRevision) UsedBy(ctx context.Context, obj *model.CompositionRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositionRevision) Uses(ctx context.Context, obj *model.CompositionRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositionRevision) Composition(ctx context.Context, obj *model.CompositionRevision) (*model.Composition, error) {
+	name := obj.Metadata.Labels(nil)[extv1.LabelCompositionName]
+	if name == "" {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	cmp := &extv1.Composition{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, cmp); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetComposition))
+		}
+		return nil, nil
+	}
+
+	out := model.GetComposition(cmp)
+	return &out, nil
+}
+
+type pipelineStep struct {
+	clients ClientCache
+}
+
+func (r *pipelineStep) Function(ctx context.Context, obj *model.PipelineStep) (*model.Function, error) {
+	if obj.FunctionRef.Name == "" {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	fn := &pkgv1.Function{}
+	if err := c.Get(ctx, types.NamespacedName{Name: obj.FunctionRef.Name}, fn); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetFunction))
+		}
+		return nil, nil
+	}
+
+	out := model.GetFunction(fn)
+	return &out, nil
+}
+
+type environmentConfig struct {
+	clients ClientCache
+}
+
+func (r *environmentConfig) Events(ctx context.Context, obj *model.EnvironmentConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *environmentConfig) UsedBy(ctx context.Context, obj *model.EnvironmentConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *environmentConfig) Uses(ctx context.Context, obj *model.EnvironmentConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
@@ -23,6 +23,8 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
@@ -33,6 +35,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -44,6 +47,8 @@ var (
 	_ generated.CompositeResourceDefinitionResolver     = &xrd{}
 	_ generated.CompositeResourceDefinitionSpecResolver = &xrdSpec{}
 	_ generated.CompositionResolver                     = &composition{}
+	_ generated.CompositionRevisionResolver             = &compositionRevision{}
+	_ generated.PipelineStepResolver                    = &pipelineStep{}
 )
 
 func TestCompositeResourceCrd(t *testing.T) {
@@ -727,6 +732,50 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 				},
 			},
 		},
+		"Selectors": {
+			reason: "We should pass the supplied label selector to the list call and only return resources matching the supplied field selector",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if diff := cmp.Diff("team=payments", lo.LabelSelector.String()); diff != "" {
+							t.Errorf("-want label selector, +got label selector:\n%s", diff)
+						}
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xr, xrNotReady, xrReady}}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceDefinition{
+					Spec: model.CompositeResourceDefinitionSpec{
+						Group: group,
+						Names: model.CompositeResourceDefinitionNames{
+							Kind:     kind,
+							ListKind: ptr.To(listKind),
+						},
+						Versions: []model.CompositeResourceDefinitionVersion{
+							{
+								Name:   version,
+								Served: true,
+							},
+						},
+					},
+				},
+				options: &model.DefinedCompositeResourceOptionsInput{
+					LabelSelector: &model.LabelSelectorInput{MatchLabels: map[string]string{"team": "payments"}},
+					FieldSelector: ptr.To("status.conditions[0].status=True"),
+				},
+			},
+			want: want{
+				crc: model.CompositeResourceConnection{
+					Nodes:      []model.CompositeResource{gxrReady},
+					TotalCount: 1,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -735,7 +784,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := x.DefinedCompositeResources(tc.args.ctx, tc.args.obj, tc.args.version, tc.args.options)
+			got, err := x.DefinedCompositeResources(tc.args.ctx, tc.args.obj, tc.args.version, tc.args.options, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -745,6 +794,7 @@ func TestXRDDefinedCompositeResources(t *testing.T) {
 				t.Errorf("\n%s\nq.DefinedCompositeResources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.crc, got,
+				cmpopts.IgnoreFields(model.CompositeResourceConnection{}, "Edges", "PageInfo"),
 				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
 				cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess"),
 			); diff != "" {
@@ -1404,6 +1454,50 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 				},
 			},
 		},
+		"Selectors": {
+			reason: "We should pass the supplied label selector to the list call and only return resources matching the supplied field selector",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if diff := cmp.Diff("team=payments", lo.LabelSelector.String()); diff != "" {
+							t.Errorf("-want label selector, +got label selector:\n%s", diff)
+						}
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{xrc, xrcNotReady, xrcReady}}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceDefinition{
+					Spec: model.CompositeResourceDefinitionSpec{
+						Group: group,
+						ClaimNames: &model.CompositeResourceDefinitionNames{
+							Kind:     kind,
+							ListKind: ptr.To(listKind),
+						},
+						Versions: []model.CompositeResourceDefinitionVersion{
+							{
+								Name:   version,
+								Served: true,
+							},
+						},
+					},
+				},
+				options: &model.DefinedCompositeResourceClaimOptionsInput{
+					LabelSelector: &model.LabelSelectorInput{MatchLabels: map[string]string{"team": "payments"}},
+					FieldSelector: ptr.To("status.conditions[0].status=True"),
+				},
+			},
+			want: want{
+				crcc: model.CompositeResourceClaimConnection{
+					Nodes:      []model.CompositeResourceClaim{gxrcReady},
+					TotalCount: 1,
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -1412,7 +1506,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := x.DefinedCompositeResourceClaims(tc.args.ctx, tc.args.obj, tc.args.version, tc.args.namespace, tc.args.options)
+			got, err := x.DefinedCompositeResourceClaims(tc.args.ctx, tc.args.obj, tc.args.version, tc.args.namespace, tc.args.options, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -1422,6 +1516,7 @@ func TestXRDDefinedCompositeResourceClaims(t *testing.T) {
 				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.crcc, got,
+				cmpopts.IgnoreFields(model.CompositeResourceClaimConnection{}, "Edges", "PageInfo"),
 				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
 				cmpopts.IgnoreFields(model.CompositeResourceClaim{}, "PavedAccess"),
 			); diff != "" {
@@ -1642,3 +1737,350 @@ func TestCompositeResourceDefinitionSpecEnforcedComposition(t *testing.T) {
 		})
 	}
 }
+
+func TestCompositionRevisions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cr := extv1.CompositionRevision{ObjectMeta: metav1.ObjectMeta{Name: "coolcomposition-abcdef"}}
+	gcr := model.GetCompositionRevision(&cr)
+
+	type args struct {
+		ctx context.Context
+		obj *model.Composition
+	}
+	type want struct {
+		crc  model.CompositionRevisionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.Composition{},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListCompositionRevisionsError": {
+			reason: "If we can't list composition revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.Composition{},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListCompRevs)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should list the revisions of the composition.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if lo.LabelSelector.String() != extv1.LabelCompositionName+"=coolcomposition" {
+							return errors.Errorf("unexpected label selector %q", lo.LabelSelector)
+						}
+						*obj.(*extv1.CompositionRevisionList) = extv1.CompositionRevisionList{Items: []extv1.CompositionRevision{cr}}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.Composition{Metadata: model.ObjectMeta{Name: "coolcomposition"}},
+			},
+			want: want{
+				crc: model.CompositionRevisionConnection{
+					Nodes:      []model.CompositionRevision{gcr},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := &composition{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := c.Revisions(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.crc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.CompositionRevision{}, "PavedAccess"),
+				cmpopts.IgnoreFields(model.CompositionRevisionConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+			); diff != "" {
+				t.Errorf("\n%s\nc.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCompositionRevisionComposition(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "coolcomposition")
+
+	gcmp := model.GetComposition(&extv1.Composition{ObjectMeta: metav1.ObjectMeta{Name: "coolcomposition"}})
+	gcr := model.GetCompositionRevision(&extv1.CompositionRevision{ObjectMeta: metav1.ObjectMeta{
+		Labels: map[string]string{extv1.LabelCompositionName: "coolcomposition"},
+	}})
+
+	type args struct {
+		ctx context.Context
+		obj *model.CompositionRevision
+	}
+	type want struct {
+		cmp  *model.Composition
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoOp": {
+			reason: "If the revision isn't labelled with its composition we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositionRevision{},
+			},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gcr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetCompositionError": {
+			reason: "If we can't get the composition we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gcr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetComposition)),
+				},
+			},
+		},
+		"GetCompositionNotFound": {
+			reason: "If the composition is not found we return nil",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gcr,
+			},
+			want: want{},
+		},
+		"Success": {
+			reason: "If we can get and model the composition we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetName("coolcomposition")
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gcr,
+			},
+			want: want{
+				cmp: &gcmp,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &compositionRevision{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Composition(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Composition(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Composition(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cmp, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nr.Composition(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPipelineStepFunction(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "coolfunction")
+
+	gfn := model.GetFunction(&pkgv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "coolfunction"}})
+	gps := model.PipelineStep{Step: "render", FunctionRef: model.LocalObjectReference{Name: "coolfunction"}}
+
+	type args struct {
+		ctx context.Context
+		obj *model.PipelineStep
+	}
+	type want struct {
+		fn   *model.Function
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoOp": {
+			reason: "If the step doesn't reference a function we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.PipelineStep{},
+			},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gps,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetFunctionError": {
+			reason: "If we can't get the function we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gps,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetFunction)),
+				},
+			},
+		},
+		"GetFunctionNotFound": {
+			reason: "If the function is not found we return nil",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gps,
+			},
+			want: want{},
+		},
+		"Success": {
+			reason: "If we can get and model the function we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetName("coolfunction")
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &gps,
+			},
+			want: want{
+				fn: &gfn,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &pipelineStep{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Function(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Function(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Function(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.fn, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nr.Function(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
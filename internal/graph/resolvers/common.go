@@ -16,30 +16,38 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
 	"sort"
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
+	xunstructured "github.com/upbound/xgql/internal/unstructured"
 )
 
 const (
-	errModelDefined = "cannot model defined resource"
+	errModelDefined  = "cannot model defined resource"
+	errLabelSelector = "cannot parse label selector"
+	errFieldSelector = "cannot parse field selector"
+	errFilter        = "cannot filter resources"
 )
 
 type genericResource struct {
 	clients ClientCache
 }
 
-func (r *genericResource) Events(ctx context.Context, obj *model.GenericResource) (model.EventConnection, error) {
+func (r *genericResource) Events(ctx context.Context, obj *model.GenericResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
@@ -47,14 +55,24 @@ func (r *genericResource) Events(ctx context.Context, obj *model.GenericResource
 		Name:       obj.Metadata.Name,
 		Namespace:  ptr.Deref(obj.Metadata.Namespace, ""),
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *genericResource) UsedBy(ctx context.Context, obj *model.GenericResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *genericResource) Uses(ctx context.Context, obj *model.GenericResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 type secret struct {
 	clients ClientCache
 }
 
-func (r *secret) Events(ctx context.Context, obj *model.Secret) (model.EventConnection, error) {
+func (r *secret) Events(ctx context.Context, obj *model.Secret, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
@@ -62,14 +80,24 @@ func (r *secret) Events(ctx context.Context, obj *model.Secret) (model.EventConn
 		Name:       obj.Metadata.Name,
 		Namespace:  ptr.Deref(obj.Metadata.Namespace, ""),
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *secret) UsedBy(ctx context.Context, obj *model.Secret, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *secret) Uses(ctx context.Context, obj *model.Secret, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 type configMap struct {
 	clients ClientCache
 }
 
-func (r *configMap) Events(ctx context.Context, obj *model.ConfigMap) (model.EventConnection, error) {
+func (r *configMap) Events(ctx context.Context, obj *model.ConfigMap, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
@@ -77,24 +105,44 @@ func (r *configMap) Events(ctx context.Context, obj *model.ConfigMap) (model.Eve
 		Name:       obj.Metadata.Name,
 		Namespace:  ptr.Deref(obj.Metadata.Namespace, ""),
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configMap) UsedBy(ctx context.Context, obj *model.ConfigMap, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configMap) Uses(ctx context.Context, obj *model.ConfigMap, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 type crd struct {
 	clients ClientCache
 }
 
-func (r *crd) Events(ctx context.Context, obj *model.CustomResourceDefinition) (model.EventConnection, error) {
+func (r *crd) Events(ctx context.Context, obj *model.CustomResourceDefinition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
-func (r *crd) DefinedResources(ctx context.Context, obj *model.CustomResourceDefinition, version *string) (model.KubernetesResourceConnection, error) {
+func (r *crd) UsedBy(ctx context.Context, obj *model.CustomResourceDefinition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *crd) Uses(ctx context.Context, obj *model.CustomResourceDefinition, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *crd) DefinedResources(ctx context.Context, obj *model.CustomResourceDefinition, version *string, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -149,6 +197,14 @@ func (r *crd) DefinedResources(ctx context.Context, obj *model.CustomResourceDef
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
 	return *out, nil
 }
 
@@ -165,3 +221,57 @@ func pickCRDVersion(vs []model.CustomResourceDefinitionVersion) string {
 	// We shouldn't get here, unless the CRD is serving no versions?
 	return ""
 }
+
+// isProviderConfigCRD returns true if the supplied CRD defines a provider
+// config. By convention every provider defines a cluster scoped kind named
+// ProviderConfig. Anything else isn't a provider config.
+func isProviderConfigCRD(crd *xunstructured.CustomResourceDefinition) bool {
+	return crd.GetSpecNames().Kind == "ProviderConfig" && crd.GetSpecScope() == kextv1.ClusterScoped
+}
+
+// filterFields removes any resources that don't match the supplied field
+// selector from the supplied list. The cache implementation we use can only
+// match fields that were indexed when the cache was started, so we filter
+// listed resources here instead. This lets callers select on any field path.
+func filterFields(in *kunstructured.UnstructuredList, s fields.Selector) {
+	if s.Empty() {
+		return
+	}
+	items := in.Items[:0]
+	for i := range in.Items {
+		if fieldsMatch(s, in.Items[i].Object) {
+			items = append(items, in.Items[i])
+		}
+	}
+	in.Items = items
+}
+
+// fieldsMatch returns true if the supplied object satisfies the supplied field
+// selector. Fields that don't exist are treated as having an empty value.
+func fieldsMatch(s fields.Selector, obj map[string]any) bool {
+	p := fieldpath.Pave(obj)
+	set := fields.Set{}
+	for _, r := range s.Requirements() {
+		v, err := p.GetValue(r.Field)
+		if err != nil || v == nil {
+			continue
+		}
+		set[r.Field] = fmt.Sprint(v)
+	}
+	return s.Matches(set)
+}
+
+// filterWhere removes any resources that don't match the supplied filter from
+// the supplied list.
+func filterWhere(in *kunstructured.UnstructuredList, m *model.ResourceMatcher) {
+	if m == nil {
+		return
+	}
+	items := in.Items[:0]
+	for i := range in.Items {
+		if m.Matches(fieldpath.Pave(in.Items[i].Object)) {
+			items = append(items, in.Items[i])
+		}
+	}
+	in.Items = items
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -37,6 +38,7 @@ const (
 	errListXRDs            = "cannot list composite resource definitions"
 	errMalformedAPIVersion = "cannot parse malformed API version"
 	errGetComposition      = "cannot get composition"
+	errGetCompositionRev   = "cannot get composition revision"
 	errGetXR               = "cannot get composite resource"
 	errGetXRC              = "cannot get composite resource claim"
 	errGetComposed         = "cannot get composed resource"
@@ -47,14 +49,24 @@ type compositeResource struct {
 	clients ClientCache
 }
 
-func (r *compositeResource) Events(ctx context.Context, obj *model.CompositeResource) (model.EventConnection, error) {
+func (r *compositeResource) Events(ctx context.Context, obj *model.CompositeResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositeResource) UsedBy(ctx context.Context, obj *model.CompositeResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositeResource) Uses(ctx context.Context, obj *model.CompositeResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 func (r *compositeResource) Definition(ctx context.Context, obj *model.CompositeResource) (*model.CompositeResourceDefinition, error) {
@@ -103,6 +115,11 @@ func (r *compositeResource) Definition(ctx context.Context, obj *model.Composite
 	return nil, nil
 }
 
+func (r *compositeResource) Ancestors(ctx context.Context, obj *model.CompositeResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
+	a := &ancestors{clients: r.clients}
+	return a.Resolve(ctx, &unstructured.Unstructured{Object: obj.UnstructuredContent()}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
 type compositeResourceSpec struct {
 	clients ClientCache
 }
@@ -143,6 +160,42 @@ func (r *compositeResourceSpec) CompositionRef(ctx context.Context, obj *model.C
 	return &model.LocalObjectReference{Name: obj.CompositionReference.Name}, nil
 }
 
+func (r *compositeResourceSpec) CompositionRevision(ctx context.Context, obj *model.CompositeResourceSpec) (*model.CompositionRevision, error) {
+	if obj.CompositionRevisionReference == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	cr := &extv1.CompositionRevision{}
+	nn := types.NamespacedName{Name: obj.CompositionRevisionReference.Name}
+	if err := c.Get(ctx, nn, cr); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCompositionRev))
+		}
+
+		return nil, nil
+	}
+
+	out := model.GetCompositionRevision(cr)
+	return &out, nil
+}
+
+func (r *compositeResourceSpec) CompositionRevisionRef(ctx context.Context, obj *model.CompositeResourceSpec) (*model.LocalObjectReference, error) {
+	if obj.CompositionRevisionReference == nil {
+		return nil, nil
+	}
+
+	return &model.LocalObjectReference{Name: obj.CompositionRevisionReference.Name}, nil
+}
+
 func (r *compositeResourceSpec) Claim(ctx context.Context, obj *model.CompositeResourceSpec) (*model.CompositeResourceClaim, error) {
 	if obj.ClaimReference == nil {
 		return nil, nil
@@ -189,7 +242,7 @@ func (r *compositeResourceSpec) ClaimRef(ctx context.Context, obj *model.Composi
 	}), nil
 }
 
-func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.CompositeResourceSpec) (model.KubernetesResourceConnection, error) {
+func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.CompositeResourceSpec, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -245,6 +298,14 @@ func (r *compositeResourceSpec) Resources(ctx context.Context, obj *model.Compos
 	wg.Wait()
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
 	return *out, nil
 }
 
@@ -256,6 +317,62 @@ func (r *compositeResourceSpec) ResourceRefs(ctx context.Context, obj *model.Com
 	return resourceRefs, nil
 }
 
+func (r *compositeResourceSpec) EnvironmentConfigs(ctx context.Context, obj *model.CompositeResourceSpec, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EnvironmentConfigConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+
+	out := &model.EnvironmentConfigConnection{
+		Nodes: make([]model.EnvironmentConfig, 0, len(obj.EnvironmentConfigReferences)),
+	}
+
+	// Crossplane merges environment configs in reference order, so later
+	// references take precedence. A config referenced more than once is only
+	// included at its last, highest precedence, position.
+	lastRef := make(map[string]int, len(obj.EnvironmentConfigReferences))
+	for i, ref := range obj.EnvironmentConfigReferences {
+		lastRef[ref.Name] = i
+	}
+
+	for i, ref := range obj.EnvironmentConfigReferences {
+		// Ignore nameless environment config references
+		if ref.Name == "" {
+			continue
+		}
+
+		if lastRef[ref.Name] != i {
+			continue
+		}
+
+		ec := &extv1alpha1.EnvironmentConfig{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, ec); err != nil {
+			if !apierrors.IsNotFound(err) {
+				graphql.AddError(ctx, errors.Wrap(err, errGetEnvConfig))
+			}
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetEnvironmentConfig(ec))
+		out.TotalCount++
+	}
+
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+	return *out, nil
+}
+
 func (r *compositeResourceSpec) ConnectionSecret(ctx context.Context, obj *model.CompositeResourceSpec) (*model.Secret, error) {
 	if obj.WriteConnectionSecretToReference == nil {
 		return nil, nil
@@ -296,14 +413,24 @@ type compositeResourceClaim struct {
 	clients ClientCache
 }
 
-func (r *compositeResourceClaim) Events(ctx context.Context, obj *model.CompositeResourceClaim) (model.EventConnection, error) {
+func (r *compositeResourceClaim) Events(ctx context.Context, obj *model.CompositeResourceClaim, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositeResourceClaim) UsedBy(ctx context.Context, obj *model.CompositeResourceClaim, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *compositeResourceClaim) Uses(ctx context.Context, obj *model.CompositeResourceClaim, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 func (r *compositeResourceClaim) Definition(ctx context.Context, obj *model.CompositeResourceClaim) (*model.CompositeResourceDefinition, error) {
@@ -396,6 +523,42 @@ func (r *compositeResourceClaimSpec) Composition(ctx context.Context, obj *model
 	return &out, nil
 }
 
+func (r *compositeResourceClaimSpec) CompositionRevision(ctx context.Context, obj *model.CompositeResourceClaimSpec) (*model.CompositionRevision, error) {
+	if obj.CompositionRevisionReference == nil {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	cr := &extv1.CompositionRevision{}
+	nn := types.NamespacedName{Name: obj.CompositionRevisionReference.Name}
+	if err := c.Get(ctx, nn, cr); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCompositionRev))
+		}
+
+		return nil, nil
+	}
+
+	out := model.GetCompositionRevision(cr)
+	return &out, nil
+}
+
+func (r *compositeResourceClaimSpec) CompositionRevisionRef(ctx context.Context, obj *model.CompositeResourceClaimSpec) (*model.LocalObjectReference, error) {
+	if obj.CompositionRevisionReference == nil {
+		return nil, nil
+	}
+
+	return &model.LocalObjectReference{Name: obj.CompositionRevisionReference.Name}, nil
+}
+
 func (r *compositeResourceClaimSpec) Resource(ctx context.Context, obj *model.CompositeResourceClaimSpec) (*model.CompositeResource, error) {
 	if obj.ResourceReference == nil {
 		return nil, nil
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +36,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource/unstructured/claim"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -379,6 +381,130 @@ func TestCompositeResourceSpecCompositionRef(t *testing.T) {
 	}
 }
 
+func TestCompositeResourceSpecCompositionRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := apierrors.NewNotFound(schema.GroupResource{}, "somename")
+
+	gcr := model.GetCompositionRevision(&extv1.CompositionRevision{})
+
+	type args struct {
+		ctx context.Context
+		obj *model.CompositeResourceSpec
+	}
+	type want struct {
+		cr   *model.CompositionRevision
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoOp": {
+			reason: "If there is no composition revision we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{},
+			},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetCompositionRevisionError": {
+			reason: "If we can't get the composition revision we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetCompositionRev)),
+				},
+			},
+		},
+		"GetCompositionRevisionNotFound": {
+			reason: "If the composition revision is not found we return nil",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				cr: nil,
+			},
+		},
+		"Success": {
+			reason: "If we can get and model the composition revision we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				cr: &gcr,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &compositeResourceSpec{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := s.CompositionRevision(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.CompositionRevision(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.CompositionRevision(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.CompositionRevision(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCompositeResourceSpecClaim(t *testing.T) {
 	errBoom := errors.New("boom")
 	errNotFound := apierrors.NewNotFound(schema.GroupResource{}, "somename")
@@ -729,7 +855,7 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := s.Resources(tc.args.ctx, tc.args.obj)
+			got, err := s.Resources(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -738,13 +864,176 @@ func TestCompositeResourceSpecResources(t *testing.T) {
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ns.Claim(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.krc, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+			if diff := cmp.Diff(tc.want.krc, got, cmpopts.IgnoreFields(model.KubernetesResourceConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
 				t.Errorf("\n%s\ns.Claim(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
+func TestCompositeResourceSpecEnvironmentConfigs(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	gec := model.GetEnvironmentConfig(&extv1alpha1.EnvironmentConfig{ObjectMeta: metav1.ObjectMeta{Name: "coolenv"}})
+	gecA := model.GetEnvironmentConfig(&extv1alpha1.EnvironmentConfig{ObjectMeta: metav1.ObjectMeta{Name: "a"}})
+	gecZ := model.GetEnvironmentConfig(&extv1alpha1.EnvironmentConfig{ObjectMeta: metav1.ObjectMeta{Name: "z"}})
+
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.SetName(key.Name)
+		return nil
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.CompositeResourceSpec
+	}
+	type want struct {
+		ecc  model.EnvironmentConfigConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetEnvironmentConfigError": {
+			reason: "If we can't get an environment config we should add the error to the GraphQL context and continue.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					EnvironmentConfigReferences: []corev1.ObjectReference{{Name: "coolenv"}},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetEnvConfig)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return the referenced environment configs, ignoring nameless references and those that don't exist.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						if key.Name != "coolenv" {
+							return apierrors.NewNotFound(schema.GroupResource{}, key.Name)
+						}
+						obj.SetName(key.Name)
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					EnvironmentConfigReferences: []corev1.ObjectReference{
+						{Name: "coolenv"},
+						{Name: "missing"},
+						{},
+					},
+				},
+			},
+			want: want{
+				ecc: model.EnvironmentConfigConnection{
+					Nodes:      []model.EnvironmentConfig{gec},
+					TotalCount: 1,
+				},
+			},
+		},
+		"ReferenceOrder": {
+			reason: "We should return environment configs in reference order, which is the order in which they're merged.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					EnvironmentConfigReferences: []corev1.ObjectReference{
+						{Name: "z"},
+						{Name: "a"},
+					},
+				},
+			},
+			want: want{
+				ecc: model.EnvironmentConfigConnection{
+					Nodes:      []model.EnvironmentConfig{gecZ, gecA},
+					TotalCount: 2,
+				},
+			},
+		},
+		"DuplicateReferences": {
+			reason: "We should only return an environment config that is referenced more than once at its last, highest precedence, reference.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceSpec{
+					EnvironmentConfigReferences: []corev1.ObjectReference{
+						{Name: "a"},
+						{Name: "z"},
+						{Name: "a"},
+					},
+				},
+			},
+			want: want{
+				ecc: model.EnvironmentConfigConnection{
+					Nodes:      []model.EnvironmentConfig{gecZ, gecA},
+					TotalCount: 2,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &compositeResourceSpec{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := s.EnvironmentConfigs(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.EnvironmentConfigs(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.EnvironmentConfigs(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.ecc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.EnvironmentConfigConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{}),
+			); diff != "" {
+				t.Errorf("\n%s\ns.EnvironmentConfigs(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCompositeResourceSpecConnectionSecret(t *testing.T) {
 	errBoom := errors.New("boom")
 	errNotFound := apierrors.NewNotFound(schema.GroupResource{}, "somename")
@@ -1272,6 +1561,130 @@ func TestCompositeResourceClaimSpecCompositionRef(t *testing.T) {
 	}
 }
 
+func TestCompositeResourceClaimSpecCompositionRevision(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := apierrors.NewNotFound(schema.GroupResource{}, "somename")
+
+	gcr := model.GetCompositionRevision(&extv1.CompositionRevision{})
+
+	type args struct {
+		ctx context.Context
+		obj *model.CompositeResourceClaimSpec
+	}
+	type want struct {
+		cr   *model.CompositionRevision
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoOp": {
+			reason: "If there is no composition revision we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceClaimSpec{},
+			},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceClaimSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetCompositionRevisionError": {
+			reason: "If we can't get the composition revision we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceClaimSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetCompositionRev)),
+				},
+			},
+		},
+		"GetCompositionRevisionNotFound": {
+			reason: "If the composition revision is not found we return nil",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceClaimSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				cr: nil,
+			},
+		},
+		"Success": {
+			reason: "If we can get and model the composition revision we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.CompositeResourceClaimSpec{
+					CompositionRevisionReference: &corev1.ObjectReference{},
+				},
+			},
+			want: want{
+				cr: &gcr,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			s := &compositeResourceClaimSpec{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := s.CompositionRevision(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.CompositionRevision(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.CompositionRevision(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.CompositionRevision(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCompositeResourceClaimSpecResource(t *testing.T) {
 	errBoom := errors.New("boom")
 	errNotFound := apierrors.NewNotFound(schema.GroupResource{}, "somename")
@@ -43,17 +43,27 @@ type configuration struct {
 	clients ClientCache
 }
 
-func (r *configuration) Events(ctx context.Context, obj *model.Configuration) (model.EventConnection, error) {
+func (r *configuration) Events(ctx context.Context, obj *model.Configuration, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
-func (r *configuration) Revisions(ctx context.Context, obj *model.Configuration) (model.ConfigurationRevisionConnection, error) {
+func (r *configuration) UsedBy(ctx context.Context, obj *model.Configuration, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configuration) Uses(ctx context.Context, obj *model.Configuration, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configuration) Revisions(ctx context.Context, obj *model.Configuration, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ConfigurationRevisionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -89,6 +99,14 @@ func (r *configuration) Revisions(ctx context.Context, obj *model.Configuration)
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ConfigurationRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ConfigurationRevisionConnection{}, nil
+	}
 	return *out, nil
 }
 
@@ -131,25 +149,65 @@ func (r *configuration) ActiveRevision(ctx context.Context, obj *model.Configura
 	return nil, nil
 }
 
+func (r *configuration) Dependencies(ctx context.Context, obj *model.Configuration) ([]model.LockDependency, error) {
+	rev, _ := r.ActiveRevision(ctx, obj)
+	if rev == nil {
+		return nil, nil
+	}
+
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependencies(ctx, rev.Metadata.Name)
+}
+
+func (r *configuration) Dependents(ctx context.Context, obj *model.Configuration) ([]model.LockPackage, error) {
+	rev, _ := r.ActiveRevision(ctx, obj)
+	if rev == nil {
+		return nil, nil
+	}
+
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependents(ctx, rev.Metadata.Name)
+}
+
 type configurationRevision struct {
 	clients ClientCache
 }
 
-func (r *configurationRevision) Events(ctx context.Context, obj *model.ConfigurationRevision) (model.EventConnection, error) {
+func (r *configurationRevision) Events(ctx context.Context, obj *model.ConfigurationRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configurationRevision) UsedBy(ctx context.Context, obj *model.ConfigurationRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configurationRevision) Uses(ctx context.Context, obj *model.ConfigurationRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *configurationRevision) Dependencies(ctx context.Context, obj *model.ConfigurationRevision) ([]model.LockDependency, error) {
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependencies(ctx, obj.Metadata.Name)
+}
+
+func (r *configurationRevision) Dependents(ctx context.Context, obj *model.ConfigurationRevision) ([]model.LockPackage, error) {
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependents(ctx, obj.Metadata.Name)
 }
 
 type configurationRevisionStatus struct {
 	clients ClientCache
 }
 
-func (r *configurationRevisionStatus) Objects(ctx context.Context, obj *model.ConfigurationRevisionStatus) (model.KubernetesResourceConnection, error) {
+func (r *configurationRevisionStatus) Objects(ctx context.Context, obj *model.ConfigurationRevisionStatus, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -208,5 +266,14 @@ func (r *configurationRevisionStatus) Objects(ctx context.Context, obj *model.Co
 	}
 	wg.Wait()
 
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
 	return *out, nil
 }
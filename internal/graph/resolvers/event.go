@@ -40,7 +40,7 @@ type events struct {
 	clients ClientCache
 }
 
-func (r *events) Resolve(ctx context.Context, obj *corev1.ObjectReference) (model.EventConnection, error) {
+func (r *events) Resolve(ctx context.Context, obj *corev1.ObjectReference, o []model.OrderBy, pa model.PageArgs) (model.EventConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -75,6 +75,14 @@ func (r *events) Resolve(ctx context.Context, obj *corev1.ObjectReference) (mode
 			Nodes:      nodes,
 			TotalCount: len(nodes),
 		}
+		if err := out.Order(o); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errOrder))
+			return model.EventConnection{}, nil
+		}
+		if err := out.Paginate(pa); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+			return model.EventConnection{}, nil
+		}
 
 		return *out, nil
 	}
@@ -101,6 +109,14 @@ func (r *events) Resolve(ctx context.Context, obj *corev1.ObjectReference) (mode
 	}
 
 	sort.Stable(sort.Reverse(out))
+	if err := out.Order(o); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.EventConnection{}, nil
+	}
+	if err := out.Paginate(pa); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.EventConnection{}, nil
+	}
 	return *out, nil
 }
 
@@ -135,6 +151,30 @@ func involves(e *corev1.Event, ref *corev1.ObjectReference) bool {
 	}
 }
 
+// latestWarning returns the most recent warning event pertaining to the
+// supplied object, or nil if there is none.
+func latestWarning(in []corev1.Event, ref *corev1.ObjectReference) *model.Event {
+	if ref == nil {
+		return nil
+	}
+
+	var latest *corev1.Event
+	for i := range in {
+		e := &in[i] // To avoid taking the address of the range var.
+		if e.Type != corev1.EventTypeWarning || !involves(e, ref) {
+			continue
+		}
+		if latest == nil || latest.LastTimestamp.Before(&e.LastTimestamp) {
+			latest = e
+		}
+	}
+	if latest == nil {
+		return nil
+	}
+	out := model.GetEvent(latest)
+	return &out
+}
+
 type event struct {
 	clients ClientCache
 }
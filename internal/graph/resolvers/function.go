@@ -0,0 +1,252 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+	xunstructured "github.com/upbound/xgql/internal/unstructured"
+)
+
+const (
+	errListFunctionRevs = "cannot list function revisions"
+)
+
+type function struct {
+	clients ClientCache
+}
+
+func (r *function) Events(ctx context.Context, obj *model.Function, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *function) UsedBy(ctx context.Context, obj *model.Function, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *function) Uses(ctx context.Context, obj *model.Function, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *function) Revisions(ctx context.Context, obj *model.Function, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.FunctionRevisionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	in := &pkgv1.FunctionRevisionList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctionRevs))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	out := &model.FunctionRevisionConnection{
+		Nodes: make([]model.FunctionRevision, 0),
+	}
+
+	for i := range in.Items {
+		fr := in.Items[i] // So we don't take the address of a range variable.
+
+		// We're not the controller reference of this FunctionRevision; it's not
+		// one of ours.
+		// https://github.com/kubernetes/community/blob/0331e/contributors/design-proposals/api-machinery/controller-ref.md
+		if c := metav1.GetControllerOf(&fr); c == nil || c.UID != types.UID(obj.Metadata.UID) {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetFunctionRevision(&fr))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.FunctionRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.FunctionRevisionConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *function) ActiveRevision(ctx context.Context, obj *model.Function) (*model.FunctionRevision, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	in := &pkgv1.FunctionRevisionList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctionRevs))
+		return nil, nil
+	}
+
+	for i := range in.Items {
+		fr := in.Items[i] // So we don't take the address of a range variable.
+
+		// This revision is not active.
+		if fr.Spec.DesiredState != pkgv1.PackageRevisionActive {
+			continue
+		}
+
+		// We're not the controller reference of this FunctionRevision;
+		// it's not one of ours.
+		// https://github.com/kubernetes/community/blob/0331e/contributors/design-proposals/api-machinery/controller-ref.md
+		if c := metav1.GetControllerOf(&fr); c == nil || c.UID != types.UID(obj.Metadata.UID) {
+			continue
+		}
+
+		out := model.GetFunctionRevision(&fr)
+		return &out, nil
+	}
+
+	return nil, nil
+}
+
+func (r *function) Deployment(ctx context.Context, obj *model.Function) (model.KubernetesResource, error) {
+	rev, _ := r.ActiveRevision(ctx, obj)
+	if rev == nil {
+		return nil, nil
+	}
+
+	rt := &packageRuntime{clients: r.clients}
+	return rt.Deployment(ctx, rev.Metadata.Name, types.UID(rev.Metadata.UID))
+}
+
+type functionRevision struct {
+	clients ClientCache
+}
+
+func (r *functionRevision) Events(ctx context.Context, obj *model.FunctionRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *functionRevision) UsedBy(ctx context.Context, obj *model.FunctionRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *functionRevision) Uses(ctx context.Context, obj *model.FunctionRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *functionRevision) Runtime(ctx context.Context, obj *model.FunctionRevision) (*model.PackageRuntime, error) {
+	rt := &packageRuntime{clients: r.clients}
+	return rt.Runtime(ctx, obj.Metadata.Name, types.UID(obj.Metadata.UID))
+}
+
+type functionRevisionStatus struct {
+	clients ClientCache
+}
+
+func (r *functionRevisionStatus) Objects(ctx context.Context, obj *model.FunctionRevisionStatus, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	out := &model.KubernetesResourceConnection{
+		Nodes: make([]model.KubernetesResource, 0, len(obj.ObjectRefs)),
+	}
+
+	// Collect all concurrently.
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, ref := range obj.ObjectRefs {
+		// Function packages may only contain CRDs, but this isn't enforced at
+		// the API level. We filter out anything that isn't a CRD, just in case.
+		if ref.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		if strings.Split(ref.APIVersion, "/")[0] != kextv1.GroupName {
+			continue
+		}
+
+		ref := ref // So we don't take the address of a range variable.
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			crd := xunstructured.NewCRD()
+			if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, crd.GetUnstructured()); err != nil {
+				graphql.AddError(ctx, errors.Wrap(err, errGetCRD))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			out.Nodes = append(out.Nodes, model.GetCustomResourceDefinition(crd))
+			out.TotalCount++
+		}()
+	}
+	wg.Wait()
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	return *out, nil
+}
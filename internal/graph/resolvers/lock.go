@@ -0,0 +1,138 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errGetLock = "cannot get package lock"
+)
+
+// The package manager records all installed packages in a single Lock with
+// this name.
+const lockName = "lock"
+
+type packageLock struct {
+	clients ClientCache
+}
+
+func (r *packageLock) Events(ctx context.Context, obj *model.PackageLock, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *packageLock) UsedBy(ctx context.Context, obj *model.PackageLock, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *packageLock) Uses(ctx context.Context, obj *model.PackageLock, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+// packageDependencies resolves the dependencies and dependents of a package
+// revision, as recorded by the package manager's Lock. The Lock identifies
+// packages by the name of their package revision.
+type packageDependencies struct {
+	clients ClientCache
+}
+
+// lock returns the package lock, or nil if it doesn't exist or can't be read.
+func (r *packageDependencies) lock(ctx context.Context) *pkgv1beta1.Lock {
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil
+	}
+
+	l := &pkgv1beta1.Lock{}
+	if err := c.Get(ctx, types.NamespacedName{Name: lockName}, l); err != nil {
+		// A missing lock just means no packages have been installed yet.
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetLock))
+		}
+		return nil
+	}
+	return l
+}
+
+func (r *packageDependencies) Dependencies(ctx context.Context, revision string) ([]model.LockDependency, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	l := r.lock(ctx)
+	if l == nil {
+		return nil, nil
+	}
+
+	for _, p := range l.Packages {
+		if p.Name == revision {
+			return model.GetLockPackage(p, l).Dependencies, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *packageDependencies) Dependents(ctx context.Context, revision string) ([]model.LockPackage, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	l := r.lock(ctx)
+	if l == nil {
+		return nil, nil
+	}
+
+	source := ""
+	for _, p := range l.Packages {
+		if p.Name == revision {
+			source = p.Source
+			break
+		}
+	}
+	if source == "" {
+		return nil, nil
+	}
+
+	out := make([]model.LockPackage, 0)
+	for _, p := range l.Packages {
+		for _, d := range p.Dependencies {
+			if d.Package == source {
+				out = append(out, model.GetLockPackage(p, l))
+				break
+			}
+		}
+	}
+	return out, nil
+}
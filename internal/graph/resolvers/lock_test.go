@@ -0,0 +1,266 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+var _ generated.PackageLockResolver = &packageLock{}
+
+// A lock in which a configuration depends on a provider and a function. The
+// function hasn't been installed yet.
+func withLock(obj client.Object) error {
+	*obj.(*pkgv1beta1.Lock) = pkgv1beta1.Lock{
+		Packages: []pkgv1beta1.LockPackage{
+			{
+				Name:    "cool-config-abcdef",
+				Type:    pkgv1beta1.ConfigurationPackageType,
+				Source:  "example.org/cool-config",
+				Version: "v1.0.0",
+				Dependencies: []pkgv1beta1.Dependency{
+					{Package: "example.org/cool-provider", Type: pkgv1beta1.ProviderPackageType, Constraints: ">=v0.1.0"},
+					{Package: "example.org/cool-function", Type: pkgv1beta1.FunctionPackageType, Constraints: "v0.2.0"},
+				},
+			},
+			{
+				Name:    "cool-provider-123456",
+				Type:    pkgv1beta1.ProviderPackageType,
+				Source:  "example.org/cool-provider",
+				Version: "v0.3.0",
+			},
+		},
+	}
+	return nil
+}
+
+func TestPackageDependenciesDependencies(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "lock")
+
+	type want struct {
+		deps []model.LockDependency
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason   string
+		clients  ClientCache
+		revision string
+		want     want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			revision: "cool-config-abcdef",
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetLockError": {
+			reason: "If we can't get the lock we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			revision: "cool-config-abcdef",
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetLock)),
+				},
+			},
+		},
+		"GetLockNotFound": {
+			reason: "If the lock doesn't exist the package has no dependencies.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			revision: "cool-config-abcdef",
+			want:     want{},
+		},
+		"NotLocked": {
+			reason: "If the package revision isn't in the lock it has no dependencies.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withLock),
+				}, nil
+			}),
+			revision: "other-config-abcdef",
+			want:     want{},
+		},
+		"Success": {
+			reason: "We should return the dependencies of the package revision, resolved to the versions in the lock.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withLock),
+				}, nil
+			}),
+			revision: "cool-config-abcdef",
+			want: want{
+				deps: []model.LockDependency{
+					{Package: "example.org/cool-provider", Type: ptr.To(model.PackageTypeProvider), Constraints: ">=v0.1.0", Version: ptr.To("v0.3.0")},
+					{Package: "example.org/cool-function", Type: ptr.To(model.PackageTypeFunction), Constraints: "v0.2.0"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &packageDependencies{clients: tc.clients}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Dependencies(ctx, tc.revision)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Dependencies(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Dependencies(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deps, got); diff != "" {
+				t.Errorf("\n%s\nr.Dependencies(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPackageDependenciesDependents(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		pkgs []model.LockPackage
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason   string
+		clients  ClientCache
+		revision string
+		want     want
+	}{
+		"GetLockError": {
+			reason: "If we can't get the lock we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			revision: "cool-provider-123456",
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetLock)),
+				},
+			},
+		},
+		"NotLocked": {
+			reason: "If the package revision isn't in the lock it has no dependents.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withLock),
+				}, nil
+			}),
+			revision: "other-provider-123456",
+			want:     want{},
+		},
+		"NoDependents": {
+			reason: "If no package depends on the package revision we should return an empty slice.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withLock),
+				}, nil
+			}),
+			revision: "cool-config-abcdef",
+			want: want{
+				pkgs: []model.LockPackage{},
+			},
+		},
+		"Success": {
+			reason: "We should return the packages that depend on the package revision's source.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withLock),
+				}, nil
+			}),
+			revision: "cool-provider-123456",
+			want: want{
+				pkgs: []model.LockPackage{
+					{
+						Name:    "cool-config-abcdef",
+						Type:    ptr.To(model.PackageTypeConfiguration),
+						Source:  "example.org/cool-config",
+						Version: "v1.0.0",
+						Dependencies: []model.LockDependency{
+							{Package: "example.org/cool-provider", Type: ptr.To(model.PackageTypeProvider), Constraints: ">=v0.1.0", Version: ptr.To("v0.3.0")},
+							{Package: "example.org/cool-function", Type: ptr.To(model.PackageTypeFunction), Constraints: "v0.2.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &packageDependencies{clients: tc.clients}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Dependents(ctx, tc.revision)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Dependents(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Dependents(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pkgs, got); diff != "" {
+				t.Errorf("\n%s\nr.Dependents(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
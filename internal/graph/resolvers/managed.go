@@ -17,13 +17,14 @@ package resolvers
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
@@ -36,24 +37,66 @@ const (
 	errListCRDs = "cannot list custom resource definitions"
 )
 
+// getDefiningCRD returns the CRD that defines the supplied kind of resource,
+// or nil if there is none. A CRD's name is the plural resource name of the kind
+// it defines, qualified by its group. We ask the REST mapper for the resource
+// name, falling back to listing all CRDs if it can't map the kind or we can't
+// find a CRD by that name.
+func getDefiningCRD(ctx context.Context, c client.Client, gvk schema.GroupVersionKind) (*unstructured.CustomResourceDefinition, error) {
+	if m := c.RESTMapper(); m != nil {
+		if rm, err := m.RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			in := unstructured.NewCRD()
+			nn := types.NamespacedName{Name: fmt.Sprintf("%s.%s", rm.Resource.Resource, gvk.Group)}
+			err := c.Get(ctx, nn, in.GetUnstructured())
+			if err != nil && !kerrors.IsNotFound(err) {
+				return nil, errors.Wrap(err, errGetCRD)
+			}
+			if err == nil && in.GetSpecGroup() == gvk.Group && in.GetSpecNames().Kind == gvk.Kind {
+				return in, nil
+			}
+		}
+	}
+
+	lin := unstructured.NewCRDList()
+	if err := c.List(ctx, lin.GetUnstructuredList()); err != nil {
+		return nil, errors.Wrap(err, errListCRDs)
+	}
+
+	for i := range lin.Items {
+		crd := &unstructured.CustomResourceDefinition{Unstructured: lin.Items[i]} // So we don't take the address of a range variable.
+		if crd.GetSpecGroup() == gvk.Group && crd.GetSpecNames().Kind == gvk.Kind {
+			return crd, nil
+		}
+	}
+
+	return nil, nil
+}
+
 type managedResource struct {
 	clients ClientCache
 }
 
-func (r *managedResource) Events(ctx context.Context, obj *model.ManagedResource) (model.EventConnection, error) {
+func (r *managedResource) Events(ctx context.Context, obj *model.ManagedResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *managedResource) UsedBy(ctx context.Context, obj *model.ManagedResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *managedResource) Uses(ctx context.Context, obj *model.ManagedResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
-func (r *managedResource) Definition(ctx context.Context, obj *model.ManagedResource) (model.ManagedResourceDefinition, error) { //nolint:gocyclo
-	// NOTE(tnthornton) this function is not really all that complex at the
-	// moment, however we should be wary of future addtions as we are already
-	// running into cyclomatic complexity errors.
+func (r *managedResource) Definition(ctx context.Context, obj *model.ManagedResource) (model.ManagedResourceDefinition, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -72,50 +115,22 @@ func (r *managedResource) Definition(ctx context.Context, obj *model.ManagedReso
 		return nil, nil
 	}
 
-	name := pluralForm(strings.ToLower(obj.Kind))
-
-	nn := types.NamespacedName{Name: fmt.Sprintf("%s.%s", name, gv.Group)}
-	in := unstructured.NewCRD()
-	err = c.Get(ctx, nn, in.GetUnstructured())
-
-	if err != nil && !kerrors.IsNotFound(err) {
-		graphql.AddError(ctx, errors.Wrap(err, errGetCRD))
+	crd, err := getDefiningCRD(ctx, c, gv.WithKind(obj.Kind))
+	if err != nil {
+		graphql.AddError(ctx, err)
 		return nil, nil
 	}
-
-	// We didn't find the CRD we were looking for, list all CRDs and see if we
-	// can find the matching one.
-	if kerrors.IsNotFound(err) {
-		lin := unstructured.NewCRDList()
-		if err := c.List(ctx, lin.GetUnstructuredList()); err != nil {
-			graphql.AddError(ctx, errors.Wrap(err, errListCRDs))
-			return nil, nil
-		}
-
-		for i := range lin.Items {
-			crd := unstructured.CustomResourceDefinition{Unstructured: lin.Items[i]} // So we don't take the address of a range variable.
-
-			if crd.GetSpecGroup() != gv.Group {
-				continue
-			}
-
-			if crd.GetSpecNames().Kind != obj.Kind {
-				continue
-			}
-
-			out := model.GetCustomResourceDefinition(&crd)
-			return &out, nil
-		}
+	if crd == nil {
+		return nil, nil
 	}
 
-	// We found a CRD, let's double check the Group and Kind match our
-	// expectations.
-	if in.GetSpecGroup() == gv.Group && in.GetSpecNames().Kind == obj.Kind {
-		out := model.GetCustomResourceDefinition(in)
-		return &out, nil
-	}
+	out := model.GetCustomResourceDefinition(crd)
+	return &out, nil
+}
 
-	return nil, nil
+func (r *managedResource) Ancestors(ctx context.Context, obj *model.ManagedResource, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
+	a := &ancestors{clients: r.clients}
+	return a.Resolve(ctx, &kunstructured.Unstructured{Object: obj.UnstructuredContent()}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 type managedResourceSpec struct {
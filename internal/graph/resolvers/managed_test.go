@@ -25,8 +25,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -43,6 +45,22 @@ import (
 
 var _ generated.ManagedResourceSpecResolver = &managedResourceSpec{}
 
+// A mappedClient is a mock client with a REST mapper.
+type mappedClient struct {
+	*test.MockClient
+	mapper meta.RESTMapper
+}
+
+func (c *mappedClient) RESTMapper() meta.RESTMapper { return c.mapper }
+
+// exampleMapper returns a REST mapper that knows the example.org/v1 Example
+// kind, whose plural resource name is examples.
+func exampleMapper() meta.RESTMapper {
+	m := meta.NewDefaultRESTMapper(nil)
+	m.Add(schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Example"}, meta.RESTScopeRoot)
+	return m
+}
+
 func TestManagedResourceDefinition(t *testing.T) {
 	errBoom := errors.New("boom")
 	errNotFound := &kerrors.StatusError{
@@ -104,13 +122,19 @@ func TestManagedResourceDefinition(t *testing.T) {
 		"GetCRDError": {
 			reason: "If we can't get the CRD we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockGet: test.NewMockGetFn(errBoom),
+				return &mappedClient{
+					MockClient: &test.MockClient{
+						MockGet: test.NewMockGetFn(errBoom),
+					},
+					mapper: exampleMapper(),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				obj: &model.ManagedResource{},
+				obj: &model.ManagedResource{
+					APIVersion: crd.GetSpecGroup() + "/v1",
+					Kind:       crd.GetSpecNames().Kind,
+				},
 			},
 			want: want{
 				errs: gqlerror.List{
@@ -141,11 +165,14 @@ func TestManagedResourceDefinition(t *testing.T) {
 		"FoundCRD": {
 			reason: "If we can get and model the CRD that defines this managed resource we should return it.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
-						*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
-						return nil
-					}),
+				return &mappedClient{
+					MockClient: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
+							return nil
+						}),
+					},
+					mapper: exampleMapper(),
 				}, nil
 			}),
 			args: args{
@@ -160,9 +187,7 @@ func TestManagedResourceDefinition(t *testing.T) {
 			},
 		},
 		"DifferentPlural": {
-			reason: `In the event we get a request for an object whose CRD has
-			a non-predictable plural form, ensure the CRD list contains the
-			expected resource.`,
+			reason: "If the REST mapper can't map the kind we should fall back to finding the CRD with a matching group and kind, regardless of its plural form.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
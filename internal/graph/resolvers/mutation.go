@@ -19,22 +19,34 @@ import (
 	"encoding/json"
 
 	"github.com/99designs/gqlgen/graphql"
+	jd "github.com/josephburnett/jd/lib"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
 	"github.com/upbound/xgql/internal/graph/model"
+	"github.com/upbound/xgql/internal/graph/present"
+	"github.com/upbound/xgql/internal/live_query"
 )
 
 const (
 	errCreateResource        = "cannot create Kubernetes resource"
 	errUpdateResource        = "cannot update Kubernetes resource"
+	errApplyResource         = "cannot apply Kubernetes resource"
+	errPatchResource         = "cannot patch Kubernetes resource"
+	errUnmarshalPatch        = "cannot unmarshal patch JSON"
+	errGetCurrent            = "cannot get current Kubernetes resource"
+	errDiff                  = "cannot diff Kubernetes resource"
 	errDeleteResource        = "cannot delete Kubernetes resource"
 	errUnmarshalUnstructured = "cannot unmarshal input unstructured JSON"
 
@@ -42,6 +54,10 @@ const (
 	errFmtPatch          = "cannot apply patch at index %d"
 )
 
+// defaultFieldManager is the field manager used to apply resources when the
+// caller doesn't specify one.
+const defaultFieldManager = "xgql"
+
 // IsRetriable indicates that an error may succeed if retried.
 func IsRetriable(err error) bool { //nolint:gocyclo // It's just a big old switch.
 	switch {
@@ -67,7 +83,7 @@ type mutation struct {
 	clients ClientCache
 }
 
-func (r *mutation) CreateKubernetesResource(ctx context.Context, input model.CreateKubernetesResourceInput) (model.CreateKubernetesResourcePayload, error) {
+func (r *mutation) CreateKubernetesResource(ctx context.Context, input model.CreateKubernetesResourceInput, dryRun *bool) (model.CreateKubernetesResourcePayload, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -97,7 +113,12 @@ func (r *mutation) CreateKubernetesResource(ctx context.Context, input model.Cre
 		}
 	}
 
-	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Create(ctx, u) }); err != nil {
+	opts := []client.CreateOption{}
+	if ptr.Deref(dryRun, false) {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Create(ctx, u, opts...) }); err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errCreateResource))
 		return model.CreateKubernetesResourcePayload{}, nil
 	}
@@ -107,10 +128,18 @@ func (r *mutation) CreateKubernetesResource(ctx context.Context, input model.Cre
 		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
 		return model.CreateKubernetesResourcePayload{}, nil
 	}
-	return model.CreateKubernetesResourcePayload{Resource: kr}, nil
+	out := model.CreateKubernetesResourcePayload{Resource: kr}
+	if ptr.Deref(dryRun, false) {
+		// There is no current resource; it would be created.
+		if out.Diff, err = getDiff(nil, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errDiff))
+			return model.CreateKubernetesResourcePayload{}, nil
+		}
+	}
+	return out, nil
 }
 
-func (r *mutation) UpdateKubernetesResource(ctx context.Context, id model.ReferenceID, input model.UpdateKubernetesResourceInput) (model.UpdateKubernetesResourcePayload, error) {
+func (r *mutation) UpdateKubernetesResource(ctx context.Context, id model.ReferenceID, input model.UpdateKubernetesResourceInput, dryRun *bool) (model.UpdateKubernetesResourcePayload, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -148,8 +177,24 @@ func (r *mutation) UpdateKubernetesResource(ctx context.Context, id model.Refere
 	u.SetNamespace(id.Namespace)
 	u.SetName(id.Name)
 
-	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Update(ctx, u) }); err != nil {
-		graphql.AddError(ctx, errors.Wrap(err, errUpdateResource))
+	// The API server rejects an update with a conflict if the resource
+	// version doesn't match the current resource.
+	if input.ExpectedResourceVersion != nil {
+		u.SetResourceVersion(*input.ExpectedResourceVersion)
+	}
+
+	var current *unstructured.Unstructured
+	opts := []client.UpdateOption{}
+	if ptr.Deref(dryRun, false) {
+		if current, err = r.getLive(ctx, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCurrent))
+			return model.UpdateKubernetesResourcePayload{}, nil
+		}
+		opts = append(opts, client.DryRunAll)
+	}
+
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Update(ctx, u, opts...) }); err != nil {
+		graphql.AddError(ctx, r.withCurrent(ctx, u, errors.Wrap(err, errUpdateResource)))
 		return model.UpdateKubernetesResourcePayload{}, nil
 	}
 
@@ -158,10 +203,178 @@ func (r *mutation) UpdateKubernetesResource(ctx context.Context, id model.Refere
 		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
 		return model.UpdateKubernetesResourcePayload{}, nil
 	}
-	return model.UpdateKubernetesResourcePayload{Resource: kr}, nil
+	out := model.UpdateKubernetesResourcePayload{Resource: kr}
+	if ptr.Deref(dryRun, false) {
+		if out.Diff, err = getDiff(current, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errDiff))
+			return model.UpdateKubernetesResourcePayload{}, nil
+		}
+	}
+	return out, nil
+}
+
+func (r *mutation) PatchKubernetesResource(ctx context.Context, id model.ReferenceID, typeArg model.PatchType, patch []byte, resourceVersion *string, dryRun *bool) (model.PatchKubernetesResourcePayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.PatchKubernetesResourcePayload{}, nil
+	}
+
+	pt := getPatchType(typeArg)
+	if resourceVersion != nil {
+		patch, err = withResourceVersion(pt, patch, *resourceVersion)
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errUnmarshalPatch))
+			return model.PatchKubernetesResourcePayload{}, nil
+		}
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+
+	var current *unstructured.Unstructured
+	opts := []client.PatchOption{}
+	if ptr.Deref(dryRun, false) {
+		if current, err = r.getLive(ctx, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCurrent))
+			return model.PatchKubernetesResourcePayload{}, nil
+		}
+		opts = append(opts, client.DryRunAll)
+	}
+
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.RawPatch(pt, patch), opts...) }); err != nil {
+		graphql.AddError(ctx, r.withCurrent(ctx, u, errors.Wrap(err, errPatchResource)))
+		return model.PatchKubernetesResourcePayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.PatchKubernetesResourcePayload{}, nil
+	}
+	out := model.PatchKubernetesResourcePayload{Resource: kr}
+	if ptr.Deref(dryRun, false) {
+		if out.Diff, err = getDiff(current, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errDiff))
+			return model.PatchKubernetesResourcePayload{}, nil
+		}
+	}
+	return out, nil
 }
 
-func (r *mutation) DeleteKubernetesResource(ctx context.Context, id model.ReferenceID) (model.DeleteKubernetesResourcePayload, error) {
+func getPatchType(pt model.PatchType) types.PatchType {
+	switch pt {
+	case model.PatchTypeJSONPatch:
+		return types.JSONPatchType
+	case model.PatchTypeStrategicMerge:
+		return types.StrategicMergePatchType
+	default:
+		return types.MergePatchType
+	}
+}
+
+// withResourceVersion adds the supplied resource version to the supplied
+// patch. The API server rejects a patch that changes a resource's version
+// with a conflict, so this makes the version a precondition of the patch.
+func withResourceVersion(pt types.PatchType, patch []byte, rv string) ([]byte, error) {
+	if pt == types.JSONPatchType {
+		ops := []interface{}{}
+		if err := json.Unmarshal(patch, &ops); err != nil {
+			return nil, err
+		}
+		ops = append(ops, map[string]interface{}{"op": "replace", "path": "/metadata/resourceVersion", "value": rv})
+		return json.Marshal(ops)
+	}
+
+	obj := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &obj); err != nil {
+		return nil, err
+	}
+	if err := fieldpath.Pave(obj).SetValue("metadata.resourceVersion", rv); err != nil {
+		return nil, err
+	}
+	return json.Marshal(obj)
+}
+
+func (r *mutation) ApplyKubernetesResource(ctx context.Context, input model.ApplyKubernetesResourceInput, fieldManager *string, force *bool, dryRun *bool) (model.ApplyKubernetesResourcePayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ApplyKubernetesResourcePayload{}, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	if err := json.Unmarshal(input.Unstructured, u); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUnmarshalUnstructured))
+		return model.ApplyKubernetesResourcePayload{}, nil
+	}
+
+	pv := fieldpath.Pave(u.Object)
+	for i, p := range input.Patches {
+		var v interface{}
+		if err := json.Unmarshal(p.Unstructured, &v); err != nil {
+			graphql.AddError(ctx, errors.Wrapf(err, errFmtUnmarshalPatch, i))
+			return model.ApplyKubernetesResourcePayload{}, nil
+		}
+		if err := pv.SetValue(p.FieldPath, v); err != nil {
+			graphql.AddError(ctx, errors.Wrapf(err, errFmtPatch, i))
+			return model.ApplyKubernetesResourcePayload{}, nil
+		}
+	}
+
+	// Server-side apply rejects objects with managed fields. Callers that read,
+	// modify, then apply will likely send them.
+	u.SetManagedFields(nil)
+
+	opts := []client.PatchOption{client.FieldOwner(defaultFieldManager)}
+	if fieldManager != nil && *fieldManager != "" {
+		opts[0] = client.FieldOwner(*fieldManager)
+	}
+	if force != nil && *force {
+		opts = append(opts, client.ForceOwnership)
+	}
+
+	var current *unstructured.Unstructured
+	if ptr.Deref(dryRun, false) {
+		if current, err = r.getLive(ctx, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCurrent))
+			return model.ApplyKubernetesResourcePayload{}, nil
+		}
+		opts = append(opts, client.DryRunAll)
+	}
+
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Patch(ctx, u, client.Apply, opts...) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errApplyResource))
+		return model.ApplyKubernetesResourcePayload{}, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
+		return model.ApplyKubernetesResourcePayload{}, nil
+	}
+	out := model.ApplyKubernetesResourcePayload{Resource: kr}
+	if ptr.Deref(dryRun, false) {
+		if out.Diff, err = getDiff(current, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errDiff))
+			return model.ApplyKubernetesResourcePayload{}, nil
+		}
+	}
+	return out, nil
+}
+
+func (r *mutation) DeleteKubernetesResource(ctx context.Context, id model.ReferenceID, dryRun *bool) (model.DeleteKubernetesResourcePayload, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -177,15 +390,126 @@ func (r *mutation) DeleteKubernetesResource(ctx context.Context, id model.Refere
 	u.SetKind(id.Kind)
 	u.SetNamespace(id.Namespace)
 	u.SetName(id.Name)
-	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Delete(ctx, u) }); resource.IgnoreNotFound(err) != nil {
+
+	var current *unstructured.Unstructured
+	opts := []client.DeleteOption{}
+	if ptr.Deref(dryRun, false) {
+		if current, err = r.getLive(ctx, u); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errGetCurrent))
+			return model.DeleteKubernetesResourcePayload{}, nil
+		}
+		opts = append(opts, client.DryRunAll)
+	}
+
+	if err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Delete(ctx, u, opts...) }); resource.IgnoreNotFound(err) != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errDeleteResource))
 		return model.DeleteKubernetesResourcePayload{}, nil //nolint:nilerr // IgnoreNotFound appears to trigger this linter.
 	}
 
-	kr, err := model.GetKubernetesResource(u)
+	// A dry run returns the resource that would be deleted, if it exists.
+	deleted := u
+	if current != nil {
+		deleted = current
+	}
+
+	kr, err := model.GetKubernetesResource(deleted)
 	if err != nil {
 		graphql.AddError(ctx, errors.Wrap(err, errModelResource))
 		return model.DeleteKubernetesResourcePayload{}, nil
 	}
-	return model.DeleteKubernetesResourcePayload{Resource: kr}, nil
+	out := model.DeleteKubernetesResourcePayload{Resource: kr}
+	if ptr.Deref(dryRun, false) {
+		if out.Diff, err = getDiff(current, nil); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errDiff))
+			return model.DeleteKubernetesResourcePayload{}, nil
+		}
+	}
+	return out, nil
+}
+
+// getCurrent returns the current state of the supplied resource, or nil if it
+// doesn't exist.
+func getCurrent(ctx context.Context, c client.Reader, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(u.GroupVersionKind())
+	err := c.Get(ctx, types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}, current)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// getLive returns the current state of the supplied resource, or nil if it
+// doesn't exist. Unlike getCurrent it reads the resource from the API server,
+// not the cache, which may not yet reflect recent writes.
+func (r *mutation) getLive(ctx context.Context, u *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds, clients.Uncached())
+	if err != nil {
+		return nil, errors.Wrap(err, errGetClient)
+	}
+	return getCurrent(ctx, c, u)
+}
+
+// withCurrent includes the current state of the supplied resource in the
+// supplied error if it's a conflict, so that the caller can rebase on it. The
+// cache has likely not yet seen the write that caused the conflict, so we read
+// the current state from the API server. The error is returned unchanged if
+// the current state can't be determined.
+func (r *mutation) withCurrent(ctx context.Context, u *unstructured.Unstructured, err error) error {
+	if !kerrors.IsConflict(err) {
+		return err
+	}
+	current, gerr := r.getLive(ctx, u)
+	if gerr != nil || current == nil {
+		return err
+	}
+	return &present.ConflictError{Err: err, Current: current.Object}
+}
+
+// getDiff returns the RFC 6902 JSON patch operations that would transform the
+// current resource into the desired resource. Either may be nil, indicating
+// that the resource doesn't exist.
+func getDiff(current, desired *unstructured.Unstructured) ([]model.JSONPatchOperation, error) {
+	x, err := getJSONNode(current)
+	if err != nil {
+		return nil, err
+	}
+	y, err := getJSONNode(desired)
+	if err != nil {
+		return nil, err
+	}
+	ops, err := live_query.CreateJSONPatch(x, y)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.JSONPatchOperation, len(ops))
+	for i, op := range ops {
+		out[i] = model.JSONPatchOperation{Op: string(op.Op), Path: op.Path}
+		if op.From != "" {
+			out[i].From = ptr.To(op.From)
+		}
+		if op.Value != nil {
+			if out[i].Value, err = json.Marshal(op.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return out, nil
+}
+
+func getJSONNode(u *unstructured.Unstructured) (jd.JsonNode, error) {
+	if u == nil {
+		// An empty string is read as a void node - i.e. no value at all.
+		return jd.ReadJsonString("")
+	}
+	j, err := json.Marshal(u.Object)
+	if err != nil {
+		return nil, err
+	}
+	return jd.ReadJsonString(string(j))
 }
@@ -25,8 +25,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
@@ -37,6 +40,7 @@ import (
 	"github.com/upbound/xgql/internal/clients"
 	"github.com/upbound/xgql/internal/graph/generated"
 	"github.com/upbound/xgql/internal/graph/model"
+	"github.com/upbound/xgql/internal/graph/present"
 )
 
 var _ generated.MutationResolver = &mutation{}
@@ -104,8 +108,9 @@ func TestCreateKubernetesResource(t *testing.T) {
 	kr, _ := model.GetKubernetesResource(u)
 
 	type args struct {
-		ctx   context.Context
-		input model.CreateKubernetesResourceInput
+		ctx    context.Context
+		input  model.CreateKubernetesResourceInput
+		dryRun *bool
 	}
 	type want struct {
 		payload model.CreateKubernetesResourcePayload
@@ -229,6 +234,36 @@ func TestCreateKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"DryRun": {
+			reason: "If we successfully dry run the creation of a Kubernetes resource we should return it, and a diff that adds it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockCreate: func(_ context.Context, _ client.Object, opts ...client.CreateOption) error {
+						co := &client.CreateOptions{}
+						co.ApplyOptions(opts)
+						if diff := cmp.Diff([]string{metav1.DryRunAll}, co.DryRun); diff != "" {
+							t.Errorf("Create(...): -want dry run, +got dry run:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.CreateKubernetesResourceInput{
+					Unstructured: uj,
+				},
+				dryRun: ptr.To(true),
+			},
+			want: want{
+				payload: model.CreateKubernetesResourcePayload{
+					Resource: kr,
+					Diff: []model.JSONPatchOperation{
+						{Op: "add", Path: "", Value: []byte(`{"apiVersion":"example.org/v1","kind":"Example","metadata":{"name":"example"}}`)},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -237,7 +272,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := m.CreateKubernetesResource(tc.args.ctx, tc.args.input)
+			got, err := m.CreateKubernetesResource(tc.args.ctx, tc.args.input, tc.args.dryRun)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -255,6 +290,7 @@ func TestCreateKubernetesResource(t *testing.T) {
 
 func TestUpdateKubernetesResource(t *testing.T) {
 	errBoom := errors.New("boom")
+	errConflict := kerrors.NewConflict(schema.GroupResource{}, "example", errBoom)
 	errFieldPath := fieldpath.Pave(map[string]interface{}{}).SetValue("..", nil)
 	errUnmarshal := json.Unmarshal([]byte("\""), nil) //nolint:govet
 
@@ -271,9 +307,10 @@ func TestUpdateKubernetesResource(t *testing.T) {
 	kr, _ := model.GetKubernetesResource(u)
 
 	type args struct {
-		ctx   context.Context
-		id    model.ReferenceID
-		input model.UpdateKubernetesResourceInput
+		ctx    context.Context
+		id     model.ReferenceID
+		input  model.UpdateKubernetesResourceInput
+		dryRun *bool
 	}
 	type want struct {
 		payload model.UpdateKubernetesResourcePayload
@@ -403,6 +440,138 @@ func TestUpdateKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"ExpectedResourceVersionConflict": {
+			reason: "If the resource doesn't have the expected resource version we should add the conflict to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+					MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+						if diff := cmp.Diff("42", obj.GetResourceVersion()); diff != "" {
+							t.Errorf("Update(...): -want resource version, +got resource version:\n%s", diff)
+						}
+						return errConflict
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.UpdateKubernetesResourceInput{
+					Unstructured:            uj,
+					ExpectedResourceVersion: ptr.To("42"),
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errConflict, errUpdateResource)),
+				},
+			},
+		},
+		"GetCurrentError": {
+			reason: "If we can't get the current Kubernetes resource to diff against we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.UpdateKubernetesResourceInput{
+					Unstructured: uj,
+				},
+				dryRun: ptr.To(true),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetCurrent)),
+				},
+			},
+		},
+		"DryRun": {
+			reason: "If we successfully dry run the update of a Kubernetes resource we should return it, and a diff against the current resource.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetName("example")
+						obj.SetLabels(map[string]string{"cool": "true"})
+						return nil
+					}),
+					MockUpdate: func(_ context.Context, _ client.Object, opts ...client.UpdateOption) error {
+						uo := &client.UpdateOptions{}
+						uo.ApplyOptions(opts)
+						if diff := cmp.Diff([]string{metav1.DryRunAll}, uo.DryRun); diff != "" {
+							t.Errorf("Update(...): -want dry run, +got dry run:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id: model.ReferenceID{
+					APIVersion: u.GetAPIVersion(),
+					Kind:       u.GetKind(),
+					Namespace:  u.GetNamespace(),
+					Name:       u.GetName(),
+				},
+				input: model.UpdateKubernetesResourceInput{
+					Unstructured: uj,
+				},
+				dryRun: ptr.To(true),
+			},
+			want: want{
+				payload: model.UpdateKubernetesResourcePayload{
+					Resource: kr,
+					Diff: []model.JSONPatchOperation{
+						{Op: "test", Path: "/metadata/labels", Value: []byte(`{"cool":"true"}`)},
+						{Op: "remove", Path: "/metadata/labels", Value: []byte(`{"cool":"true"}`)},
+					},
+				},
+			},
+		},
+		"DryRunStaleCache": {
+			reason: "If we dry run an update we should diff against the resource read from the API server, not the cache, which may be stale.",
+			clients: ClientCacheFn(func(_ auth.Credentials, o ...clients.GetOption) (client.Client, error) {
+				// The only option we pass requests an uncached client.
+				if len(o) > 0 {
+					return &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							obj.SetName("example")
+							obj.SetLabels(map[string]string{"cool": "true"})
+							return nil
+						}),
+					}, nil
+				}
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						obj.SetName("example")
+						return nil
+					}),
+					MockUpdate: test.NewMockUpdateFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id: model.ReferenceID{
+					APIVersion: u.GetAPIVersion(),
+					Kind:       u.GetKind(),
+					Namespace:  u.GetNamespace(),
+					Name:       u.GetName(),
+				},
+				input: model.UpdateKubernetesResourceInput{
+					Unstructured: uj,
+				},
+				dryRun: ptr.To(true),
+			},
+			want: want{
+				payload: model.UpdateKubernetesResourcePayload{
+					Resource: kr,
+					Diff: []model.JSONPatchOperation{
+						{Op: "test", Path: "/metadata/labels", Value: []byte(`{"cool":"true"}`)},
+						{Op: "remove", Path: "/metadata/labels", Value: []byte(`{"cool":"true"}`)},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -411,7 +580,7 @@ func TestUpdateKubernetesResource(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := m.UpdateKubernetesResource(tc.args.ctx, tc.args.id, tc.args.input)
+			got, err := m.UpdateKubernetesResource(tc.args.ctx, tc.args.id, tc.args.input, tc.args.dryRun)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -427,12 +596,396 @@ func TestUpdateKubernetesResource(t *testing.T) {
 	}
 }
 
+func TestPatchKubernetesResource(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	var ops []interface{}
+	errUnmarshal := json.Unmarshal([]byte("{}"), &ops)
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Example")
+	u.SetName("example")
+
+	kr, _ := model.GetKubernetesResource(u)
+
+	id := model.ReferenceID{
+		APIVersion: u.GetAPIVersion(),
+		Kind:       u.GetKind(),
+		Name:       u.GetName(),
+	}
+
+	// patch returns a MockPatchFn that expects the supplied patch.
+	patch := func(pt types.PatchType, data string) test.MockPatchFn {
+		return func(_ context.Context, obj client.Object, p client.Patch, _ ...client.PatchOption) error {
+			if diff := cmp.Diff(pt, p.Type()); diff != "" {
+				t.Errorf("Patch(...): -want type, +got type:\n%s", diff)
+			}
+			got, _ := p.Data(obj)
+			if diff := cmp.Diff(data, string(got)); diff != "" {
+				t.Errorf("Patch(...): -want data, +got data:\n%s", diff)
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx             context.Context
+		pt              model.PatchType
+		patch           []byte
+		resourceVersion *string
+		dryRun          *bool
+	}
+	type want struct {
+		payload model.PatchKubernetesResourcePayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"UnmarshalPatchError": {
+			reason: "If we can't unmarshal the patch to add a resource version precondition we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			args: args{
+				ctx:             graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				pt:              model.PatchTypeJSONPatch,
+				patch:           []byte("{}"),
+				resourceVersion: ptr.To("42"),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errUnmarshal, errUnmarshalPatch)),
+				},
+			},
+		},
+		"PatchError": {
+			reason: "If we can't patch a Kubernetes resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet:   test.NewMockGetFn(nil),
+					MockPatch: test.NewMockPatchFn(kerrors.NewConflict(schema.GroupResource{}, "example", errBoom)),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				pt:    model.PatchTypeMergePatch,
+				patch: []byte(`{}`),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(kerrors.NewConflict(schema.GroupResource{}, "example", errBoom), errPatchResource)),
+				},
+			},
+		},
+		"StrategicMergePatch": {
+			reason: "If we successfully patch a Kubernetes resource we should model and return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patch(types.StrategicMergePatchType, `{"metadata":{"labels":{"cool":"true"}}}`),
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				pt:    model.PatchTypeStrategicMerge,
+				patch: []byte(`{"metadata":{"labels":{"cool":"true"}}}`),
+			},
+			want: want{
+				payload: model.PatchKubernetesResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+		"MergePatchWithResourceVersion": {
+			reason: "If a resource version is supplied we should add it to a merge patch.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patch(types.MergePatchType, `{"metadata":{"labels":{"cool":"true"},"resourceVersion":"42"}}`),
+				}, nil
+			}),
+			args: args{
+				ctx:             graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				pt:              model.PatchTypeMergePatch,
+				patch:           []byte(`{"metadata":{"labels":{"cool":"true"}}}`),
+				resourceVersion: ptr.To("42"),
+			},
+			want: want{
+				payload: model.PatchKubernetesResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+		"JSONPatchWithResourceVersion": {
+			reason: "If a resource version is supplied we should add an operation that sets it to a JSON patch.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patch(types.JSONPatchType, `[{"op":"remove","path":"/metadata/labels/cool"},{"op":"replace","path":"/metadata/resourceVersion","value":"42"}]`),
+				}, nil
+			}),
+			args: args{
+				ctx:             graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				pt:              model.PatchTypeJSONPatch,
+				patch:           []byte(`[{"op":"remove","path":"/metadata/labels/cool"}]`),
+				resourceVersion: ptr.To("42"),
+			},
+			want: want{
+				payload: model.PatchKubernetesResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.PatchKubernetesResource(tc.args.ctx, id, tc.args.pt, tc.args.patch, tc.args.resourceVersion, tc.args.dryRun)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.PatchKubernetesResource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.PatchKubernetesResource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.PatchKubernetesResource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestApplyKubernetesResource(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// Unmarshalling to an *interface{} results in a slightly different error.
+	var v interface{}
+	errUnmarshalPatch := json.Unmarshal([]byte("\""), &v)
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Example")
+	u.SetName("example")
+	uj, _ := json.Marshal(u)
+
+	kr, _ := model.GetKubernetesResource(u)
+
+	// patch returns a MockPatchFn that expects to apply using the supplied
+	// field manager and force option.
+	patch := func(fieldManager string, force bool) test.MockPatchFn {
+		return func(_ context.Context, _ client.Object, p client.Patch, opts ...client.PatchOption) error {
+			if p != client.Apply {
+				t.Errorf("Patch(...): want server-side apply, got %s", p.Type())
+			}
+			po := &client.PatchOptions{}
+			po.ApplyOptions(opts)
+			if diff := cmp.Diff(fieldManager, po.FieldManager); diff != "" {
+				t.Errorf("Patch(...): -want field manager, +got field manager:\n%s", diff)
+			}
+			if diff := cmp.Diff(force, ptr.Deref(po.Force, false)); diff != "" {
+				t.Errorf("Patch(...): -want force, +got force:\n%s", diff)
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx          context.Context
+		input        model.ApplyKubernetesResourceInput
+		fieldManager *string
+		force        *bool
+		dryRun       *bool
+	}
+	type want struct {
+		payload model.ApplyKubernetesResourcePayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"UnmarshalPatchError": {
+			reason: "If we can't get unmarshal an unstructured patch we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.ApplyKubernetesResourceInput{
+					Unstructured: uj,
+					Patches: []model.Patch{{
+						Unstructured: []byte("\""),
+					}},
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrapf(errUnmarshalPatch, errFmtUnmarshalPatch, 0)),
+				},
+			},
+		},
+		"ApplyError": {
+			reason: "If we can't apply a Kubernetes resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: test.NewMockPatchFn(kerrors.NewApplyConflict(nil, "boom")),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.ApplyKubernetesResourceInput{
+					Unstructured: uj,
+				},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(kerrors.NewApplyConflict(nil, "boom"), errApplyResource)),
+				},
+			},
+		},
+		"DefaultFieldManager": {
+			reason: "If no field manager is supplied we should apply using the default field manager, without forcing.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patch(defaultFieldManager, false),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.ApplyKubernetesResourceInput{
+					Unstructured: uj,
+				},
+			},
+			want: want{
+				payload: model.ApplyKubernetesResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+		"ForceApply": {
+			reason: "If we successfully force apply a Kubernetes resource using the supplied field manager we should model and return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockPatch: patch("cool-manager", true),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.ApplyKubernetesResourceInput{
+					Unstructured: uj,
+				},
+				fieldManager: ptr.To("cool-manager"),
+				force:        ptr.To(true),
+			},
+			want: want{
+				payload: model.ApplyKubernetesResourcePayload{
+					Resource: kr,
+				},
+			},
+		},
+		"DryRun": {
+			reason: "If we successfully dry run an apply that would create a Kubernetes resource we should return it, and a diff that adds it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "example")),
+					MockPatch: func(_ context.Context, _ client.Object, _ client.Patch, opts ...client.PatchOption) error {
+						po := &client.PatchOptions{}
+						po.ApplyOptions(opts)
+						if diff := cmp.Diff([]string{metav1.DryRunAll}, po.DryRun); diff != "" {
+							t.Errorf("Patch(...): -want dry run, +got dry run:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				input: model.ApplyKubernetesResourceInput{
+					Unstructured: uj,
+				},
+				dryRun: ptr.To(true),
+			},
+			want: want{
+				payload: model.ApplyKubernetesResourcePayload{
+					Resource: kr,
+					Diff: []model.JSONPatchOperation{
+						{Op: "add", Path: "", Value: []byte(`{"apiVersion":"example.org/v1","kind":"Example","metadata":{"name":"example"}}`)},
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.ApplyKubernetesResource(tc.args.ctx, tc.args.input, tc.args.fieldManager, tc.args.force, tc.args.dryRun)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ApplyKubernetesResource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ApplyKubernetesResource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.ApplyKubernetesResource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestDeleteKubernetesResource(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type args struct {
-		ctx context.Context
-		id  model.ReferenceID
+		ctx    context.Context
+		id     model.ReferenceID
+		dryRun *bool
 	}
 	type want struct {
 		payload model.DeleteKubernetesResourcePayload
@@ -505,6 +1058,43 @@ func TestDeleteKubernetesResource(t *testing.T) {
 				},
 			},
 		},
+		"DryRun": {
+			reason: "If we successfully dry run the deletion of a Kubernetes resource we should return the current resource, and a diff that removes it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						*obj.(*unstructured.Unstructured) = *u.DeepCopy()
+						return nil
+					}),
+					MockDelete: func(_ context.Context, _ client.Object, opts ...client.DeleteOption) error {
+						do := &client.DeleteOptions{}
+						do.ApplyOptions(opts)
+						if diff := cmp.Diff([]string{metav1.DryRunAll}, do.DryRun); diff != "" {
+							t.Errorf("Delete(...): -want dry run, +got dry run:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id: model.ReferenceID{
+					APIVersion: u.GetAPIVersion(),
+					Kind:       u.GetKind(),
+					Name:       u.GetName(),
+				},
+				dryRun: ptr.To(true),
+			},
+			want: want{
+				payload: model.DeleteKubernetesResourcePayload{
+					Resource: kr,
+					Diff: []model.JSONPatchOperation{
+						{Op: "test", Path: "", Value: []byte(`{"apiVersion":"example.org/v1","kind":"Example","metadata":{"name":"example"}}`)},
+						{Op: "remove", Path: "", Value: []byte(`{"apiVersion":"example.org/v1","kind":"Example","metadata":{"name":"example"}}`)},
+					},
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -513,7 +1103,7 @@ func TestDeleteKubernetesResource(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := m.DeleteKubernetesResource(tc.args.ctx, tc.args.id)
+			got, err := m.DeleteKubernetesResource(tc.args.ctx, tc.args.id, tc.args.dryRun)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -528,3 +1118,103 @@ func TestDeleteKubernetesResource(t *testing.T) {
 		})
 	}
 }
+
+func TestWithCurrent(t *testing.T) {
+	errBoom := errors.New("boom")
+	errConflict := kerrors.NewConflict(schema.GroupResource{}, "example", errBoom)
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Example")
+	u.SetName("example")
+
+	current := u.DeepCopy()
+	current.SetResourceVersion("43")
+
+	stale := u.DeepCopy()
+	stale.SetResourceVersion("42")
+
+	get := func(u *unstructured.Unstructured) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(obj client.Object) error {
+			*obj.(*unstructured.Unstructured) = *u.DeepCopy()
+			return nil
+		})
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		err     error
+		want    error
+	}{
+		"NotAConflict": {
+			reason: "Errors that aren't conflicts should be returned unchanged.",
+			err:    errBoom,
+			want:   errBoom,
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should return the conflict unchanged.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			err:  errConflict,
+			want: errConflict,
+		},
+		"GetCurrentError": {
+			reason: "If we can't get the current resource we should return the conflict unchanged.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			err:  errConflict,
+			want: errConflict,
+		},
+		"NotFound": {
+			reason: "If the current resource doesn't exist we should return the conflict unchanged.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "example"))}, nil
+			}),
+			err:  errConflict,
+			want: errConflict,
+		},
+		"Conflict": {
+			reason: "Conflicts should include the current resource.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get(current)}, nil
+			}),
+			err:  errConflict,
+			want: &present.ConflictError{Err: errConflict, Current: current.Object},
+		},
+		"StaleCache": {
+			reason: "Conflicts should include the current resource read from the API server, not the cache, which may be stale.",
+			clients: ClientCacheFn(func(_ auth.Credentials, o ...clients.GetOption) (client.Client, error) {
+				// The only option we pass requests an uncached client.
+				if len(o) > 0 {
+					return &test.MockClient{MockGet: get(current)}, nil
+				}
+				return &test.MockClient{MockGet: get(stale)}, nil
+			}),
+			err:  errConflict,
+			want: &present.ConflictError{Err: errConflict, Current: current.Object},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+			got := m.withCurrent(context.Background(), u, tc.err)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.withCurrent(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			want, wok := tc.want.(*present.ConflictError)
+			ce, ok := got.(*present.ConflictError)
+			if wok != ok {
+				t.Fatalf("\n%s\nm.withCurrent(...): want conflict error %t, got %t\n", tc.reason, wok, ok)
+			}
+			if ok {
+				if diff := cmp.Diff(want.Current, ce.Current); diff != "" {
+					t.Errorf("\n%s\nm.withCurrent(...): -want current, +got current:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
@@ -16,29 +16,36 @@ package resolvers
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"github.com/99designs/gqlgen/graphql"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
+	"github.com/upbound/xgql/internal/unstructured"
 )
 
 const (
-	errGetOwner   = "cannot get owner"
-	errModelOwner = "cannot model owner"
+	errGetOwner      = "cannot get owner"
+	errModelOwner    = "cannot model owner"
+	errGetAncestor   = "cannot get ancestor"
+	errModelAncestor = "cannot model ancestor"
 )
 
 type objectMeta struct {
 	clients ClientCache
 }
 
-func (r *objectMeta) Owners(ctx context.Context, obj *model.ObjectMeta) (model.OwnerConnection, error) {
+func (r *objectMeta) Owners(ctx context.Context, obj *model.ObjectMeta, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.OwnerConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -60,12 +67,8 @@ func (r *objectMeta) Owners(ctx context.Context, obj *model.ObjectMeta) (model.O
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			u := &kunstructured.Unstructured{}
-			u.SetAPIVersion(ref.APIVersion)
-			u.SetKind(ref.Kind)
-
-			nn := types.NamespacedName{Namespace: ptr.Deref(obj.Namespace, ""), Name: ref.Name}
-			if err := c.Get(ctx, nn, u); err != nil {
+			u, err := getOwner(ctx, c, ptr.Deref(obj.Namespace, ""), ref)
+			if err != nil {
 				graphql.AddError(ctx, errors.Wrap(err, errGetOwner))
 				return
 			}
@@ -83,7 +86,17 @@ func (r *objectMeta) Owners(ctx context.Context, obj *model.ObjectMeta) (model.O
 	}
 	wg.Wait()
 
-	return model.OwnerConnection{Nodes: owners, TotalCount: len(owners)}, nil
+	out := &model.OwnerConnection{Nodes: owners, TotalCount: len(owners)}
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.OwnerConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.OwnerConnection{}, nil
+	}
+	return *out, nil
 }
 
 func (r *objectMeta) Controller(ctx context.Context, obj *model.ObjectMeta) (model.KubernetesResource, error) {
@@ -102,12 +115,8 @@ func (r *objectMeta) Controller(ctx context.Context, obj *model.ObjectMeta) (mod
 			continue
 		}
 
-		u := &kunstructured.Unstructured{}
-		u.SetAPIVersion(ref.APIVersion)
-		u.SetKind(ref.Kind)
-
-		nn := types.NamespacedName{Namespace: ptr.Deref(obj.Namespace, ""), Name: ref.Name}
-		if err := c.Get(ctx, nn, u); err != nil {
+		u, err := getOwner(ctx, c, ptr.Deref(obj.Namespace, ""), ref)
+		if err != nil {
 			graphql.AddError(ctx, errors.Wrap(err, errGetOwner))
 			return nil, nil
 		}
@@ -122,3 +131,112 @@ func (r *objectMeta) Controller(ctx context.Context, obj *model.ObjectMeta) (mod
 
 	return nil, nil
 }
+
+// getOwner gets the owner referenced by the supplied owner reference. Owners
+// must be cluster scoped or in the same namespace as the objects they own.
+func getOwner(ctx context.Context, c client.Client, namespace string, ref metav1.OwnerReference) (*kunstructured.Unstructured, error) {
+	u := &kunstructured.Unstructured{}
+	u.SetAPIVersion(ref.APIVersion)
+	u.SetKind(ref.Kind)
+
+	nn := types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	if err := c.Get(ctx, nn, u); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// getParent gets the parent of the supplied object. The parent of a composite
+// resource is the claim that claims it, if any. The parent of any other object
+// is its controller. getParent returns nil if the object has no parent, or if
+// its parent no longer exists.
+func getParent(ctx context.Context, c client.Client, u *kunstructured.Unstructured) (*kunstructured.Unstructured, error) {
+	if unstructured.ProbablyComposite(u) {
+		xr := &unstructured.Composite{Unstructured: *u}
+		if ref := xr.GetClaimReference(); ref != nil && ref.Name != "" {
+			p := &kunstructured.Unstructured{}
+			p.SetAPIVersion(ref.APIVersion)
+			p.SetKind(ref.Kind)
+			err := c.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, p)
+			if kerrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return p, err
+		}
+	}
+
+	ref := metav1.GetControllerOfNoCopy(u)
+	if ref == nil {
+		return nil, nil
+	}
+	p, err := getOwner(ctx, c, u.GetNamespace(), *ref)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return p, err
+}
+
+// getAncestors returns the ancestors of the supplied object, ordered from its
+// parent to its root.
+func getAncestors(ctx context.Context, c client.Client, u *kunstructured.Unstructured) ([]model.KubernetesResource, error) {
+	out := make([]model.KubernetesResource, 0)
+
+	// Guard against cycles, which should never happen but would otherwise
+	// cause us to walk forever.
+	id := func(u *kunstructured.Unstructured) model.ReferenceID {
+		return model.ReferenceID{APIVersion: u.GetAPIVersion(), Kind: u.GetKind(), Namespace: u.GetNamespace(), Name: u.GetName()}
+	}
+	seen := map[model.ReferenceID]bool{id(u): true}
+	for {
+		p, err := getParent(ctx, c, u)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetAncestor)
+		}
+		if p == nil || seen[id(p)] {
+			return out, nil
+		}
+		seen[id(p)] = true
+
+		kr, err := model.GetKubernetesResource(p)
+		if err != nil {
+			return nil, errors.Wrap(err, errModelAncestor)
+		}
+		out = append(out, kr)
+		u = p
+	}
+}
+
+type ancestors struct {
+	clients ClientCache
+}
+
+func (r *ancestors) Resolve(ctx context.Context, u *kunstructured.Unstructured, o []model.OrderBy, pa model.PageArgs) (model.KubernetesResourceConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	in, err := getAncestors(ctx, c, u)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	// Ancestors are ordered from parent to root, so we don't sort them unless
+	// we're asked to.
+	out := &model.KubernetesResourceConnection{Nodes: in, TotalCount: len(in)}
+	if err := out.Order(o); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(pa); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	return *out, nil
+}
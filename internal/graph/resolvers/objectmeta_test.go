@@ -22,12 +22,15 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/vektah/gqlparser/v2/gqlerror"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/upbound/xgql/internal/auth"
@@ -130,7 +133,7 @@ func TestObjectMetaOwners(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := m.Owners(tc.args.ctx, tc.args.obj)
+			got, err := m.Owners(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -140,6 +143,7 @@ func TestObjectMetaOwners(t *testing.T) {
 				t.Errorf("\n%s\nq.Owners(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.oc, got,
+				cmpopts.IgnoreFields(model.OwnerConnection{}, "Edges", "PageInfo"),
 				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
 				cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"),
 			); diff != "" {
@@ -293,3 +297,217 @@ func TestObjectMetaController(t *testing.T) {
 		})
 	}
 }
+
+func TestAncestorsResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	controlledBy := func(u *unstructured.Unstructured, owner *unstructured.Unstructured) {
+		u.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: owner.GetAPIVersion(),
+			Kind:       owner.GetKind(),
+			Name:       owner.GetName(),
+			Controller: ptr.To(true),
+		}})
+	}
+
+	// A claim, which is the root of the tree.
+	xrc := unstructured.Unstructured{}
+	xrc.SetAPIVersion("example.org/v1")
+	xrc.SetKind("Bucket")
+	xrc.SetNamespace("default")
+	xrc.SetName("coolclaim")
+	xrc.SetUID("claim")
+	_ = fieldpath.Pave(xrc.Object).SetValue("spec.compositionRef.name", "cool")
+	gxrc, _ := model.GetKubernetesResource(&xrc)
+
+	// A composite resource, claimed by the claim.
+	xr := unstructured.Unstructured{}
+	xr.SetAPIVersion("example.org/v1")
+	xr.SetKind("XBucket")
+	xr.SetName("coolxr")
+	xr.SetUID("xr")
+	_ = fieldpath.Pave(xr.Object).SetValue("spec.compositionRef.name", "cool")
+	_ = fieldpath.Pave(xr.Object).SetValue("spec.claimRef", map[string]any{
+		"apiVersion": xrc.GetAPIVersion(),
+		"kind":       xrc.GetKind(),
+		"namespace":  xrc.GetNamespace(),
+		"name":       xrc.GetName(),
+	})
+	gxr, _ := model.GetKubernetesResource(&xr)
+
+	// A managed resource, controlled by the composite resource.
+	mr := unstructured.Unstructured{}
+	mr.SetAPIVersion("example.org/v1")
+	mr.SetKind("S3Bucket")
+	mr.SetName("coolmr")
+	mr.SetUID("mr")
+	_ = fieldpath.Pave(mr.Object).SetValue("spec.providerConfigRef.name", "default")
+	controlledBy(&mr, &xr)
+
+	// Two resources that control each other.
+	a := unstructured.Unstructured{}
+	a.SetAPIVersion("example.org/v1")
+	a.SetKind("A")
+	a.SetName("a")
+	a.SetUID("a")
+	b := unstructured.Unstructured{}
+	b.SetAPIVersion("example.org/v1")
+	b.SetKind("B")
+	b.SetName("b")
+	b.SetUID("b")
+	controlledBy(&a, &b)
+	controlledBy(&b, &a)
+	gb, _ := model.GetKubernetesResource(&b)
+
+	get := func(objs ...unstructured.Unstructured) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			u := obj.(*unstructured.Unstructured)
+			for _, o := range objs {
+				if o.GetKind() == u.GetKind() && o.GetNamespace() == key.Namespace && o.GetName() == key.Name {
+					*u = *o.DeepCopy()
+					return nil
+				}
+			}
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+	}
+
+	type args struct {
+		ctx context.Context
+		u   *unstructured.Unstructured
+	}
+	type want struct {
+		krc  model.KubernetesResourceConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				u:   &mr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetAncestorError": {
+			reason: "If we can't get an ancestor we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				u:   &mr,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetAncestor)),
+				},
+			},
+		},
+		"NoAncestors": {
+			reason: "A resource without a controller or claim has no ancestors.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get()}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				u:   &xrc,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{},
+			},
+		},
+		"Ancestors": {
+			reason: "We should follow controller references and claim references up to the root, ordered from parent to root.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get(xr, xrc)}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				u:   &mr,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gxr, gxrc},
+					TotalCount: 2,
+				},
+			},
+		},
+		"MissingAncestor": {
+			reason: "We should stop at an ancestor whose parent no longer exists.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get(xr)}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				u:   &mr,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gxr},
+					TotalCount: 1,
+				},
+			},
+		},
+		"Cycle": {
+			reason: "We should stop rather than walk forever if controller references form a cycle.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get(a, b)}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				u:   &a,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gb},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := &ancestors{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := a.Resolve(tc.args.ctx, tc.args.u, nil, model.PageArgs{})
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\na.Resolve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\na.Resolve(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.krc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.KubernetesResourceConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"),
+				cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess"),
+				cmpopts.IgnoreFields(model.CompositeResourceClaim{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\na.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
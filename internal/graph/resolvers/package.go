@@ -0,0 +1,524 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errInstallPackage   = "cannot install package"
+	errUpdatePackage    = "cannot update package"
+	errUninstallPackage = "cannot uninstall package"
+
+	errEmptyPackage                 = "package must not be empty"
+	errNegativeRevisionHistoryLimit = "revision history limit must not be negative"
+	errFmtInvalidName               = "invalid name %q: %s"
+	errFmtInvalidRuntimeConfigName  = "invalid runtime config name %q: %s"
+)
+
+// A packageSpec is the subset of a provider, configuration, or function spec
+// that may be set by a typed package mutation. Nil fields are left unchanged.
+type packageSpec struct {
+	Package                     string
+	RevisionActivationPolicy    *model.RevisionActivationPolicy
+	RevisionHistoryLimit        *int
+	PackagePullPolicy           *model.PackagePullPolicy
+	IgnoreCrossplaneConstraints *bool
+	SkipDependencyResolution    *bool
+	RuntimeConfigRef            *model.RuntimeConfigReferenceInput
+}
+
+// validate the supplied package name and spec.
+func (s packageSpec) validate(name string) error {
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return errors.Errorf(errFmtInvalidName, name, strings.Join(errs, ", "))
+	}
+	if strings.TrimSpace(s.Package) == "" {
+		return errors.New(errEmptyPackage)
+	}
+	if s.RevisionHistoryLimit != nil && *s.RevisionHistoryLimit < 0 {
+		return errors.New(errNegativeRevisionHistoryLimit)
+	}
+	if s.RuntimeConfigRef != nil {
+		if errs := validation.IsDNS1123Subdomain(s.RuntimeConfigRef.Name); len(errs) > 0 {
+			return errors.Errorf(errFmtInvalidRuntimeConfigName, s.RuntimeConfigRef.Name, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// apply the spec to the supplied package.
+func (s packageSpec) apply(p pkgv1.Package) {
+	p.SetSource(s.Package)
+	if s.RevisionActivationPolicy != nil {
+		p.SetActivationPolicy(getActivationPolicy(*s.RevisionActivationPolicy))
+	}
+	if s.RevisionHistoryLimit != nil {
+		p.SetRevisionHistoryLimit(ptr.To(int64(*s.RevisionHistoryLimit)))
+	}
+	if s.PackagePullPolicy != nil {
+		p.SetPackagePullPolicy(getPullPolicy(*s.PackagePullPolicy))
+	}
+	if s.IgnoreCrossplaneConstraints != nil {
+		p.SetIgnoreCrossplaneConstraints(s.IgnoreCrossplaneConstraints)
+	}
+	if s.SkipDependencyResolution != nil {
+		p.SetSkipDependencyResolution(s.SkipDependencyResolution)
+	}
+	if pr, ok := p.(pkgv1.PackageWithRuntime); ok && s.RuntimeConfigRef != nil {
+		pr.SetRuntimeConfigRef(&pkgv1.RuntimeConfigReference{Name: s.RuntimeConfigRef.Name})
+	}
+}
+
+// getActivationPolicy returns the Crossplane equivalent of the supplied policy.
+func getActivationPolicy(in model.RevisionActivationPolicy) *pkgv1.RevisionActivationPolicy {
+	if in == model.RevisionActivationPolicyManual {
+		return ptr.To(pkgv1.ManualActivation)
+	}
+	return ptr.To(pkgv1.AutomaticActivation)
+}
+
+// getPullPolicy returns the Kubernetes equivalent of the supplied policy.
+func getPullPolicy(in model.PackagePullPolicy) *corev1.PullPolicy {
+	switch in {
+	case model.PackagePullPolicyAlways:
+		return ptr.To(corev1.PullAlways)
+	case model.PackagePullPolicyNever:
+		return ptr.To(corev1.PullNever)
+	default:
+		return ptr.To(corev1.PullIfNotPresent)
+	}
+}
+
+// installPackage creates the supplied package.
+func installPackage(ctx context.Context, c client.Client, p pkgv1.Package) error {
+	// Typed objects may lose their type metadata when they're decoded.
+	gvk := p.GetObjectKind().GroupVersionKind()
+	defer p.GetObjectKind().SetGroupVersionKind(gvk)
+
+	return retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Create(ctx, p) })
+}
+
+// updatePackage reads the named package into the supplied package, mutates it,
+// then updates it, retrying if the package changed while we were updating it.
+func updatePackage(ctx context.Context, c client.Client, p pkgv1.Package, mutate func(p pkgv1.Package)) error {
+	gvk := p.GetObjectKind().GroupVersionKind()
+	defer p.GetObjectKind().SetGroupVersionKind(gvk)
+
+	nn := types.NamespacedName{Name: p.GetName()}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := c.Get(ctx, nn, p); err != nil {
+			return err
+		}
+		mutate(p)
+		return c.Update(ctx, p)
+	})
+}
+
+// uninstallPackage reads the named package into the supplied package, then
+// deletes it. It returns false if the package doesn't exist.
+func uninstallPackage(ctx context.Context, c client.Client, p pkgv1.Package) (bool, error) {
+	gvk := p.GetObjectKind().GroupVersionKind()
+	defer p.GetObjectKind().SetGroupVersionKind(gvk)
+
+	if err := c.Get(ctx, types.NamespacedName{Name: p.GetName()}, p); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	err := retry.OnError(retry.DefaultBackoff, IsRetriable, func() error { return c.Delete(ctx, p) })
+	return true, resource.IgnoreNotFound(err)
+}
+
+func newProvider(name string) *pkgv1.Provider {
+	return &pkgv1.Provider{
+		TypeMeta:   metav1.TypeMeta{APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(), Kind: pkgv1.ProviderKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+func newConfiguration(name string) *pkgv1.Configuration {
+	return &pkgv1.Configuration{
+		TypeMeta:   metav1.TypeMeta{APIVersion: pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(), Kind: pkgv1.ConfigurationKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+func newFunction(name string) *pkgv1.Function {
+	return &pkgv1.Function{
+		TypeMeta:   metav1.TypeMeta{APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(), Kind: pkgv1.FunctionKind},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+func (r *mutation) InstallProvider(ctx context.Context, input model.InstallProviderInput) (model.ProviderPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := packageSpec{
+		Package:                     input.Package,
+		RevisionActivationPolicy:    input.RevisionActivationPolicy,
+		RevisionHistoryLimit:        input.RevisionHistoryLimit,
+		PackagePullPolicy:           input.PackagePullPolicy,
+		IgnoreCrossplaneConstraints: input.IgnoreCrossplaneConstraints,
+		SkipDependencyResolution:    input.SkipDependencyResolution,
+		RuntimeConfigRef:            input.RuntimeConfigRef,
+	}
+	if err := s.validate(input.Name); err != nil {
+		graphql.AddError(ctx, err)
+		return model.ProviderPayload{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderPayload{}, nil
+	}
+
+	p := newProvider(input.Name)
+	s.apply(p)
+	if err := installPackage(ctx, c, p); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errInstallPackage))
+		return model.ProviderPayload{}, nil
+	}
+
+	out := model.GetProvider(p)
+	return model.ProviderPayload{Provider: &out}, nil
+}
+
+func (r *mutation) UpdateProviderPackage(ctx context.Context, name string, input model.UpdateProviderPackageInput) (model.ProviderPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := packageSpec{
+		Package:              input.Package,
+		PackagePullPolicy:    input.PackagePullPolicy,
+		RevisionHistoryLimit: input.RevisionHistoryLimit,
+		RuntimeConfigRef:     input.RuntimeConfigRef,
+	}
+	if err := s.validate(name); err != nil {
+		graphql.AddError(ctx, err)
+		return model.ProviderPayload{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderPayload{}, nil
+	}
+
+	p := newProvider(name)
+	if err := updatePackage(ctx, c, p, s.apply); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUpdatePackage))
+		return model.ProviderPayload{}, nil
+	}
+
+	out := model.GetProvider(p)
+	return model.ProviderPayload{Provider: &out}, nil
+}
+
+func (r *mutation) SetProviderActivationPolicy(ctx context.Context, name string, policy model.RevisionActivationPolicy) (model.ProviderPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderPayload{}, nil
+	}
+
+	p := newProvider(name)
+	if err := updatePackage(ctx, c, p, func(p pkgv1.Package) { p.SetActivationPolicy(getActivationPolicy(policy)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUpdatePackage))
+		return model.ProviderPayload{}, nil
+	}
+
+	out := model.GetProvider(p)
+	return model.ProviderPayload{Provider: &out}, nil
+}
+
+func (r *mutation) UninstallProvider(ctx context.Context, name string) (model.ProviderPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderPayload{}, nil
+	}
+
+	p := newProvider(name)
+	found, err := uninstallPackage(ctx, c, p)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUninstallPackage))
+		return model.ProviderPayload{}, nil
+	}
+	if !found {
+		return model.ProviderPayload{}, nil
+	}
+
+	out := model.GetProvider(p)
+	return model.ProviderPayload{Provider: &out}, nil
+}
+
+func (r *mutation) InstallConfiguration(ctx context.Context, input model.InstallConfigurationInput) (model.ConfigurationPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := packageSpec{
+		Package:                     input.Package,
+		RevisionActivationPolicy:    input.RevisionActivationPolicy,
+		RevisionHistoryLimit:        input.RevisionHistoryLimit,
+		PackagePullPolicy:           input.PackagePullPolicy,
+		IgnoreCrossplaneConstraints: input.IgnoreCrossplaneConstraints,
+		SkipDependencyResolution:    input.SkipDependencyResolution,
+	}
+	if err := s.validate(input.Name); err != nil {
+		graphql.AddError(ctx, err)
+		return model.ConfigurationPayload{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	p := newConfiguration(input.Name)
+	s.apply(p)
+	if err := installPackage(ctx, c, p); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errInstallPackage))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	out := model.GetConfiguration(p)
+	return model.ConfigurationPayload{Configuration: &out}, nil
+}
+
+func (r *mutation) UpdateConfigurationPackage(ctx context.Context, name string, input model.UpdateConfigurationPackageInput) (model.ConfigurationPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := packageSpec{
+		Package:              input.Package,
+		PackagePullPolicy:    input.PackagePullPolicy,
+		RevisionHistoryLimit: input.RevisionHistoryLimit,
+	}
+	if err := s.validate(name); err != nil {
+		graphql.AddError(ctx, err)
+		return model.ConfigurationPayload{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	p := newConfiguration(name)
+	if err := updatePackage(ctx, c, p, s.apply); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUpdatePackage))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	out := model.GetConfiguration(p)
+	return model.ConfigurationPayload{Configuration: &out}, nil
+}
+
+func (r *mutation) SetConfigurationActivationPolicy(ctx context.Context, name string, policy model.RevisionActivationPolicy) (model.ConfigurationPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	p := newConfiguration(name)
+	if err := updatePackage(ctx, c, p, func(p pkgv1.Package) { p.SetActivationPolicy(getActivationPolicy(policy)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUpdatePackage))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	out := model.GetConfiguration(p)
+	return model.ConfigurationPayload{Configuration: &out}, nil
+}
+
+func (r *mutation) UninstallConfiguration(ctx context.Context, name string) (model.ConfigurationPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ConfigurationPayload{}, nil
+	}
+
+	p := newConfiguration(name)
+	found, err := uninstallPackage(ctx, c, p)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUninstallPackage))
+		return model.ConfigurationPayload{}, nil
+	}
+	if !found {
+		return model.ConfigurationPayload{}, nil
+	}
+
+	out := model.GetConfiguration(p)
+	return model.ConfigurationPayload{Configuration: &out}, nil
+}
+
+func (r *mutation) InstallFunction(ctx context.Context, input model.InstallFunctionInput) (model.FunctionPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := packageSpec{
+		Package:                     input.Package,
+		RevisionActivationPolicy:    input.RevisionActivationPolicy,
+		RevisionHistoryLimit:        input.RevisionHistoryLimit,
+		PackagePullPolicy:           input.PackagePullPolicy,
+		IgnoreCrossplaneConstraints: input.IgnoreCrossplaneConstraints,
+		SkipDependencyResolution:    input.SkipDependencyResolution,
+		RuntimeConfigRef:            input.RuntimeConfigRef,
+	}
+	if err := s.validate(input.Name); err != nil {
+		graphql.AddError(ctx, err)
+		return model.FunctionPayload{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionPayload{}, nil
+	}
+
+	p := newFunction(input.Name)
+	s.apply(p)
+	if err := installPackage(ctx, c, p); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errInstallPackage))
+		return model.FunctionPayload{}, nil
+	}
+
+	out := model.GetFunction(p)
+	return model.FunctionPayload{Function: &out}, nil
+}
+
+func (r *mutation) UpdateFunctionPackage(ctx context.Context, name string, input model.UpdateFunctionPackageInput) (model.FunctionPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	s := packageSpec{
+		Package:              input.Package,
+		PackagePullPolicy:    input.PackagePullPolicy,
+		RevisionHistoryLimit: input.RevisionHistoryLimit,
+		RuntimeConfigRef:     input.RuntimeConfigRef,
+	}
+	if err := s.validate(name); err != nil {
+		graphql.AddError(ctx, err)
+		return model.FunctionPayload{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionPayload{}, nil
+	}
+
+	p := newFunction(name)
+	if err := updatePackage(ctx, c, p, s.apply); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUpdatePackage))
+		return model.FunctionPayload{}, nil
+	}
+
+	out := model.GetFunction(p)
+	return model.FunctionPayload{Function: &out}, nil
+}
+
+func (r *mutation) SetFunctionActivationPolicy(ctx context.Context, name string, policy model.RevisionActivationPolicy) (model.FunctionPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionPayload{}, nil
+	}
+
+	p := newFunction(name)
+	if err := updatePackage(ctx, c, p, func(p pkgv1.Package) { p.SetActivationPolicy(getActivationPolicy(policy)) }); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUpdatePackage))
+		return model.FunctionPayload{}, nil
+	}
+
+	out := model.GetFunction(p)
+	return model.FunctionPayload{Function: &out}, nil
+}
+
+func (r *mutation) UninstallFunction(ctx context.Context, name string) (model.FunctionPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionPayload{}, nil
+	}
+
+	p := newFunction(name)
+	found, err := uninstallPackage(ctx, c, p)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errUninstallPackage))
+		return model.FunctionPayload{}, nil
+	}
+	if !found {
+		return model.FunctionPayload{}, nil
+	}
+
+	out := model.GetFunction(p)
+	return model.FunctionPayload{Function: &out}, nil
+}
@@ -0,0 +1,380 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+func TestInstallProvider(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	installed := newProvider("cool")
+	installed.Spec.Package = "xpkg.upbound.io/crossplane/provider-cool:v1.0.0"
+	installed.Spec.RevisionActivationPolicy = ptr.To(pkgv1.ManualActivation)
+	installed.Spec.RevisionHistoryLimit = ptr.To[int64](2)
+	installed.Spec.PackagePullPolicy = ptr.To(corev1.PullAlways)
+	installed.Spec.RuntimeConfigReference = &pkgv1.RuntimeConfigReference{Name: "debug"}
+	gp := model.GetProvider(installed)
+
+	input := model.InstallProviderInput{
+		Name:                     "cool",
+		Package:                  "xpkg.upbound.io/crossplane/provider-cool:v1.0.0",
+		RevisionActivationPolicy: ptr.To(model.RevisionActivationPolicyManual),
+		RevisionHistoryLimit:     ptr.To(2),
+		PackagePullPolicy:        ptr.To(model.PackagePullPolicyAlways),
+		RuntimeConfigRef:         &model.RuntimeConfigReferenceInput{Name: "debug"},
+	}
+
+	type want struct {
+		payload model.ProviderPayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		input   model.InstallProviderInput
+		want    want
+	}{
+		"InvalidName": {
+			reason: "If the supplied name is invalid we should add an error to the GraphQL context and return early.",
+			input:  model.InstallProviderInput{Name: "Cool!", Package: "example"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Errorf(errFmtInvalidName, "Cool!", strings.Join(validation.IsDNS1123Subdomain("Cool!"), ", "))),
+				},
+			},
+		},
+		"EmptyPackage": {
+			reason: "If the supplied package is empty we should add an error to the GraphQL context and return early.",
+			input:  model.InstallProviderInput{Name: "cool"},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errEmptyPackage)),
+				},
+			},
+		},
+		"NegativeRevisionHistoryLimit": {
+			reason: "If the supplied revision history limit is negative we should add an error to the GraphQL context and return early.",
+			input:  model.InstallProviderInput{Name: "cool", Package: "example", RevisionHistoryLimit: ptr.To(-1)},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errNegativeRevisionHistoryLimit)),
+				},
+			},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return nil, errBoom
+			}),
+			input: input,
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"CreateError": {
+			reason: "If we can't create the provider we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockCreate: test.NewMockCreateFn(kerrors.NewBadRequest("boom"))}, nil
+			}),
+			input: input,
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(kerrors.NewBadRequest("boom"), errInstallPackage)),
+				},
+			},
+		},
+		"Success": {
+			reason: "If we create the provider we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockCreate: test.NewMockCreateFn(nil, func(obj client.Object) error {
+					if diff := cmp.Diff(installed, obj); diff != "" {
+						t.Errorf("Create(...): -want, +got:\n%s", diff)
+					}
+					return nil
+				})}, nil
+			}),
+			input: input,
+			want: want{
+				payload: model.ProviderPayload{Provider: &gp},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.InstallProvider(ctx, tc.input)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.InstallProvider(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.InstallProvider(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}), cmpopts.IgnoreFields(model.Provider{}, "PavedAccess")); diff != "" {
+				t.Errorf("\n%s\nm.InstallProvider(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateFunctionPackage(t *testing.T) {
+	errBoom := errors.New("boom")
+	errConflict := kerrors.NewConflict(schema.GroupResource{}, "cool", errBoom)
+
+	// The existing function has a revision activation policy that the update
+	// shouldn't touch.
+	existing := func() *pkgv1.Function {
+		f := newFunction("cool")
+		f.Spec.Package = "xpkg.upbound.io/crossplane/function-cool:v1.0.0"
+		f.Spec.RevisionActivationPolicy = ptr.To(pkgv1.ManualActivation)
+		return f
+	}
+	updated := existing()
+	updated.Spec.Package = "xpkg.upbound.io/crossplane/function-cool:v2.0.0"
+	gf := model.GetFunction(updated)
+
+	input := model.UpdateFunctionPackageInput{Package: "xpkg.upbound.io/crossplane/function-cool:v2.0.0"}
+
+	get := test.NewMockGetFn(nil, func(obj client.Object) error {
+		*obj.(*pkgv1.Function) = *existing()
+		return nil
+	})
+
+	type want struct {
+		payload model.FunctionPayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetError": {
+			reason: "If we can't get the function we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errUpdatePackage)),
+				},
+			},
+		},
+		"UpdateError": {
+			reason: "If we can't update the function we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get, MockUpdate: test.NewMockUpdateFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errUpdatePackage)),
+				},
+			},
+		},
+		"SuccessAfterConflict": {
+			reason: "If the function changes while we're updating it we should try again, and return the updated function.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				conflicted := false
+				return &test.MockClient{
+					MockGet: get,
+					MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+						if !conflicted {
+							conflicted = true
+							return errConflict
+						}
+						if diff := cmp.Diff(updated, obj); diff != "" {
+							t.Errorf("Update(...): -want, +got:\n%s", diff)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			want: want{
+				payload: model.FunctionPayload{Function: &gf},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.UpdateFunctionPackage(ctx, "cool", input)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.UpdateFunctionPackage(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.UpdateFunctionPackage(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}), cmpopts.IgnoreFields(model.Function{}, "PavedAccess")); diff != "" {
+				t.Errorf("\n%s\nm.UpdateFunctionPackage(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSetConfigurationActivationPolicy(t *testing.T) {
+	updated := newConfiguration("cool")
+	updated.Spec.RevisionActivationPolicy = ptr.To(pkgv1.ManualActivation)
+	gc := model.GetConfiguration(updated)
+
+	m := &mutation{clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+		return &test.MockClient{
+			MockGet: test.NewMockGetFn(nil),
+			MockUpdate: test.NewMockUpdateFn(nil, func(obj client.Object) error {
+				if diff := cmp.Diff(updated, obj); diff != "" {
+					t.Errorf("Update(...): -want, +got:\n%s", diff)
+				}
+				return nil
+			}),
+		}, nil
+	})}
+	ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+	got, _ := m.SetConfigurationActivationPolicy(ctx, "cool", model.RevisionActivationPolicyManual)
+	if diff := cmp.Diff(gqlerror.List(nil), graphql.GetErrors(ctx)); diff != "" {
+		t.Errorf("\nm.SetConfigurationActivationPolicy(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", diff)
+	}
+	want := model.ConfigurationPayload{Configuration: &gc}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}), cmpopts.IgnoreFields(model.Configuration{}, "PavedAccess")); diff != "" {
+		t.Errorf("\nm.SetConfigurationActivationPolicy(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestUninstallProvider(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "cool")
+
+	gp := model.GetProvider(newProvider("cool"))
+
+	type want struct {
+		payload model.ProviderPayload
+		err     error
+		errs    gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"NotFound": {
+			reason: "If the provider doesn't exist we should return an empty payload.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errNotFound)}, nil
+			}),
+			want: want{},
+		},
+		"GetError": {
+			reason: "If we can't get the provider we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errUninstallPackage)),
+				},
+			},
+		},
+		"DeleteError": {
+			reason: "If we can't delete the provider we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet:    test.NewMockGetFn(nil),
+					MockDelete: test.NewMockDeleteFn(kerrors.NewForbidden(schema.GroupResource{}, "cool", errBoom)),
+				}, nil
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(kerrors.NewForbidden(schema.GroupResource{}, "cool", errBoom), errUninstallPackage)),
+				},
+			},
+		},
+		"Success": {
+			reason: "If we delete the provider we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet:    test.NewMockGetFn(nil),
+					MockDelete: test.NewMockDeleteFn(nil),
+				}, nil
+			}),
+			want: want{
+				payload: model.ProviderPayload{Provider: &gp},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mutation{clients: tc.clients}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := m.UninstallProvider(ctx, "cool")
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.UninstallProvider(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nm.UninstallProvider(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.payload, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}), cmpopts.IgnoreFields(model.Provider{}, "PavedAccess")); diff != "" {
+				t.Errorf("\n%s\nm.UninstallProvider(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errGetPodLogs           = "cannot get pod logs"
+	errNoLogReader          = "reading pod logs is not supported"
+	errNegativeTailLines    = "tailLines must not be negative"
+	errNegativeSinceSeconds = "sinceSeconds must not be negative"
+)
+
+const (
+	// defaultTailLines is the number of lines read from the end of a
+	// container's logs when neither tailLines nor sinceSeconds are supplied.
+	// Package runtime pods are long-running, so their logs can be large.
+	defaultTailLines = 1000
+
+	// maxLogBytes is the most bytes of logs read from a container, regardless
+	// of tailLines and sinceSeconds.
+	maxLogBytes = 1 << 20
+)
+
+type pod struct {
+	clients ClientCache
+	logs    LogReader
+}
+
+func (r *pod) Events(ctx context.Context, obj *model.Pod, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		Namespace:  ptr.Deref(obj.Metadata.Namespace, ""),
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *pod) UsedBy(ctx context.Context, obj *model.Pod, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *pod) Uses(ctx context.Context, obj *model.Pod, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *pod) Logs(ctx context.Context, obj *model.Pod, container *string, tailLines *int, sinceSeconds *int) (*string, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if tailLines != nil && *tailLines < 0 {
+		graphql.AddError(ctx, errors.New(errNegativeTailLines))
+		return nil, nil
+	}
+	if sinceSeconds != nil && *sinceSeconds < 0 {
+		graphql.AddError(ctx, errors.New(errNegativeSinceSeconds))
+		return nil, nil
+	}
+
+	o := &corev1.PodLogOptions{Container: ptr.Deref(container, ""), LimitBytes: ptr.To[int64](maxLogBytes)}
+	if tailLines != nil {
+		o.TailLines = ptr.To(int64(*tailLines))
+	}
+	if sinceSeconds != nil {
+		o.SinceSeconds = ptr.To(int64(*sinceSeconds))
+	}
+	if tailLines == nil && sinceSeconds == nil {
+		o.TailLines = ptr.To[int64](defaultTailLines)
+	}
+
+	// Logs are read using the caller's credentials, so callers can only read
+	// the logs of pods they're allowed to read the logs of.
+	creds, _ := auth.FromContext(ctx)
+	b, err := r.logs.PodLogs(ctx, creds, ptr.Deref(obj.Metadata.Namespace, ""), obj.Metadata.Name, o)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetPodLogs))
+		return nil, nil
+	}
+
+	out := string(b)
+	return &out, nil
+}
@@ -0,0 +1,147 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+var _ generated.PodResolver = &pod{}
+
+func TestPodLogs(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	gp := model.GetPod(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "cool"}})
+
+	type args struct {
+		container    *string
+		tailLines    *int
+		sinceSeconds *int
+	}
+	type want struct {
+		logs *string
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason string
+		logs   LogReader
+		args   args
+		want   want
+	}{
+		"PodLogsError": {
+			reason: "If we can't read the pod's logs we should add the error to the GraphQL context and return early.",
+			logs: LogReaderFn(func(_ context.Context, _ auth.Credentials, _, _ string, _ *corev1.PodLogOptions) ([]byte, error) {
+				return nil, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetPodLogs)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should read the logs of the supplied pod, using the supplied options.",
+			logs: LogReaderFn(func(_ context.Context, _ auth.Credentials, namespace, name string, o *corev1.PodLogOptions) ([]byte, error) {
+				want := &corev1.PodLogOptions{Container: "package-runtime", TailLines: ptr.To[int64](10), SinceSeconds: ptr.To[int64](60), LimitBytes: ptr.To[int64](maxLogBytes)}
+				if namespace != "crossplane-system" || name != "cool" || !cmp.Equal(want, o) {
+					return nil, errors.Errorf("unexpected pod %s/%s or options %v", namespace, name, o)
+				}
+				return []byte("cool logs"), nil
+			}),
+			args: args{
+				container:    ptr.To("package-runtime"),
+				tailLines:    ptr.To(10),
+				sinceSeconds: ptr.To(60),
+			},
+			want: want{
+				logs: ptr.To("cool logs"),
+			},
+		},
+		"DefaultTailLines": {
+			reason: "If neither tailLines nor sinceSeconds are supplied we should only read the end of the logs.",
+			logs: LogReaderFn(func(_ context.Context, _ auth.Credentials, _, _ string, o *corev1.PodLogOptions) ([]byte, error) {
+				want := &corev1.PodLogOptions{TailLines: ptr.To[int64](defaultTailLines), LimitBytes: ptr.To[int64](maxLogBytes)}
+				if !cmp.Equal(want, o) {
+					return nil, errors.Errorf("unexpected options %v", o)
+				}
+				return []byte("cool logs"), nil
+			}),
+			want: want{
+				logs: ptr.To("cool logs"),
+			},
+		},
+		"NegativeTailLines": {
+			reason: "If tailLines is negative we should add an error to the GraphQL context and return early.",
+			args: args{
+				tailLines: ptr.To(-1),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errNegativeTailLines)),
+				},
+			},
+		},
+		"NegativeSinceSeconds": {
+			reason: "If sinceSeconds is negative we should add an error to the GraphQL context and return early.",
+			args: args{
+				sinceSeconds: ptr.To(-1),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errNegativeSinceSeconds)),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &pod{logs: tc.logs}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Logs(ctx, &gp, tc.args.container, tc.args.tailLines, tc.args.sinceSeconds)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Logs(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Logs(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.logs, got); diff != "" {
+				t.Errorf("\n%s\nr.Logs(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -43,17 +43,27 @@ type provider struct {
 	clients ClientCache
 }
 
-func (r *provider) Events(ctx context.Context, obj *model.Provider) (model.EventConnection, error) {
+func (r *provider) Events(ctx context.Context, obj *model.Provider, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
-func (r *provider) Revisions(ctx context.Context, obj *model.Provider) (model.ProviderRevisionConnection, error) {
+func (r *provider) UsedBy(ctx context.Context, obj *model.Provider, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *provider) Uses(ctx context.Context, obj *model.Provider, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *provider) Revisions(ctx context.Context, obj *model.Provider, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ProviderRevisionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -89,6 +99,14 @@ func (r *provider) Revisions(ctx context.Context, obj *model.Provider) (model.Pr
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ProviderRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ProviderRevisionConnection{}, nil
+	}
 	return *out, nil
 }
 
@@ -131,25 +149,80 @@ func (r *provider) ActiveRevision(ctx context.Context, obj *model.Provider) (*mo
 	return nil, nil
 }
 
+func (r *provider) Deployment(ctx context.Context, obj *model.Provider) (model.KubernetesResource, error) {
+	rev, _ := r.ActiveRevision(ctx, obj)
+	if rev == nil {
+		return nil, nil
+	}
+
+	rt := &packageRuntime{clients: r.clients}
+	return rt.Deployment(ctx, rev.Metadata.Name, types.UID(rev.Metadata.UID))
+}
+
+func (r *provider) Dependencies(ctx context.Context, obj *model.Provider) ([]model.LockDependency, error) {
+	rev, _ := r.ActiveRevision(ctx, obj)
+	if rev == nil {
+		return nil, nil
+	}
+
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependencies(ctx, rev.Metadata.Name)
+}
+
+func (r *provider) Dependents(ctx context.Context, obj *model.Provider) ([]model.LockPackage, error) {
+	rev, _ := r.ActiveRevision(ctx, obj)
+	if rev == nil {
+		return nil, nil
+	}
+
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependents(ctx, rev.Metadata.Name)
+}
+
 type providerRevision struct {
 	clients ClientCache
 }
 
-func (r *providerRevision) Events(ctx context.Context, obj *model.ProviderRevision) (model.EventConnection, error) {
+func (r *providerRevision) Events(ctx context.Context, obj *model.ProviderRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerRevision) UsedBy(ctx context.Context, obj *model.ProviderRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerRevision) Uses(ctx context.Context, obj *model.ProviderRevision, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerRevision) Dependencies(ctx context.Context, obj *model.ProviderRevision) ([]model.LockDependency, error) {
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependencies(ctx, obj.Metadata.Name)
+}
+
+func (r *providerRevision) Dependents(ctx context.Context, obj *model.ProviderRevision) ([]model.LockPackage, error) {
+	d := &packageDependencies{clients: r.clients}
+	return d.Dependents(ctx, obj.Metadata.Name)
+}
+
+func (r *providerRevision) Runtime(ctx context.Context, obj *model.ProviderRevision) (*model.PackageRuntime, error) {
+	rt := &packageRuntime{clients: r.clients}
+	return rt.Runtime(ctx, obj.Metadata.Name, types.UID(obj.Metadata.UID))
 }
 
 type providerRevisionStatus struct {
 	clients ClientCache
 }
 
-func (r *providerRevisionStatus) Objects(ctx context.Context, obj *model.ProviderRevisionStatus) (model.KubernetesResourceConnection, error) {
+func (r *providerRevisionStatus) Objects(ctx context.Context, obj *model.ProviderRevisionStatus, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -198,5 +271,14 @@ func (r *providerRevisionStatus) Objects(ctx context.Context, obj *model.Provide
 	}
 	wg.Wait()
 
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
 	return *out, nil
 }
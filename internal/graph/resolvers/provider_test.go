@@ -155,7 +155,7 @@ func TestProviderRevisions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := c.Revisions(tc.args.ctx, tc.args.obj)
+			got, err := c.Revisions(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -164,7 +164,7 @@ func TestProviderRevisions(t *testing.T) {
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.ProviderRevisionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
 				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
@@ -437,7 +437,7 @@ func TestProviderRevisionStatusObjects(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := s.Objects(tc.args.ctx, tc.args.obj)
+			got, err := s.Objects(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -447,6 +447,7 @@ func TestProviderRevisionStatusObjects(t *testing.T) {
 				t.Errorf("\n%s\ns.Objects(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.krc, got,
+				cmpopts.IgnoreFields(model.KubernetesResourceConnection{}, "Edges", "PageInfo"),
 				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
 				cmpopts.IgnoreFields(model.CustomResourceDefinition{}, "PavedAccess"),
 			); diff != "" {
@@ -16,42 +16,54 @@ package resolvers
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"sort"
 
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/99designs/gqlgen/graphql"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
-	"github.com/upbound/xgql/internal/unstructured"
+)
+
+const (
+	errListProviderConfigUsages = "cannot list provider config usages"
+	errGetManaged               = "cannot get managed resource"
 )
 
 type providerConfig struct {
 	clients ClientCache
 }
 
-func (r *providerConfig) Events(ctx context.Context, obj *model.ProviderConfig) (model.EventConnection, error) {
+func (r *providerConfig) Events(ctx context.Context, obj *model.ProviderConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := &events{clients: r.clients}
 	return e.Resolve(ctx, &corev1.ObjectReference{
 		APIVersion: obj.APIVersion,
 		Kind:       obj.Kind,
 		Name:       obj.Metadata.Name,
 		UID:        types.UID(obj.Metadata.UID),
-	})
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
-func (r *providerConfig) Definition(ctx context.Context, obj *model.ProviderConfig) (model.ProviderConfigDefinition, error) { //nolint:gocyclo
-	// NOTE(tnthornton) this function is not really all that complex at the
-	// moment, however we should be wary of future addtions as we are already
-	// running into cyclomatic complexity errors.
+func (r *providerConfig) UsedBy(ctx context.Context, obj *model.ProviderConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
 
+func (r *providerConfig) Uses(ctx context.Context, obj *model.ProviderConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerConfig) Definition(ctx context.Context, obj *model.ProviderConfig) (model.ProviderConfigDefinition, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -70,49 +82,107 @@ func (r *providerConfig) Definition(ctx context.Context, obj *model.ProviderConf
 		return nil, nil
 	}
 
-	name := pluralForm(strings.ToLower(obj.Kind))
+	crd, err := getDefiningCRD(ctx, c, gv.WithKind(obj.Kind))
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return nil, nil
+	}
+	if crd == nil {
+		return nil, nil
+	}
 
-	nn := types.NamespacedName{Name: fmt.Sprintf("%s.%s", name, gv.Group)}
+	out := model.GetCustomResourceDefinition(crd)
+	return &out, nil
+}
 
-	in := unstructured.NewCRD()
-	err = c.Get(ctx, nn, in.GetUnstructured())
+func (r *providerConfig) Usages(ctx context.Context, obj *model.ProviderConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ProviderConfigUsageConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	if err != nil && !kerrors.IsNotFound(err) {
-		graphql.AddError(ctx, errors.Wrap(err, errGetCRD))
-		return nil, nil
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderConfigUsageConnection{}, nil
 	}
 
-	// We didn't find the CRD we were looking for, list all CRDs and see if we
-	// can find the matching one.
-	if kerrors.IsNotFound(err) {
-		lin := unstructured.NewCRDList()
-		if err := c.List(ctx, lin.GetUnstructuredList()); err != nil {
-			graphql.AddError(ctx, errors.Wrap(err, errListCRDs))
-			return nil, nil
-		}
+	// Providers define a ProviderConfigUsage kind alongside each ProviderConfig
+	// kind, and label each usage with the name of the provider config it uses.
+	in := &kunstructured.UnstructuredList{}
+	in.SetAPIVersion(obj.APIVersion)
+	in.SetKind("ProviderConfigUsageList")
+	if err := c.List(ctx, in, client.MatchingLabels{xpv1.LabelKeyProviderName: obj.Metadata.Name}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListProviderConfigUsages))
+		return model.ProviderConfigUsageConnection{}, nil
+	}
 
-		for i := range lin.Items {
-			crd := unstructured.CustomResourceDefinition{Unstructured: lin.Items[i]} // So we don't take the address of a range variable.
+	out := &model.ProviderConfigUsageConnection{
+		Nodes:      make([]model.ProviderConfigUsage, 0, len(in.Items)),
+		TotalCount: len(in.Items),
+	}
 
-			if crd.GetSpecGroup() != gv.Group {
-				continue
-			}
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetProviderConfigUsage(&in.Items[i]))
+	}
 
-			if crd.GetSpecNames().Kind != obj.Kind {
-				continue
-			}
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ProviderConfigUsageConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ProviderConfigUsageConnection{}, nil
+	}
+	return *out, nil
+}
 
-			out := model.GetCustomResourceDefinition(&crd)
-			return &out, nil
-		}
+type providerConfigUsage struct {
+	clients ClientCache
+}
+
+func (r *providerConfigUsage) Events(ctx context.Context, obj *model.ProviderConfigUsage, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerConfigUsage) UsedBy(ctx context.Context, obj *model.ProviderConfigUsage, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerConfigUsage) Uses(ctx context.Context, obj *model.ProviderConfigUsage, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *providerConfigUsage) Resource(ctx context.Context, obj *model.ProviderConfigUsage) (*model.ManagedResource, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
 	}
 
-	// We found a CRD, let's double check the Group and Kind match our
-	// expectations.
-	if in.GetSpecGroup() == gv.Group && in.GetSpecNames().Kind == obj.Kind {
-		out := model.GetCustomResourceDefinition(in)
-		return &out, nil
+	mr := &kunstructured.Unstructured{}
+	mr.SetAPIVersion(obj.ResourceRef.APIVersion)
+	mr.SetKind(obj.ResourceRef.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Name: obj.ResourceRef.Name}, mr); err != nil {
+		// The managed resource may have been deleted before its usage.
+		if !kerrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetManaged))
+		}
+		return nil, nil
 	}
 
-	return nil, nil
+	out := model.GetManagedResource(mr)
+	return &out, nil
 }
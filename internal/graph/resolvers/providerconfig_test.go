@@ -28,6 +28,7 @@ import (
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
@@ -38,7 +39,10 @@ import (
 	"github.com/upbound/xgql/internal/unstructured"
 )
 
-var _ generated.ProviderConfigResolver = &providerConfig{}
+var (
+	_ generated.ProviderConfigResolver      = &providerConfig{}
+	_ generated.ProviderConfigUsageResolver = &providerConfigUsage{}
+)
 
 func TestProviderConfigDefinition(t *testing.T) {
 	errBoom := errors.New("boom")
@@ -101,13 +105,19 @@ func TestProviderConfigDefinition(t *testing.T) {
 		"GetCRDError": {
 			reason: "If we can't get the CRD we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockGet: test.NewMockGetFn(errBoom),
+				return &mappedClient{
+					MockClient: &test.MockClient{
+						MockGet: test.NewMockGetFn(errBoom),
+					},
+					mapper: exampleMapper(),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				obj: &model.ProviderConfig{},
+				obj: &model.ProviderConfig{
+					APIVersion: crd.GetSpecGroup() + "/v1",
+					Kind:       crd.GetSpecNames().Kind,
+				},
 			},
 			want: want{
 				errs: gqlerror.List{
@@ -138,11 +148,14 @@ func TestProviderConfigDefinition(t *testing.T) {
 		"FoundCRD": {
 			reason: "If we can get and model the CRD that defines this provider config we should return it.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
-						*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
-						return nil
-					}),
+				return &mappedClient{
+					MockClient: &test.MockClient{
+						MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+							*obj.(*kunstructured.Unstructured) = *crd.GetUnstructured()
+							return nil
+						}),
+					},
+					mapper: exampleMapper(),
 				}, nil
 			}),
 			args: args{
@@ -157,9 +170,7 @@ func TestProviderConfigDefinition(t *testing.T) {
 			},
 		},
 		"DifferentPlural": {
-			reason: `In the event we get a request for an object whose CRD has 
-			a non-predictable plural form, ensure the CRD list contains the 
-			expected resource.`,
+			reason: "If the REST mapper can't map the kind we should fall back to finding the CRD with a matching group and kind, regardless of its plural form.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
@@ -236,3 +247,250 @@ func TestProviderConfigDefinition(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderConfigUsages(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	pcu := unstructured.ProviderConfigUsage{}
+	pcu.SetAPIVersion("example.org/v1")
+	pcu.SetKind("ProviderConfigUsage")
+	pcu.SetName("no-you-id")
+	pcu.SetLabels(map[string]string{xpv1.LabelKeyProviderName: "default"})
+	pcu.SetProviderConfigReference(xpv1.Reference{Name: "default"})
+	pcu.SetResourceReference(xpv1.TypedReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"})
+	gpcu := model.GetProviderConfigUsage(pcu.GetUnstructured())
+
+	type args struct {
+		ctx context.Context
+		obj *model.ProviderConfig
+	}
+	type want struct {
+		pcuc model.ProviderConfigUsageConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.ProviderConfig{},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListUsagesError": {
+			reason: "If we can't list provider config usages we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.ProviderConfig{},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviderConfigUsages)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return the usages of the provider config.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						l := obj.(*kunstructured.UnstructuredList)
+						if l.GetAPIVersion() != "example.org/v1" || l.GetKind() != "ProviderConfigUsageList" {
+							t.Errorf("unexpected list of %q kind %q", l.GetAPIVersion(), l.GetKind())
+						}
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if lo.LabelSelector.String() != xpv1.LabelKeyProviderName+"=default" {
+							t.Errorf("unexpected label selector %q", lo.LabelSelector)
+						}
+						l.Items = []kunstructured.Unstructured{*pcu.GetUnstructured()}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.ProviderConfig{
+					APIVersion: "example.org/v1",
+					Kind:       "ProviderConfig",
+					Metadata:   model.ObjectMeta{Name: "default"},
+				},
+			},
+			want: want{
+				pcuc: model.ProviderConfigUsageConnection{
+					Nodes:      []model.ProviderConfigUsage{gpcu},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &providerConfig{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := pc.Usages(tc.args.ctx, tc.args.obj, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npc.Usages(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npc.Usages(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pcuc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.ProviderConfigUsageConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.ProviderConfigUsage{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\npc.Usages(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigUsageResource(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := &kerrors.StatusError{
+		ErrStatus: metav1.Status{
+			Reason: metav1.StatusReasonNotFound,
+		},
+	}
+
+	mr := unstructured.Managed{}
+	mr.SetAPIVersion("example.org/v1")
+	mr.SetKind("Bucket")
+	mr.SetName("coolbucket")
+	gmr := model.GetManagedResource(mr.GetUnstructured())
+
+	pcu := &model.ProviderConfigUsage{
+		ResourceRef: model.TypedReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"},
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.ProviderConfigUsage
+	}
+	type want struct {
+		mr   *model.ManagedResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pcu,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetManagedError": {
+			reason: "If we can't get the managed resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pcu,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetManaged)),
+				},
+			},
+		},
+		"ManagedNotFound": {
+			reason: "If the managed resource doesn't exist we should return nil without adding an error.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pcu,
+			},
+			want: want{},
+		},
+		"Success": {
+			reason: "We should return the managed resource that uses the provider config.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						*obj.(*kunstructured.Unstructured) = *mr.GetUnstructured()
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: pcu,
+			},
+			want: want{
+				mr: &gmr,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pcu := &providerConfigUsage{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := pcu.Resource(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npcu.Resource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\npcu.Resource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mr, got,
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.ManagedResource{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\npcu.Resource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -16,20 +16,30 @@ package resolvers
 
 import (
 	"context"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/graph/model"
@@ -43,89 +53,257 @@ const (
 	errGetSecret     = "cannot get secret"
 	errGetConfigMap  = "cannot get config map"
 	errListProviders = "cannot list providers"
+	errListFunctions = "cannot list functions"
 	errListConfigs   = "cannot list configurations"
+	errPaginate      = "cannot paginate connection"
+	errOrder         = "cannot order connection"
+	errDiscover      = "cannot discover API resources"
+	errNoDiscoverer  = "discovering API resources is not supported"
 )
 
+// maxFailingReasons is the maximum number of failing reasons returned by the
+// healthSummary query.
+const maxFailingReasons = 10
+
 type query struct {
-	clients ClientCache
+	clients   ClientCache
+	discovery Discoverer
 }
 
-// Recursively collect `CrossplaneResourceTreeNode`s from the given KubernetesResource
-func (r *query) getAllDescendant(ctx context.Context, res model.KubernetesResource, parentID *model.ReferenceID) []model.CrossplaneResourceTreeNode { //nolint:gocyclo
-	// This isn't _really_ that complex; it's a long but simple switch.
+func (r *query) CrossplaneResourceTree(ctx context.Context, id model.ReferenceID, where *model.ResourceFilter, kinds []string, maxDepth *int, include []model.CrossplaneResourceTreeRelation, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CrossplaneResourceTreeConnection, error) { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a few simple filters.
 
-	switch typedRes := res.(type) {
-	case model.CompositeResource:
-		list := []model.CrossplaneResourceTreeNode{{ParentID: parentID, Resource: typedRes}}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-		compositeResolver := compositeResourceSpec{clients: r.clients}
-		resources, err := compositeResolver.Resources(ctx, &typedRes.Spec)
-		if err != nil || len(graphql.GetErrors(ctx)) > 0 {
-			return nil
-		}
+	if maxDepth != nil && *maxDepth < 0 {
+		graphql.AddError(ctx, errors.New(errNegativeMaxDepth))
+		return model.CrossplaneResourceTreeConnection{}, nil
+	}
+	matcher, err := where.Compile()
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFilter))
+		return model.CrossplaneResourceTreeConnection{}, nil
+	}
 
-		// Collect all concurrently.
-		var (
-			wg sync.WaitGroup
-		)
-		childLists := make([][]model.CrossplaneResourceTreeNode, len(resources.Nodes))
-		for i, childRes := range resources.Nodes {
-			i, childRes := i, childRes // So we don't capture the loop variable.
-			wg.Add(1)
-			go func() {
-				defer wg.Done()
-				childLists[i] = r.getAllDescendant(ctx, childRes, &typedRes.ID)
-			}()
-		}
-		wg.Wait()
+	rootRes, err := r.KubernetesResource(ctx, id)
+	if err != nil || len(graphql.GetErrors(ctx)) > 0 {
+		return model.CrossplaneResourceTreeConnection{}, err
+	}
 
-		if len(graphql.GetErrors(ctx)) > 0 {
-			return nil
-		}
+	list := newTree(r.clients, maxDepth, include).Resolve(ctx, rootRes)
+	if len(graphql.GetErrors(ctx)) > 0 {
+		return model.CrossplaneResourceTreeConnection{}, nil
+	}
 
-		for _, childList := range childLists {
-			list = append(list, childList...)
-		}
-		return list
-	case model.CompositeResourceClaim:
-		list := []model.CrossplaneResourceTreeNode{{ParentID: parentID, Resource: typedRes}}
+	// Filtering may remove a node's parent, so we remember the parents of the
+	// unfiltered tree in order to point nodes at their nearest kept ancestor.
+	tp := parents(list)
 
-		claimResolver := compositeResourceClaimSpec{clients: r.clients}
-		composite, err := claimResolver.Resource(ctx, &typedRes.Spec)
-		if err != nil || len(graphql.GetErrors(ctx)) > 0 {
-			return nil
+	if len(kinds) > 0 {
+		nodes := list[:0]
+		for _, n := range list {
+			if slices.Contains(kinds, model.GetResourceID(n.Resource).Kind) {
+				nodes = append(nodes, n)
+			}
 		}
+		list = nodes
+	}
 
-		if composite == nil {
-			return list
+	if matcher != nil {
+		nodes := list[:0]
+		for _, n := range list {
+			ok, err := matcher.MatchesResource(n.Resource)
+			if err != nil {
+				graphql.AddError(ctx, errors.Wrap(err, errFilter))
+				return model.CrossplaneResourceTreeConnection{}, nil
+			}
+			if ok {
+				nodes = append(nodes, n)
+			}
 		}
+		list = nodes
+	}
 
-		childList := r.getAllDescendant(ctx, *composite, &typedRes.ID)
-		if err != nil || len(graphql.GetErrors(ctx)) > 0 {
-			return nil
-		}
+	if len(kinds) > 0 || matcher != nil {
+		reparent(list, tp)
+	}
 
-		return append(list, childList...)
-	default:
-		return []model.CrossplaneResourceTreeNode{{ParentID: parentID, Resource: typedRes}}
+	out := model.CrossplaneResourceTreeConnection{Nodes: list, TotalCount: len(list)}
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CrossplaneResourceTreeConnection{}, nil
 	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CrossplaneResourceTreeConnection{}, nil
+	}
+	return out, nil
 }
 
-func (r *query) CrossplaneResourceTree(ctx context.Context, id model.ReferenceID) (model.CrossplaneResourceTreeConnection, error) {
+func (r *query) Ancestors(ctx context.Context, id model.ReferenceID, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	u := &kunstructured.Unstructured{}
+	u.SetAPIVersion(id.APIVersion)
+	u.SetKind(id.Kind)
+	nn := types.NamespacedName{Namespace: id.Namespace, Name: id.Name}
+	if err := c.Get(ctx, nn, u); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetResource))
+		return model.KubernetesResourceConnection{}, nil
+	}
+
+	a := &ancestors{clients: r.clients}
+	return a.Resolve(ctx, u, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *query) Trace(ctx context.Context, id model.ReferenceID, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.TraceConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
 	rootRes, err := r.KubernetesResource(ctx, id)
 	if err != nil || len(graphql.GetErrors(ctx)) > 0 {
-		return model.CrossplaneResourceTreeConnection{}, err
+		return model.TraceConnection{}, err
 	}
 
-	list := r.getAllDescendant(ctx, rootRes, nil)
+	list := newTree(r.clients, nil, nil).Resolve(ctx, rootRes)
 	if len(graphql.GetErrors(ctx)) > 0 {
-		return model.CrossplaneResourceTreeConnection{}, nil
+		return model.TraceConnection{}, nil
 	}
 
-	return model.CrossplaneResourceTreeConnection{Nodes: list, TotalCount: len(list)}, nil
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.TraceConnection{}, nil
+	}
+
+	// We list all events once rather than once per node in the tree.
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.UnsafeDisableDeepCopyOption(true)); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListEvents))
+		return model.TraceConnection{}, nil
+	}
+
+	now := time.Now()
+	nodes := make([]model.TraceNode, len(list))
+	for i, n := range list {
+		nodes[i] = model.TraceNode{
+			ParentID: n.ParentID,
+			Resource: n.Resource,
+			Health:   model.GetResourceHealth(model.GetResourceConditions(n.Resource), latestWarning(events.Items, model.GetResourceReference(n.Resource)), now),
+		}
+	}
+	model.SetRootCauses(nodes)
+
+	out := &model.TraceConnection{Nodes: nodes, TotalCount: len(nodes)}
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.TraceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.TraceConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) HealthSummary(ctx context.Context, namespace *string) (model.HealthSummary, error) { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a long but simple series of lists.
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.HealthSummary{}, nil
+	}
+
+	// Only claims are namespaced. Every other kind of resource is summarized
+	// cluster-wide regardless of the supplied namespace.
+	lopts := []client.ListOption{}
+	if namespace != nil {
+		lopts = []client.ListOption{client.InNamespace(*namespace)}
+	}
+
+	xrds := &extv1.CompositeResourceDefinitionList{}
+	if err := c.List(ctx, xrds); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListXRDs))
+		return model.HealthSummary{}, nil
+	}
+
+	crds := xunstructured.NewCRDList()
+	if err := c.List(ctx, crds.GetUnstructuredList()); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListCRDs))
+		return model.HealthSummary{}, nil
+	}
+
+	providers := &pkgv1.ProviderList{}
+	if err := c.List(ctx, providers); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListProviders))
+		return model.HealthSummary{}, nil
+	}
+
+	configs := &pkgv1.ConfigurationList{}
+	if err := c.List(ctx, configs); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListConfigs))
+		return model.HealthSummary{}, nil
+	}
+
+	var claims, xrs, mrs []model.ConditionedModel
+	for _, u := range listAllDefined(ctx, c, xrds.Items, func(m *model.CompositeResourceDefinition) *model.CompositeResourceDefinitionNames {
+		return m.Spec.ClaimNames
+	}, fields.Everything(), nil, lopts...) {
+		m := model.GetCompositeResourceClaim(&u)
+		claims = append(claims, &m)
+	}
+	for _, u := range listAllDefined(ctx, c, xrds.Items, func(m *model.CompositeResourceDefinition) *model.CompositeResourceDefinitionNames {
+		return &m.Spec.Names
+	}, fields.Everything(), nil) {
+		m := model.GetCompositeResource(&u)
+		xrs = append(xrs, &m)
+	}
+	for _, m := range listAllManaged(ctx, c, crds, nil) {
+		m := m // So we don't take the address of a range variable.
+		mrs = append(mrs, &m)
+	}
+	if len(graphql.GetErrors(ctx)) > 0 {
+		return model.HealthSummary{}, nil
+	}
+
+	out := model.HealthSummary{
+		Claims:             model.GetResourceHealthSummary(claims),
+		CompositeResources: model.GetResourceHealthSummary(xrs),
+		ManagedResources:   model.GetResourceHealthSummary(mrs),
+	}
+
+	ps := make([]model.ConditionedModel, len(providers.Items))
+	for i := range providers.Items {
+		m := model.GetProvider(&providers.Items[i])
+		ps[i] = &m
+	}
+	out.Providers = model.GetResourceHealthSummary(ps)
+
+	cs := make([]model.ConditionedModel, len(configs.Items))
+	for i := range configs.Items {
+		m := model.GetConfiguration(&configs.Items[i])
+		cs[i] = &m
+	}
+	out.Configurations = model.GetResourceHealthSummary(cs)
+
+	out.TopFailingReasons = model.GetTopFailingReasons(slices.Concat(claims, xrs, mrs, ps, cs), maxFailingReasons)
+
+	return out, nil
 }
 
 func (r *query) KubernetesResource(ctx context.Context, id model.ReferenceID) (model.KubernetesResource, error) {
@@ -156,7 +334,7 @@ func (r *query) KubernetesResource(ctx context.Context, id model.ReferenceID) (m
 	return out, nil
 }
 
-func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string, listKind, namespace *string) (model.KubernetesResourceConnection, error) {
+func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string, listKind, namespace *string, labelSelector *model.LabelSelectorInput, fieldSelector *string, where *model.ResourceFilter, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.KubernetesResourceConnection, error) { //nolint:gocyclo
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -164,6 +342,24 @@ func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string
 	if namespace != nil {
 		lopts = []client.ListOption{client.InNamespace(*namespace)}
 	}
+	if labelSelector != nil {
+		ls, err := labelSelector.AsSelector()
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errLabelSelector))
+			return model.KubernetesResourceConnection{}, nil
+		}
+		lopts = append(lopts, client.MatchingLabelsSelector{Selector: ls})
+	}
+	fs, err := fields.ParseSelector(ptr.Deref(fieldSelector, ""))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFieldSelector))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	matcher, err := where.Compile()
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFilter))
+		return model.KubernetesResourceConnection{}, nil
+	}
 
 	creds, _ := auth.FromContext(ctx)
 	c, err := r.clients.Get(creds)
@@ -183,6 +379,8 @@ func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string
 		graphql.AddError(ctx, errors.Wrap(err, errListResources))
 		return model.KubernetesResourceConnection{}, nil
 	}
+	filterFields(in, fs)
+	filterWhere(in, matcher)
 
 	out := &model.KubernetesResourceConnection{
 		Nodes: make([]model.KubernetesResource, 0, len(in.Items)),
@@ -199,23 +397,289 @@ func (r *query) KubernetesResources(ctx context.Context, apiVersion, kind string
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.KubernetesResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.KubernetesResourceConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) Events(ctx context.Context, involved *model.ReferenceID) (model.EventConnection, error) {
+func (r *query) ManagedResources(ctx context.Context, provider *model.ReferenceID, ready *bool, synced *bool, namespace *string, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ManagedResourceConnection, error) { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a long but simple fan out.
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ManagedResourceConnection{}, nil
+	}
+
+	// If a provider was supplied we only want the kinds of managed resource
+	// defined by its active revision.
+	var defined map[string]bool
+	if provider != nil {
+		defined, err = activeProviderCRDs(ctx, c, *provider)
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errListProviderRevs))
+			return model.ManagedResourceConnection{}, nil
+		}
+	}
+
+	crds := xunstructured.NewCRDList()
+	if err := c.List(ctx, crds.GetUnstructuredList()); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListCRDs))
+		return model.ManagedResourceConnection{}, nil
+	}
+
+	lopts := []client.ListOption{}
+	if namespace != nil {
+		lopts = []client.ListOption{client.InNamespace(*namespace)}
+	}
+
+	out := &model.ManagedResourceConnection{
+		Nodes: make([]model.ManagedResource, 0),
+	}
+	for _, mr := range listAllManaged(ctx, c, crds, defined, lopts...) {
+		if !conditionMatches("Ready", ready, &mr) || !conditionMatches("Synced", synced, &mr) {
+			continue
+		}
+		out.Nodes = append(out.Nodes, mr)
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ManagedResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ManagedResourceConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) ProviderConfigs(ctx context.Context, provider *model.ReferenceID, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ProviderConfigConnection, error) { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a long but simple fan out.
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.ProviderConfigConnection{}, nil
+	}
+
+	// If a provider was supplied we only want the kinds of provider config
+	// defined by its active revision.
+	var defined map[string]bool
+	if provider != nil {
+		defined, err = activeProviderCRDs(ctx, c, *provider)
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errListProviderRevs))
+			return model.ProviderConfigConnection{}, nil
+		}
+	}
+
+	crds := xunstructured.NewCRDList()
+	if err := c.List(ctx, crds.GetUnstructuredList()); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListCRDs))
+		return model.ProviderConfigConnection{}, nil
+	}
+
+	out := &model.ProviderConfigConnection{
+		Nodes: make([]model.ProviderConfig, 0),
+	}
+
+	// Collect all concurrently.
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i := range crds.Items {
+		crd := &xunstructured.CustomResourceDefinition{Unstructured: crds.Items[i]}
+		if !isProviderConfigCRD(crd) {
+			continue
+		}
+		if defined != nil && !defined[crd.GetName()] {
+			continue
+		}
+
+		m := model.GetCustomResourceDefinition(crd)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			in := &kunstructured.UnstructuredList{}
+			in.SetAPIVersion(schema.GroupVersion{Group: m.Spec.Group, Version: pickCRDVersion(m.Spec.Versions)}.String())
+			in.SetKind(m.Spec.Names.Kind + "List")
+			if lk := m.Spec.Names.ListKind; lk != nil && *lk != "" {
+				in.SetKind(*lk)
+			}
+
+			if err := c.List(ctx, in); err != nil {
+				graphql.AddError(ctx, errors.Wrap(err, errListResources))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for j := range in.Items {
+				out.Nodes = append(out.Nodes, model.GetProviderConfig(&in.Items[j]))
+				out.TotalCount++
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ProviderConfigConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ProviderConfigConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) CompositeResources(ctx context.Context, ready *bool, labelSelector *model.LabelSelectorInput, fieldSelector *string, where *model.ResourceFilter, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositeResourceConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	lopts := []client.ListOption{}
+	if labelSelector != nil {
+		ls, err := labelSelector.AsSelector()
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errLabelSelector))
+			return model.CompositeResourceConnection{}, nil
+		}
+		lopts = append(lopts, client.MatchingLabelsSelector{Selector: ls})
+	}
+	fs, err := fields.ParseSelector(ptr.Deref(fieldSelector, ""))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFieldSelector))
+		return model.CompositeResourceConnection{}, nil
+	}
+	matcher, err := where.Compile()
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFilter))
+		return model.CompositeResourceConnection{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.CompositeResourceConnection{}, nil
+	}
+
+	xrds := &extv1.CompositeResourceDefinitionList{}
+	if err := c.List(ctx, xrds); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListXRDs))
+		return model.CompositeResourceConnection{}, nil
+	}
+
+	in := &kunstructured.UnstructuredList{}
+	in.Items = listAllDefined(ctx, c, xrds.Items, func(m *model.CompositeResourceDefinition) *model.CompositeResourceDefinitionNames {
+		return &m.Spec.Names
+	}, fs, matcher, lopts...)
+
+	out := getCompositeResourceConnection(in, &model.DefinedCompositeResourceOptionsInput{Ready: ready})
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CompositeResourceConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositeResourceConnection{}, nil
+	}
+	return out, nil
+}
+
+func (r *query) CompositeResourceClaims(ctx context.Context, namespace *string, ready *bool, labelSelector *model.LabelSelectorInput, fieldSelector *string, where *model.ResourceFilter, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositeResourceClaimConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	lopts := []client.ListOption{}
+	if namespace != nil {
+		lopts = []client.ListOption{client.InNamespace(*namespace)}
+	}
+	if labelSelector != nil {
+		ls, err := labelSelector.AsSelector()
+		if err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errLabelSelector))
+			return model.CompositeResourceClaimConnection{}, nil
+		}
+		lopts = append(lopts, client.MatchingLabelsSelector{Selector: ls})
+	}
+	fs, err := fields.ParseSelector(ptr.Deref(fieldSelector, ""))
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFieldSelector))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+	matcher, err := where.Compile()
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errFilter))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+
+	xrds := &extv1.CompositeResourceDefinitionList{}
+	if err := c.List(ctx, xrds); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListXRDs))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+
+	in := &kunstructured.UnstructuredList{}
+	in.Items = listAllDefined(ctx, c, xrds.Items, func(m *model.CompositeResourceDefinition) *model.CompositeResourceDefinitionNames {
+		return m.Spec.ClaimNames
+	}, fs, matcher, lopts...)
+
+	out := getCompositeResourceClaimConnection(in, &model.DefinedCompositeResourceClaimOptionsInput{Ready: ready})
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositeResourceClaimConnection{}, nil
+	}
+	return out, nil
+}
+
+func (r *query) Events(ctx context.Context, involved *model.ReferenceID, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
 	e := events{clients: r.clients}
-	if involved == nil {
-		// Resolve all events.
-		return e.Resolve(ctx, nil)
+
+	// Resolve all events, unless an involved object was supplied.
+	var ref *corev1.ObjectReference
+	if involved != nil {
+		// Resolve events pertaining to the supplied ID.
+		ref = &corev1.ObjectReference{
+			APIVersion: involved.APIVersion,
+			Kind:       involved.Kind,
+			Namespace:  involved.Namespace,
+			Name:       involved.Name,
+		}
 	}
 
-	// Resolve events pertaining to the supplied ID.
-	return e.Resolve(ctx, &corev1.ObjectReference{
-		APIVersion: involved.APIVersion,
-		Kind:       involved.Kind,
-		Namespace:  involved.Namespace,
-		Name:       involved.Name,
-	})
+	return e.Resolve(ctx, ref, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
 }
 
 func (r *query) Secret(ctx context.Context, namespace, name string) (*model.Secret, error) {
@@ -262,7 +726,7 @@ func (r *query) ConfigMap(ctx context.Context, namespace, name string) (*model.C
 	return &out, nil
 }
 
-func (r *query) Providers(ctx context.Context) (model.ProviderConnection, error) {
+func (r *query) Providers(ctx context.Context, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ProviderConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -289,10 +753,18 @@ func (r *query) Providers(ctx context.Context) (model.ProviderConnection, error)
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ProviderConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ProviderConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) ProviderRevisions(ctx context.Context, provider *model.ReferenceID, active *bool) (model.ProviderRevisionConnection, error) {
+func (r *query) ProviderRevisions(ctx context.Context, provider *model.ReferenceID, active *bool, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ProviderRevisionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -331,10 +803,106 @@ func (r *query) ProviderRevisions(ctx context.Context, provider *model.Reference
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ProviderRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ProviderRevisionConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) CustomResourceDefinitions(ctx context.Context, revision *model.ReferenceID) (model.CustomResourceDefinitionConnection, error) {
+func (r *query) Functions(ctx context.Context, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.FunctionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionConnection{}, nil
+	}
+
+	in := &pkgv1.FunctionList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctions))
+		return model.FunctionConnection{}, nil
+	}
+
+	out := &model.FunctionConnection{
+		Nodes:      make([]model.Function, 0, len(in.Items)),
+		TotalCount: len(in.Items),
+	}
+
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetFunction(&in.Items[i]))
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.FunctionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.FunctionConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) FunctionRevisions(ctx context.Context, function *model.ReferenceID, active *bool, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.FunctionRevisionConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	in := &pkgv1.FunctionRevisionList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListFunctionRevs))
+		return model.FunctionRevisionConnection{}, nil
+	}
+
+	out := &model.FunctionRevisionConnection{
+		Nodes: make([]model.FunctionRevision, 0),
+	}
+
+	for i := range in.Items {
+		fr := in.Items[i] // So we don't take the address of a range variable.
+
+		// The supplied function is not an owner of this PackageRevision.
+		if function != nil && !containsID(fr.OwnerReferences, *function) {
+			continue
+		}
+
+		// We only want the active PackageRevision, and this isn't it.
+		if ptr.Deref(active, false) && fr.Spec.DesiredState != pkgv1.PackageRevisionActive {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetFunctionRevision(&fr))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.FunctionRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.FunctionRevisionConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) CustomResourceDefinitions(ctx context.Context, revision *model.ReferenceID, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CustomResourceDefinitionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -368,10 +936,18 @@ func (r *query) CustomResourceDefinitions(ctx context.Context, revision *model.R
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CustomResourceDefinitionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CustomResourceDefinitionConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) Configurations(ctx context.Context) (model.ConfigurationConnection, error) {
+func (r *query) Configurations(ctx context.Context, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ConfigurationConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -398,10 +974,18 @@ func (r *query) Configurations(ctx context.Context) (model.ConfigurationConnecti
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ConfigurationConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ConfigurationConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) ConfigurationRevisions(ctx context.Context, configuration *model.ReferenceID, active *bool) (model.ConfigurationRevisionConnection, error) {
+func (r *query) ConfigurationRevisions(ctx context.Context, configuration *model.ReferenceID, active *bool, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.ConfigurationRevisionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -440,10 +1024,18 @@ func (r *query) ConfigurationRevisions(ctx context.Context, configuration *model
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.ConfigurationRevisionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.ConfigurationRevisionConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) CompositeResourceDefinitions(ctx context.Context, revision *model.ReferenceID, dangling *bool) (model.CompositeResourceDefinitionConnection, error) {
+func (r *query) CompositeResourceDefinitions(ctx context.Context, revision *model.ReferenceID, dangling *bool, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositeResourceDefinitionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -482,10 +1074,18 @@ func (r *query) CompositeResourceDefinitions(ctx context.Context, revision *mode
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CompositeResourceDefinitionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositeResourceDefinitionConnection{}, nil
+	}
 	return *out, nil
 }
 
-func (r *query) Compositions(ctx context.Context, revision *model.ReferenceID, dangling *bool) (model.CompositionConnection, error) {
+func (r *query) Compositions(ctx context.Context, revision *model.ReferenceID, dangling *bool, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.CompositionConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
@@ -524,9 +1124,174 @@ func (r *query) Compositions(ctx context.Context, revision *model.ReferenceID, d
 	}
 
 	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.CompositionConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.CompositionConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) EnvironmentConfigs(ctx context.Context, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EnvironmentConfigConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+
+	in := &extv1alpha1.EnvironmentConfigList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListEnvConfigs))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+
+	out := &model.EnvironmentConfigConnection{
+		Nodes: make([]model.EnvironmentConfig, 0, len(in.Items)),
+	}
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetEnvironmentConfig(&in.Items[i]))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.EnvironmentConfigConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.EnvironmentConfigConnection{}, nil
+	}
 	return *out, nil
 }
 
+func (r *query) Usages(ctx context.Context, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.UsageConnection{}, nil
+	}
+
+	in := &extv1alpha1.UsageList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListUsages))
+		return model.UsageConnection{}, nil
+	}
+
+	out := &model.UsageConnection{
+		Nodes: make([]model.Usage, 0, len(in.Items)),
+	}
+	for i := range in.Items {
+		out.Nodes = append(out.Nodes, model.GetUsage(&in.Items[i]))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(orderBy); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.UsageConnection{}, nil
+	}
+	if err := out.Paginate(model.PageArgs{First: first, After: after, Last: last, Before: before}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.UsageConnection{}, nil
+	}
+	return *out, nil
+}
+
+func (r *query) PackageLock(ctx context.Context) (*model.PackageLock, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	l := &pkgv1beta1.Lock{}
+	if err := c.Get(ctx, types.NamespacedName{Name: lockName}, l); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetLock))
+		}
+		return nil, nil
+	}
+
+	out := model.GetPackageLock(l)
+	return &out, nil
+}
+
+func (r *query) APIResources(ctx context.Context, group *string, categories []string) ([]model.APIResource, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// Discovery doesn't take a context, so we bound it by our timeout by
+	// discovering in a goroutine. The channel is buffered so the goroutine can
+	// return even if we stop waiting for it.
+	type result struct {
+		groups []*metav1.APIGroup
+		lists  []*metav1.APIResourceList
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		groups, lists, err := r.discovery.ServerGroupsAndResources()
+		done <- result{groups: groups, lists: lists, err: err}
+	}()
+
+	var res result
+	select {
+	case <-ctx.Done():
+		graphql.AddError(ctx, errors.Wrap(ctx.Err(), errDiscover))
+		return []model.APIResource{}, nil
+	case res = <-done:
+	}
+
+	groups, lists, err := res.groups, res.lists, res.err
+	if err != nil {
+		// Discovery returns partial results if it fails to discover some API
+		// groups, e.g. because an aggregated API server is unavailable.
+		graphql.AddError(ctx, errors.Wrap(err, errDiscover))
+		if !discovery.IsGroupDiscoveryFailedError(err) {
+			return []model.APIResource{}, nil
+		}
+	}
+
+	all := model.GetAPIResources(groups, lists)
+	out := make([]model.APIResource, 0, len(all))
+	for _, res := range all {
+		if group != nil && res.Group != *group {
+			continue
+		}
+		if !containsAll(res.Categories, categories) {
+			continue
+		}
+		out = append(out, res)
+	}
+	return out, nil
+}
+
+// containsAll returns true if s contains all of the supplied values.
+func containsAll(s []string, values []string) bool {
+	for _, v := range values {
+		if !slices.Contains(s, v) {
+			return false
+		}
+	}
+	return true
+}
+
 func containsCR(in []metav1.OwnerReference) bool {
 	for _, ref := range in {
 		switch {
@@ -540,6 +1305,89 @@ func containsCR(in []metav1.OwnerReference) bool {
 	return false
 }
 
+// listAllManaged lists all managed resources of the kinds defined by the
+// supplied CRDs. If defined is not nil only kinds defined by the named CRDs
+// are listed. Errors are added to the GraphQL context.
+func listAllManaged(ctx context.Context, c client.Client, crds *xunstructured.CustomResourceDefinitionList, defined map[string]bool, lopts ...client.ListOption) []model.ManagedResource {
+	out := make([]model.ManagedResource, 0)
+
+	// Collect all concurrently.
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for i := range crds.Items {
+		crd := &xunstructured.CustomResourceDefinition{Unstructured: crds.Items[i]}
+
+		// Crossplane providers add all of their managed resources to the
+		// managed category. Anything else isn't a managed resource.
+		if !slices.Contains(crd.GetSpecNames().Categories, "managed") {
+			continue
+		}
+		if defined != nil && !defined[crd.GetName()] {
+			continue
+		}
+
+		m := model.GetCustomResourceDefinition(crd)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			in := &kunstructured.UnstructuredList{}
+			in.SetAPIVersion(schema.GroupVersion{Group: m.Spec.Group, Version: pickCRDVersion(m.Spec.Versions)}.String())
+			in.SetKind(m.Spec.Names.Kind + "List")
+			if lk := m.Spec.Names.ListKind; lk != nil && *lk != "" {
+				in.SetKind(*lk)
+			}
+
+			if err := c.List(ctx, in, lopts...); err != nil {
+				graphql.AddError(ctx, errors.Wrap(err, errListResources))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			for j := range in.Items {
+				out = append(out, model.GetManagedResource(&in.Items[j]))
+			}
+		}()
+	}
+	wg.Wait()
+
+	return out
+}
+
+// activeProviderCRDs returns the names of the CustomResourceDefinitions
+// defined by the active revision of the supplied provider.
+func activeProviderCRDs(ctx context.Context, c client.Client, provider model.ReferenceID) (map[string]bool, error) {
+	in := &pkgv1.ProviderRevisionList{}
+	if err := c.List(ctx, in); err != nil {
+		return nil, err
+	}
+
+	defined := map[string]bool{}
+	for i := range in.Items {
+		pr := in.Items[i] // So we don't take the address of a range variable.
+
+		// The supplied provider is not an owner of this PackageRevision.
+		if !containsID(pr.OwnerReferences, provider) {
+			continue
+		}
+
+		// We only want the active PackageRevision, and this isn't it.
+		if pr.Spec.DesiredState != pkgv1.PackageRevisionActive {
+			continue
+		}
+
+		for _, ref := range pr.Status.ObjectRefs {
+			if ref.Kind == "CustomResourceDefinition" && strings.Split(ref.APIVersion, "/")[0] == kextv1.GroupName {
+				defined[ref.Name] = true
+			}
+		}
+	}
+	return defined, nil
+}
+
 func containsID(in []metav1.OwnerReference, id model.ReferenceID) bool {
 	for _, ref := range in {
 		switch {
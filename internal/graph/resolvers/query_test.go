@@ -17,24 +17,31 @@ package resolvers
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 	corev1 "k8s.io/api/core/v1"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
 	"k8s.io/utils/ptr"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/errors"
 	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
 	pkgv1 "github.com/crossplane/crossplane/apis/pkg/v1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
 
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
@@ -49,8 +56,11 @@ func TestCrossplaneResourceTree(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type args struct {
-		ctx context.Context
-		id  model.ReferenceID
+		ctx      context.Context
+		id       model.ReferenceID
+		where    *model.ResourceFilter
+		kinds    []string
+		maxDepth *int
 	}
 	type want struct {
 		kr   model.CrossplaneResourceTreeConnection
@@ -60,6 +70,8 @@ func TestCrossplaneResourceTree(t *testing.T) {
 
 	namespace := "default"
 	deletionPolicyDelete := model.DeletionPolicyDelete
+	xr := model.CrossplaneResourceTreeRelationCompositeResource
+	composed := model.CrossplaneResourceTreeRelationComposedResource
 
 	cases := map[string]struct {
 		reason  string
@@ -160,22 +172,25 @@ func TestCrossplaneResourceTree(t *testing.T) {
 					},
 					{
 						ParentID: &model.ReferenceID{Namespace: "default", Name: "root"},
+						Relation: &xr,
 						Resource: model.CompositeResource{
 							ID:       model.ReferenceID{Name: "composite"},
 							Metadata: model.ObjectMeta{Name: "composite"},
-							Spec:     model.CompositeResourceSpec{ResourceReferences: []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}}},
+							Spec:     model.CompositeResourceSpec{ResourceReferences: []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}}, EnvironmentConfigReferences: []corev1.ObjectReference{}},
 						},
 					},
 					{
 						ParentID: &model.ReferenceID{Name: "composite"},
+						Relation: &composed,
 						Resource: model.CompositeResource{
 							ID:       model.ReferenceID{Name: "child-composite"},
 							Metadata: model.ObjectMeta{Name: "child-composite"},
-							Spec:     model.CompositeResourceSpec{ResourceReferences: []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}}},
+							Spec:     model.CompositeResourceSpec{ResourceReferences: []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}}, EnvironmentConfigReferences: []corev1.ObjectReference{}},
 						},
 					},
 					{
 						ParentID: &model.ReferenceID{Name: "child-composite"},
+						Relation: &composed,
 						Resource: model.ProviderConfig{
 							ID:       model.ReferenceID{Kind: "ProviderConfig", Name: "provider-config"},
 							Kind:     "ProviderConfig",
@@ -184,6 +199,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 					},
 					{
 						ParentID: &model.ReferenceID{Name: "child-composite"},
+						Relation: &composed,
 						Resource: model.ManagedResource{
 							ID:       model.ReferenceID{Name: "managed2"},
 							Metadata: model.ObjectMeta{Name: "managed2"},
@@ -192,6 +208,132 @@ func TestCrossplaneResourceTree(t *testing.T) {
 					},
 					{
 						ParentID: &model.ReferenceID{Name: "composite"},
+						Relation: &composed,
+						Resource: model.ManagedResource{
+							ID:       model.ReferenceID{Name: "managed1"},
+							Metadata: model.ObjectMeta{Name: "managed1"},
+							Spec:     model.ManagedResourceSpec{ProviderConfigRef: &model.ProviderConfigReference{}, DeletionPolicy: &deletionPolicyDelete},
+						},
+					},
+				}},
+			},
+		},
+		"SuccessWithFilter": {
+			reason: "We should only return resources in the tree that match the supplied filter.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						u := *obj.(*unstructured.Unstructured)
+						u.SetName(key.Name)
+
+						switch key.Name {
+						case "root":
+							u.SetNamespace(namespace)
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRef", &corev1.ObjectReference{Name: "composite"})
+						case "composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}})
+						case "child-composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}})
+						case "managed1":
+							fallthrough
+						case "managed2":
+							fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "")
+						case "provider-config":
+							u.SetKind("ProviderConfig")
+						default:
+							t.Fatalf("unknown get with name: %s", key.Name)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    model.ReferenceID{Name: "root"},
+				where: &model.ResourceFilter{Field: ptr.To("kind"), Eq: []byte(`"ProviderConfig"`)},
+			},
+			want: want{
+				kr: model.CrossplaneResourceTreeConnection{TotalCount: 1, Nodes: []model.CrossplaneResourceTreeNode{
+					{
+						Relation: &composed,
+						Resource: model.ProviderConfig{
+							ID:       model.ReferenceID{Kind: "ProviderConfig", Name: "provider-config"},
+							Kind:     "ProviderConfig",
+							Metadata: model.ObjectMeta{Name: "provider-config"},
+						},
+					},
+				}},
+			},
+		},
+		"SuccessWithFilterReparented": {
+			reason: "Nodes whose parents are filtered out should have the ID of their nearest ancestor that isn't.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						u := *obj.(*unstructured.Unstructured)
+						u.SetName(key.Name)
+
+						switch key.Name {
+						case "root":
+							u.SetNamespace(namespace)
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRef", &corev1.ObjectReference{Name: "composite"})
+						case "composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}})
+						case "child-composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}})
+						case "managed1":
+							fallthrough
+						case "managed2":
+							fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "")
+						case "provider-config":
+							u.SetKind("ProviderConfig")
+						default:
+							t.Fatalf("unknown get with name: %s", key.Name)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  model.ReferenceID{Name: "root"},
+				where: &model.ResourceFilter{Field: ptr.To("metadata.name"), In: [][]byte{
+					[]byte(`"root"`),
+					[]byte(`"child-composite"`),
+					[]byte(`"managed1"`),
+					[]byte(`"provider-config"`),
+				}},
+			},
+			want: want{
+				kr: model.CrossplaneResourceTreeConnection{TotalCount: 4, Nodes: []model.CrossplaneResourceTreeNode{
+					{
+						Resource: model.CompositeResourceClaim{
+							ID:       model.ReferenceID{Namespace: namespace, Name: "root"},
+							Metadata: model.ObjectMeta{Namespace: &namespace, Name: "root"},
+							Spec:     model.CompositeResourceClaimSpec{ResourceReference: &corev1.ObjectReference{Name: "composite"}},
+						},
+					},
+					{
+						ParentID: &model.ReferenceID{Namespace: "default", Name: "root"},
+						Relation: &composed,
+						Resource: model.CompositeResource{
+							ID:       model.ReferenceID{Name: "child-composite"},
+							Metadata: model.ObjectMeta{Name: "child-composite"},
+							Spec:     model.CompositeResourceSpec{ResourceReferences: []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}}, EnvironmentConfigReferences: []corev1.ObjectReference{}},
+						},
+					},
+					{
+						ParentID: &model.ReferenceID{Name: "child-composite"},
+						Relation: &composed,
+						Resource: model.ProviderConfig{
+							ID:       model.ReferenceID{Kind: "ProviderConfig", Name: "provider-config"},
+							Kind:     "ProviderConfig",
+							Metadata: model.ObjectMeta{Name: "provider-config"},
+						},
+					},
+					{
+						ParentID: &model.ReferenceID{Namespace: "default", Name: "root"},
+						Relation: &composed,
 						Resource: model.ManagedResource{
 							ID:       model.ReferenceID{Name: "managed1"},
 							Metadata: model.ObjectMeta{Name: "managed1"},
@@ -201,6 +343,161 @@ func TestCrossplaneResourceTree(t *testing.T) {
 				}},
 			},
 		},
+		"SuccessWithKinds": {
+			reason: "We should only return resources in the tree of the supplied kinds.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						u := *obj.(*unstructured.Unstructured)
+						u.SetName(key.Name)
+
+						switch key.Name {
+						case "root":
+							u.SetNamespace(namespace)
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRef", &corev1.ObjectReference{Name: "composite"})
+						case "composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}})
+						case "child-composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}})
+						case "managed1":
+							fallthrough
+						case "managed2":
+							fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "")
+						case "provider-config":
+							u.SetKind("ProviderConfig")
+						default:
+							t.Fatalf("unknown get with name: %s", key.Name)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    model.ReferenceID{Name: "root"},
+				kinds: []string{"ProviderConfig"},
+			},
+			want: want{
+				kr: model.CrossplaneResourceTreeConnection{TotalCount: 1, Nodes: []model.CrossplaneResourceTreeNode{
+					{
+						Relation: &composed,
+						Resource: model.ProviderConfig{
+							ID:       model.ReferenceID{Kind: "ProviderConfig", Name: "provider-config"},
+							Kind:     "ProviderConfig",
+							Metadata: model.ObjectMeta{Name: "provider-config"},
+						},
+					},
+				}},
+			},
+		},
+		"SuccessWithMaxDepth": {
+			reason: "We should not traverse the tree beyond the supplied maximum depth.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						u := *obj.(*unstructured.Unstructured)
+						u.SetName(key.Name)
+
+						switch key.Name {
+						case "root":
+							u.SetNamespace(namespace)
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRef", &corev1.ObjectReference{Name: "composite"})
+						case "composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}})
+						case "child-composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}})
+						case "managed1":
+							fallthrough
+						case "managed2":
+							fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "")
+						case "provider-config":
+							u.SetKind("ProviderConfig")
+						default:
+							t.Fatalf("unknown get with name: %s", key.Name)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:       model.ReferenceID{Name: "root"},
+				maxDepth: ptr.To(1),
+			},
+			want: want{
+				kr: model.CrossplaneResourceTreeConnection{TotalCount: 2, Nodes: []model.CrossplaneResourceTreeNode{
+					{
+						Resource: model.CompositeResourceClaim{
+							ID:       model.ReferenceID{Namespace: namespace, Name: "root"},
+							Metadata: model.ObjectMeta{Namespace: &namespace, Name: "root"},
+							Spec:     model.CompositeResourceClaimSpec{ResourceReference: &corev1.ObjectReference{Name: "composite"}},
+						},
+					},
+					{
+						ParentID: &model.ReferenceID{Namespace: "default", Name: "root"},
+						Relation: &xr,
+						Resource: model.CompositeResource{
+							ID:       model.ReferenceID{Name: "composite"},
+							Metadata: model.ObjectMeta{Name: "composite"},
+							Spec:     model.CompositeResourceSpec{ResourceReferences: []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}}, EnvironmentConfigReferences: []corev1.ObjectReference{}},
+						},
+					},
+				}},
+			},
+		},
+		"NegativeMaxDepth": {
+			reason: "We should return an error if the supplied maximum depth is negative.",
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:       model.ReferenceID{Name: "root"},
+				maxDepth: ptr.To(-1),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.New(errNegativeMaxDepth)),
+				},
+			},
+		},
+		"FilterError": {
+			reason: "If we can't evaluate the supplied filter we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						u := *obj.(*unstructured.Unstructured)
+						u.SetName(key.Name)
+
+						switch key.Name {
+						case "root":
+							u.SetNamespace(namespace)
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRef", &corev1.ObjectReference{Name: "composite"})
+						case "composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed1"}, {Name: "child-composite"}})
+						case "child-composite":
+							fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed2"}, {Name: "provider-config"}})
+						case "managed1":
+							fallthrough
+						case "managed2":
+							fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "")
+						case "provider-config":
+							u.SetKind("ProviderConfig")
+						default:
+							t.Fatalf("unknown get with name: %s", key.Name)
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:    model.ReferenceID{Name: "root"},
+				where: &model.ResourceFilter{Exists: ptr.To(true)},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.New("a field is required by the eq, ne, in, exists and regex predicates"), errFilter)),
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -209,7 +506,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.CrossplaneResourceTree(tc.args.ctx, tc.args.id)
+			got, err := q.CrossplaneResourceTree(tc.args.ctx, tc.args.id, tc.args.where, tc.args.kinds, tc.args.maxDepth, nil, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -224,6 +521,7 @@ func TestCrossplaneResourceTree(t *testing.T) {
 				cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess"),
 				cmpopts.IgnoreFields(model.ManagedResource{}, "PavedAccess"),
 				cmpopts.IgnoreFields(model.ProviderConfig{}, "PavedAccess"),
+				cmpopts.IgnoreFields(model.CrossplaneResourceTreeConnection{}, "Edges", "PageInfo"),
 				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
 			}
 
@@ -234,17 +532,49 @@ func TestCrossplaneResourceTree(t *testing.T) {
 	}
 }
 
-func TestQueryKubernetesResource(t *testing.T) {
+func TestQueryTrace(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	gkr, _ := model.GetKubernetesResource(&unstructured.Unstructured{})
+	namespace := "default"
+	then := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// The managed resource can't be synced, so it's the root cause of its
+	// composite resource's unreadiness, which is in turn the cause of the
+	// claim's unreadiness.
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		u := obj.(*unstructured.Unstructured)
+		u.SetName(key.Name)
+
+		switch key.Name {
+		case "root":
+			u.SetNamespace(namespace)
+			_ = fieldpath.Pave(u.Object).SetValue("spec.resourceRef", &corev1.ObjectReference{Name: "composite"})
+			_ = fieldpath.Pave(u.Object).SetValue("status.conditions", []xpv1.Condition{xpv1.Creating()})
+		case "composite":
+			_ = fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []corev1.ObjectReference{{Name: "managed"}})
+			_ = fieldpath.Pave(u.Object).SetValue("status.conditions", []xpv1.Condition{xpv1.Creating(), xpv1.ReconcileSuccess()})
+		case "managed":
+			_ = fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "default")
+			_ = fieldpath.Pave(u.Object).SetValue("status.conditions", []xpv1.Condition{xpv1.ReconcileError(errBoom)})
+		}
+		return nil
+	}
+
+	warning := corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "warning"},
+		InvolvedObject: corev1.ObjectReference{Name: "managed"},
+		Type:           corev1.EventTypeWarning,
+		Message:        "boom",
+		LastTimestamp:  then,
+	}
+	gwarning := model.GetEvent(&warning)
 
 	type args struct {
 		ctx context.Context
 		id  model.ReferenceID
 	}
 	type want struct {
-		kr   model.KubernetesResource
+		tc   model.TraceConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -255,8 +585,8 @@ func TestQueryKubernetesResource(t *testing.T) {
 		args    args
 		want    want
 	}{
-		"GetClientError": {
-			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+		"GetKubernetesResourceError": {
+			reason: "If we can't get the root resource we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{}, errBoom
 			}),
@@ -269,34 +599,65 @@ func TestQueryKubernetesResource(t *testing.T) {
 				},
 			},
 		},
-		"GetKubernetesResourceError": {
-			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
+		"ListEventsError": {
+			reason: "If we can't list events we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockGet: test.NewMockGetFn(errBoom),
+					MockGet:  get,
+					MockList: test.NewMockListFn(errBoom),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  model.ReferenceID{Namespace: namespace, Name: "root"},
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errGetResource)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListEvents)),
 				},
 			},
 		},
 		"Success": {
-			reason: "If we can get and model the resource we should return it.",
+			reason: "We should explain the health of every resource in the tree, and identify the root cause.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockGet: test.NewMockGetFn(nil),
+					MockGet: get,
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						obj.(*corev1.EventList).Items = []corev1.Event{warning}
+						return nil
+					}),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  model.ReferenceID{Namespace: namespace, Name: "root"},
 			},
 			want: want{
-				kr: gkr,
+				tc: model.TraceConnection{TotalCount: 3, Nodes: []model.TraceNode{
+					{
+						Health: model.ResourceHealth{
+							Ready:            ptr.To(model.ConditionStatusFalse),
+							FailingCondition: &model.GetConditions([]xpv1.Condition{xpv1.Creating()})[0],
+						},
+					},
+					{
+						ParentID: &model.ReferenceID{Namespace: namespace, Name: "root"},
+						Health: model.ResourceHealth{
+							Ready:            ptr.To(model.ConditionStatusFalse),
+							Synced:           ptr.To(model.ConditionStatusTrue),
+							FailingCondition: &model.GetConditions([]xpv1.Condition{xpv1.Creating()})[0],
+						},
+					},
+					{
+						ParentID: &model.ReferenceID{Name: "composite"},
+						Health: model.ResourceHealth{
+							Synced:             ptr.To(model.ConditionStatusFalse),
+							FailingCondition:   &model.GetConditions([]xpv1.Condition{xpv1.ReconcileError(errBoom)})[0],
+							LatestWarningEvent: &gwarning,
+							RootCause:          true,
+						},
+					},
+				}},
 			},
 		},
 	}
@@ -307,48 +668,117 @@ func TestQueryKubernetesResource(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.KubernetesResource(tc.args.ctx, tc.args.id)
+			got, err := q.Trace(tc.args.ctx, tc.args.id, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.KubernetesResource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Trace(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.KubernetesResource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Trace(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
-				t.Errorf("\n%s\ns.KubernetesResource(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.tc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.EquateApproxTime(time.Second),
+				cmpopts.IgnoreFields(model.TraceConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreFields(model.TraceNode{}, "Resource"),
+				cmpopts.IgnoreFields(model.ResourceHealth{}, "LastTransitionTime", "SecondsSinceLastTransition"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.Event{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.Trace(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryKubernetesResources(t *testing.T) {
+func TestQueryHealthSummary(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	kr := unstructured.Unstructured{}
-	gkr, _ := model.GetKubernetesResource(&kr)
+	cnd := func(t xpv1.ConditionType, s corev1.ConditionStatus, r xpv1.ConditionReason) xpv1.Condition {
+		return xpv1.Condition{Type: t, Status: s, Reason: r}
+	}
 
-	group := "example.org"
-	version := "v1"
-	apiVersion := schema.GroupVersion{Group: group, Version: version}.String()
-	kind := "Example"
+	xrd := extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xbuckets.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XBucket", ListKind: "XBucketList"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Bucket", ListKind: "BucketList"},
+			Versions:   []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}},
+		},
+	}
+	xrd.Status.SetConditions(xpv1.Condition{Type: extv1.TypeEstablished, Status: corev1.ConditionTrue})
 
-	// In almost all real cases this would be 'ExampleList', but we infer that
-	// when ListKind is not set, and want to test that this will override it.
-	listKind := "Examples"
+	crd := xunstructured.NewCRD()
+	crd.SetName("buckets.s3.example.org")
+	crd.SetSpecGroup("s3.example.org")
+	crd.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: "Bucket", ListKind: "BucketList", Categories: []string{"managed"}})
+	crd.SetSpecVersions([]kextv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true}})
 
-	ns := "default"
+	resource := func(apiVersion, kind, name string, c ...xpv1.Condition) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetName(name)
+		_ = fieldpath.Pave(u.Object).SetValue("status.conditions", c)
+		return u
+	}
+	xrc := resource("example.org/v1", "Bucket", "coolclaim", cnd(xpv1.TypeReady, corev1.ConditionFalse, xpv1.ReasonCreating))
+	xrc.SetNamespace("default")
+	xr := resource("example.org/v1", "XBucket", "coolxr", cnd(xpv1.TypeReady, corev1.ConditionFalse, xpv1.ReasonCreating), cnd(xpv1.TypeSynced, corev1.ConditionTrue, xpv1.ReasonReconcileSuccess))
+	mr := resource("s3.example.org/v1", "Bucket", "coolmr", cnd(xpv1.TypeSynced, corev1.ConditionFalse, xpv1.ReasonReconcileError))
+
+	provider := pkgv1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "coolprovider"}}
+	provider.SetConditions(cnd(pkgv1.TypeHealthy, corev1.ConditionTrue, "HealthyPackageRevision"))
+	config := pkgv1.Configuration{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	config.SetConditions(cnd(pkgv1.TypeHealthy, corev1.ConditionFalse, "UnhealthyPackageRevision"))
+
+	// Like the cache, only return resources in the namespace we list, if any.
+	// Cluster scoped resources are not in any namespace.
+	list := func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+		lo := &client.ListOptions{}
+		lo.ApplyOptions(opts)
+
+		switch l := obj.(type) {
+		case *extv1.CompositeResourceDefinitionList:
+			l.Items = []extv1.CompositeResourceDefinition{xrd}
+		case *pkgv1.ProviderList:
+			l.Items = []pkgv1.Provider{provider}
+		case *pkgv1.ConfigurationList:
+			l.Items = []pkgv1.Configuration{config}
+		case *unstructured.UnstructuredList:
+			switch l.GetAPIVersion() + " " + l.GetKind() {
+			case "apiextensions.k8s.io/v1 CustomResourceDefinitionList":
+				l.Items = []unstructured.Unstructured{*crd.GetUnstructured()}
+			case "example.org/v1 BucketList":
+				l.Items = []unstructured.Unstructured{xrc}
+			case "example.org/v1 XBucketList":
+				l.Items = []unstructured.Unstructured{xr}
+			case "s3.example.org/v1 BucketList":
+				l.Items = []unstructured.Unstructured{mr}
+			}
+			if lo.Namespace != "" {
+				items := []unstructured.Unstructured{}
+				for _, u := range l.Items {
+					if u.GetNamespace() == lo.Namespace {
+						items = append(items, u)
+					}
+				}
+				l.Items = items
+			}
+		}
+		return nil
+	}
+
+	absent := model.ConditionSummary{AbsentCount: 1}
 
 	type args struct {
-		ctx        context.Context
-		apiVersion string
-		kind       string
-		listKind   *string
-		namespace  *string
+		ctx       context.Context
+		namespace *string
 	}
 	type want struct {
-		krc  model.KubernetesResourceConnection
+		hs   model.HealthSummary
 		err  error
 		errs gqlerror.List
 	}
@@ -362,7 +792,7 @@ func TestQueryKubernetesResources(t *testing.T) {
 		"GetClientError": {
 			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{}, errBoom
+				return nil, errBoom
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
@@ -373,8 +803,8 @@ func TestQueryKubernetesResources(t *testing.T) {
 				},
 			},
 		},
-		"ListKubernetesResourcesError": {
-			reason: "If we can't list defined claims we should add the error to the GraphQL context and return early.",
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
@@ -385,106 +815,58 @@ func TestQueryKubernetesResources(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListResources)),
-				},
-			},
-		},
-		"GVKOnly": {
-			reason: "We should successfully return any Kubernetes resources of the specified GVK that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						u := *obj.(*unstructured.UnstructuredList)
-
-						// Ensure we're being asked to list the expected GVK.
-						got := u.GetObjectKind().GroupVersionKind()
-						want := schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"}
-						if diff := cmp.Diff(want, got); diff != "" {
-							t.Errorf("-want GVK, +got GVK:\n%s", diff)
-						}
-
-						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
-						return nil
-					}),
-				}, nil
-			}),
-			args: args{
-				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				apiVersion: apiVersion,
-				kind:       kind,
-			},
-			want: want{
-				krc: model.KubernetesResourceConnection{
-					Nodes:      []model.KubernetesResource{gkr},
-					TotalCount: 1,
+					gqlerror.Wrap(errors.Wrap(errBoom, errListXRDs)),
 				},
 			},
 		},
-		"WithListKind": {
-			reason: "We should successfully list, model, and return resources of a bespoke listKind.",
+		"Success": {
+			reason: "We should summarize the health of each kind of resource, only filtering claims by namespace.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						u := *obj.(*unstructured.UnstructuredList)
-
-						// Ensure we're being asked to list the expected GVK.
-						got := u.GetObjectKind().GroupVersionKind()
-						want := schema.GroupVersionKind{Group: group, Version: version, Kind: listKind}
-						if diff := cmp.Diff(want, got); diff != "" {
-							t.Errorf("-want GVK, +got GVK:\n%s", diff)
-						}
-
-						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
-						return nil
-					}),
+					MockList: list,
 				}, nil
 			}),
 			args: args{
-				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				apiVersion: apiVersion,
-				kind:       kind,
-				listKind:   &listKind,
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: ptr.To("default"),
 			},
 			want: want{
-				krc: model.KubernetesResourceConnection{
-					Nodes:      []model.KubernetesResource{gkr},
-					TotalCount: 1,
-				},
-			},
-		},
-		"WithNamespace": {
-			reason: "We should successfully list, model, and return resources from within a specific namespace.",
-			clients: ClientCacheFn(func(_ auth.Credentials, o ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
-						if len(opts) != 1 {
-							t.Errorf("Expected 1 ListOption, got %d", len(opts))
-						}
-						u := *list.(*unstructured.UnstructuredList)
-
-						// Ensure we're being asked to list the expected GVK.
-						got := u.GetObjectKind().GroupVersionKind()
-						want := schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"}
-						if diff := cmp.Diff(want, got); diff != "" {
-							t.Errorf("-want GVK, +got GVK:\n%s", diff)
-						}
-
-						*list.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
-						return nil
-
+				hs: model.HealthSummary{
+					Claims: model.ResourceHealthSummary{
+						Total:   1,
+						Ready:   model.ConditionSummary{FalseCount: 1, Reasons: []model.ReasonCount{{Reason: "Creating", Count: 1}}},
+						Synced:  absent,
+						Healthy: absent,
+					},
+					CompositeResources: model.ResourceHealthSummary{
+						Total:   1,
+						Ready:   model.ConditionSummary{FalseCount: 1, Reasons: []model.ReasonCount{{Reason: "Creating", Count: 1}}},
+						Synced:  model.ConditionSummary{TrueCount: 1, Reasons: []model.ReasonCount{{Reason: "ReconcileSuccess", Count: 1}}},
+						Healthy: absent,
+					},
+					ManagedResources: model.ResourceHealthSummary{
+						Total:   1,
+						Ready:   absent,
+						Synced:  model.ConditionSummary{FalseCount: 1, Reasons: []model.ReasonCount{{Reason: "ReconcileError", Count: 1}}},
+						Healthy: absent,
+					},
+					Providers: model.ResourceHealthSummary{
+						Total:   1,
+						Ready:   absent,
+						Synced:  absent,
+						Healthy: model.ConditionSummary{TrueCount: 1, Reasons: []model.ReasonCount{{Reason: "HealthyPackageRevision", Count: 1}}},
+					},
+					Configurations: model.ResourceHealthSummary{
+						Total:   1,
+						Ready:   absent,
+						Synced:  absent,
+						Healthy: model.ConditionSummary{FalseCount: 1, Reasons: []model.ReasonCount{{Reason: "UnhealthyPackageRevision", Count: 1}}},
+					},
+					TopFailingReasons: []model.FailingReason{
+						{Type: "Ready", Reason: "Creating", Count: 2},
+						{Type: "Healthy", Reason: "UnhealthyPackageRevision", Count: 1},
+						{Type: "Synced", Reason: "ReconcileError", Count: 1},
 					},
-				}, nil
-			}),
-			args: args{
-				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				apiVersion: apiVersion,
-				kind:       kind,
-				namespace:  &ns,
-			},
-			want: want{
-				krc: model.KubernetesResourceConnection{
-					Nodes:      []model.KubernetesResource{gkr},
-					TotalCount: 1,
 				},
 			},
 		},
@@ -496,34 +878,33 @@ func TestQueryKubernetesResources(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.KubernetesResources(tc.args.ctx, tc.args.apiVersion, tc.args.kind, tc.args.listKind, tc.args.namespace)
+			got, err := q.HealthSummary(tc.args.ctx, tc.args.namespace)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.HealthSummary(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.HealthSummary(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.krc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.hs, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nq.HealthSummary(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQuerySecret(t *testing.T) {
+func TestQueryKubernetesResource(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	gsec := model.GetSecret(&corev1.Secret{})
+	gkr, _ := model.GetKubernetesResource(&unstructured.Unstructured{})
 
 	type args struct {
-		ctx       context.Context
-		namespace string
-		name      string
+		ctx context.Context
+		id  model.ReferenceID
 	}
 	type want struct {
-		sec  *model.Secret
+		kr   model.KubernetesResource
 		err  error
 		errs gqlerror.List
 	}
@@ -548,8 +929,8 @@ func TestQuerySecret(t *testing.T) {
 				},
 			},
 		},
-		"GetSecretError": {
-			reason: "If we can't get the secret we should add the error to the GraphQL context and return early.",
+		"GetKubernetesResourceError": {
+			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
@@ -560,12 +941,12 @@ func TestQuerySecret(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errGetSecret)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetResource)),
 				},
 			},
 		},
 		"Success": {
-			reason: "If we can get and model the secret we should return it.",
+			reason: "If we can get and model the resource we should return it.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(nil),
@@ -575,7 +956,7 @@ func TestQuerySecret(t *testing.T) {
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				sec: &gsec,
+				kr: gkr,
 			},
 		},
 	}
@@ -586,34 +967,50 @@ func TestQuerySecret(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.Secret(tc.args.ctx, tc.args.namespace, tc.args.name)
+			got, err := q.KubernetesResource(tc.args.ctx, tc.args.id)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.Secret(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\ns.KubernetesResource(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.Secret(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\ns.KubernetesResource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.sec, got, cmp.AllowUnexported(model.Secret{}), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\ns.Secret(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreFields(model.GenericResource{}, "PavedAccess"), cmpopts.IgnoreUnexported(model.ObjectMeta{})); diff != "" {
+				t.Errorf("\n%s\ns.KubernetesResource(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryConfigMap(t *testing.T) {
+func TestQueryAncestors(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	gsec := model.GetConfigMap(&corev1.ConfigMap{})
+	// A composite resource, which controls the managed resource.
+	xr := unstructured.Unstructured{}
+	xr.SetAPIVersion("example.org/v1")
+	xr.SetKind("XBucket")
+	xr.SetName("coolxr")
+	_ = fieldpath.Pave(xr.Object).SetValue("spec.compositionRef.name", "cool")
+	gxr, _ := model.GetKubernetesResource(&xr)
+
+	mr := unstructured.Unstructured{}
+	mr.SetAPIVersion("example.org/v1")
+	mr.SetKind("S3Bucket")
+	mr.SetName("coolmr")
+	mr.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion: xr.GetAPIVersion(),
+		Kind:       xr.GetKind(),
+		Name:       xr.GetName(),
+		Controller: ptr.To(true),
+	}})
 
 	type args struct {
-		ctx       context.Context
-		namespace string
-		name      string
+		ctx context.Context
+		id  model.ReferenceID
 	}
 	type want struct {
-		cm   *model.ConfigMap
+		krc  model.KubernetesResourceConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -638,8 +1035,8 @@ func TestQueryConfigMap(t *testing.T) {
 				},
 			},
 		},
-		"GetConfigMapError": {
-			reason: "If we can't get the config map we should add the error to the GraphQL context and return early.",
+		"GetResourceError": {
+			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockGet: test.NewMockGetFn(errBoom),
@@ -650,22 +1047,35 @@ func TestQueryConfigMap(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errGetConfigMap)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetResource)),
 				},
 			},
 		},
 		"Success": {
-			reason: "If we can get and model the config map we should return it.",
+			reason: "We should return the ancestors of the resource.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockGet: test.NewMockGetFn(nil),
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						u := obj.(*unstructured.Unstructured)
+						switch u.GetKind() {
+						case mr.GetKind():
+							*u = *mr.DeepCopy()
+						case xr.GetKind():
+							*u = *xr.DeepCopy()
+						}
+						return nil
+					}),
 				}, nil
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  model.ReferenceID{APIVersion: mr.GetAPIVersion(), Kind: mr.GetKind(), Name: mr.GetName()},
 			},
 			want: want{
-				cm: &gsec,
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gxr},
+					TotalCount: 1,
+				},
 			},
 		},
 	}
@@ -676,33 +1086,69 @@ func TestQueryConfigMap(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.ConfigMap(tc.args.ctx, tc.args.namespace, tc.args.name)
+			got, err := q.Ancestors(tc.args.ctx, tc.args.id, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.ConfigMap(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Ancestors(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ns.ConfigMap(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Ancestors(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.cm, got, cmp.AllowUnexported(model.ConfigMap{}), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\ns.ConfigMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.krc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.KubernetesResourceConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.Ancestors(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryProviders(t *testing.T) {
+func TestQueryKubernetesResources(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	p := pkgv1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "coolprovider"}}
-	gp := model.GetProvider(&p)
+	kr := unstructured.Unstructured{}
+	gkr, _ := model.GetKubernetesResource(&kr)
+	idkr := gkr.(model.GenericResource).ID
+	ckr := idkr.String()
+
+	kr2 := unstructured.Unstructured{}
+	kr2.SetName("b")
+	gkr2, _ := model.GetKubernetesResource(&kr2)
+	idkr2 := gkr2.(model.GenericResource).ID
+	ckr2 := idkr2.String()
+
+	group := "example.org"
+	version := "v1"
+	apiVersion := schema.GroupVersion{Group: group, Version: version}.String()
+	kind := "Example"
+
+	// In almost all real cases this would be 'ExampleList', but we infer that
+	// when ListKind is not set, and want to test that this will override it.
+	listKind := "Examples"
+
+	ns := "default"
 
 	type args struct {
-		ctx context.Context
+		ctx           context.Context
+		apiVersion    string
+		kind          string
+		listKind      *string
+		namespace     *string
+		labelSelector *model.LabelSelectorInput
+		fieldSelector *string
+		where         *model.ResourceFilter
+		orderBy       []model.OrderBy
+		first         *int
+		after         *string
+		last          *int
+		before        *string
 	}
 	type want struct {
-		pc   model.ProviderConnection
+		krc  model.KubernetesResourceConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -727,8 +1173,8 @@ func TestQueryProviders(t *testing.T) {
 				},
 			},
 		},
-		"ListProvidersError": {
-			reason: "If we can't list providers we should add the error to the GraphQL context and return early.",
+		"ListKubernetesResourcesError": {
+			reason: "If we can't list defined claims we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
@@ -739,26 +1185,2127 @@ func TestQueryProviders(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListProviders)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListResources)),
 				},
 			},
 		},
-		"Success": {
-			reason: "We should successfully return any providers we can list and model.",
+		"GVKOnly": {
+			reason: "We should successfully return any Kubernetes resources of the specified GVK that we can list and model.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ProviderList) = pkgv1.ProviderList{Items: []pkgv1.Provider{p}}
-						return nil
-					}),
-				}, nil
-			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+						u := *obj.(*unstructured.UnstructuredList)
+
+						// Ensure we're being asked to list the expected GVK.
+						got := u.GetObjectKind().GroupVersionKind()
+						want := schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"}
+						if diff := cmp.Diff(want, got); diff != "" {
+							t.Errorf("-want GVK, +got GVK:\n%s", diff)
+						}
+
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr, Node: gkr}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr, EndCursor: &ckr},
+					TotalCount: 1,
+				},
+			},
+		},
+		"WithListKind": {
+			reason: "We should successfully list, model, and return resources of a bespoke listKind.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						u := *obj.(*unstructured.UnstructuredList)
+
+						// Ensure we're being asked to list the expected GVK.
+						got := u.GetObjectKind().GroupVersionKind()
+						want := schema.GroupVersionKind{Group: group, Version: version, Kind: listKind}
+						if diff := cmp.Diff(want, got); diff != "" {
+							t.Errorf("-want GVK, +got GVK:\n%s", diff)
+						}
+
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				listKind:   &listKind,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr, Node: gkr}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr, EndCursor: &ckr},
+					TotalCount: 1,
+				},
+			},
+		},
+		"WithNamespace": {
+			reason: "We should successfully list, model, and return resources from within a specific namespace.",
+			clients: ClientCacheFn(func(_ auth.Credentials, o ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						if len(opts) != 1 {
+							t.Errorf("Expected 1 ListOption, got %d", len(opts))
+						}
+						u := *list.(*unstructured.UnstructuredList)
+
+						// Ensure we're being asked to list the expected GVK.
+						got := u.GetObjectKind().GroupVersionKind()
+						want := schema.GroupVersionKind{Group: group, Version: version, Kind: kind + "List"}
+						if diff := cmp.Diff(want, got); diff != "" {
+							t.Errorf("-want GVK, +got GVK:\n%s", diff)
+						}
+
+						*list.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
+						return nil
+
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				namespace:  &ns,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr, Node: gkr}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr, EndCursor: &ckr},
+					TotalCount: 1,
+				},
+			},
+		},
+		"LabelSelectorError": {
+			reason: "If we can't parse the supplied label selector we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			args: args{
+				ctx:           graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion:    apiVersion,
+				kind:          kind,
+				labelSelector: &model.LabelSelectorInput{Selector: ptr.To("!")},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.New("unable to parse requirement: found '', expected: identifier"), errLabelSelector)),
+				},
+			},
+		},
+		"FieldSelectorError": {
+			reason: "If we can't parse the supplied field selector we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, nil
+			}),
+			args: args{
+				ctx:           graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion:    apiVersion,
+				kind:          kind,
+				fieldSelector: ptr.To("metadata.name"),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.New(`invalid selector: 'metadata.name'; can't understand 'metadata.name'`), errFieldSelector)),
+				},
+			},
+		},
+		"WithLabelSelector": {
+			reason: "We should pass the supplied label selector to the list call.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+						lo := &client.ListOptions{}
+						lo.ApplyOptions(opts)
+						if diff := cmp.Diff("team=payments", lo.LabelSelector.String()); diff != "" {
+							t.Errorf("-want label selector, +got label selector:\n%s", diff)
+						}
+						*list.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:           graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion:    apiVersion,
+				kind:          kind,
+				labelSelector: &model.LabelSelectorInput{MatchLabels: map[string]string{"team": "payments"}},
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr, Node: gkr}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr, EndCursor: &ckr},
+					TotalCount: 1,
+				},
+			},
+		},
+		"WithFieldSelector": {
+			reason: "We should only return resources that match the supplied field selector.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr2, kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:           graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion:    apiVersion,
+				kind:          kind,
+				fieldSelector: ptr.To("metadata.name=b"),
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr2},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr2, Node: gkr2}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr2, EndCursor: &ckr2},
+					TotalCount: 1,
+				},
+			},
+		},
+		"WithFilter": {
+			reason: "We should only return resources that match the supplied filter.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr2, kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				where: &model.ResourceFilter{
+					Or: []model.ResourceFilter{
+						{Field: ptr.To("metadata.name"), Eq: []byte(`"b"`)},
+						{Field: ptr.To("metadata.name"), In: [][]byte{[]byte(`"c"`), []byte(`"d"`)}},
+					},
+				},
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr2},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr2, Node: gkr2}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr2, EndCursor: &ckr2},
+					TotalCount: 1,
+				},
+			},
+		},
+		"FilterError": {
+			reason: "If we can't compile the supplied filter we should add the error to the GraphQL context and return early, without listing any resources.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				where:      &model.ResourceFilter{Field: ptr.To("metadata.name"), Regex: ptr.To("(")},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.Wrap(errors.New("error parsing regexp: missing closing ): `(`"), `cannot compile regex "("`), errFilter)),
+				},
+			},
+		},
+		"Ordered": {
+			reason: "We should sort resources by the supplied orders, with missing values sorted last.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr, kr2}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				orderBy:    []model.OrderBy{{Field: "metadata.name", Direction: ptr.To(model.OrderDirectionDesc)}},
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr2, gkr},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr2, Node: gkr2}, {Cursor: ckr, Node: gkr}},
+					PageInfo:   model.PageInfo{StartCursor: &ckr2, EndCursor: &ckr},
+					TotalCount: 2,
+				},
+			},
+		},
+		"OrderError": {
+			reason: "If we can't sort resources by the supplied orders we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr, kr2}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				orderBy:    []model.OrderBy{{Field: "metadata..name"}},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.Wrap(errors.New("unexpected '.' at position 9"), `cannot parse order field "metadata..name"`), errOrder)),
+				},
+			},
+		},
+		"Paginated": {
+			reason: "We should return only the requested page of resources.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr2, kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				first:      ptr.To(1),
+				after:      &ckr,
+			},
+			want: want{
+				krc: model.KubernetesResourceConnection{
+					Nodes:      []model.KubernetesResource{gkr2},
+					Edges:      []model.KubernetesResourceEdge{{Cursor: ckr2, Node: gkr2}},
+					PageInfo:   model.PageInfo{HasPreviousPage: true, StartCursor: &ckr2, EndCursor: &ckr2},
+					TotalCount: 2,
+				},
+			},
+		},
+		"PaginateError": {
+			reason: "If we can't find the supplied cursor we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{Items: []unstructured.Unstructured{kr}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:        graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				apiVersion: apiVersion,
+				kind:       kind,
+				before:     &ckr2,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errors.Errorf("cannot find cursor %q in connection", ckr2), errPaginate)),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.KubernetesResources(tc.args.ctx, tc.args.apiVersion, tc.args.kind, tc.args.listKind, tc.args.namespace, tc.args.labelSelector, tc.args.fieldSelector, tc.args.where, tc.args.orderBy, tc.args.first, tc.args.after, tc.args.last, tc.args.before)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.krc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.DefinedCompositeResourceClaims(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryManagedResources(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ProviderKind,
+		Name:       "coolprovider",
+	}
+
+	crd := func(name, kind string, categories ...string) unstructured.Unstructured {
+		crd := xunstructured.NewCRD()
+		crd.SetName(name)
+		crd.SetSpecGroup("example.org")
+		crd.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: kind, ListKind: kind + "List", Categories: categories})
+		crd.SetSpecVersions([]kextv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true}})
+		return *crd.GetUnstructured()
+	}
+	buckets := crd("buckets.example.org", "Bucket", "crossplane", "managed")
+	instances := crd("instances.example.org", "Instance", "managed")
+	configs := crd("providerconfigs.example.org", "ProviderConfig", "crossplane")
+
+	// The active ProviderRevision of our provider, which defines buckets.
+	active := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+		Status: pkgv1.PackageRevisionStatus{
+			ObjectRefs: []xpv1.TypedReference{{APIVersion: kextv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition", Name: buckets.GetName()}},
+		},
+	}
+
+	bucket := unstructured.Unstructured{}
+	bucket.SetAPIVersion("example.org/v1")
+	bucket.SetKind("Bucket")
+	bucket.SetName("coolbucket")
+	_ = fieldpath.Pave(bucket.Object).SetValue("status.conditions", []any{map[string]any{"type": "Ready", "status": "True"}})
+	gbucket := model.GetManagedResource(&bucket)
+
+	instance := unstructured.Unstructured{}
+	instance.SetAPIVersion("example.org/v1")
+	instance.SetKind("Instance")
+	instance.SetName("coolinstance")
+	ginstance := model.GetManagedResource(&instance)
+
+	list := func(t *testing.T) func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *pkgv1.ProviderRevisionList:
+				l.Items = []pkgv1.ProviderRevision{active}
+			case *unstructured.UnstructuredList:
+				switch l.GetKind() {
+				case "CustomResourceDefinitionList":
+					l.Items = []unstructured.Unstructured{buckets, instances, configs}
+				case "BucketList":
+					l.Items = []unstructured.Unstructured{bucket}
+				case "InstanceList":
+					l.Items = []unstructured.Unstructured{instance}
+				default:
+					t.Errorf("unexpected list of kind %q", l.GetKind())
+				}
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx      context.Context
+		provider *model.ReferenceID
+		ready    *bool
+		synced   *bool
+	}
+	type want struct {
+		mrc  model.ManagedResourceConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListProviderRevisionsError": {
+			reason: "If we can't list provider revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				provider: &id,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviderRevs)),
+				},
+			},
+		},
+		"ListCRDsError": {
+			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListCRDs)),
+				},
+			},
+		},
+		"AllManagedResources": {
+			reason: "We should return managed resources of every kind in the managed category.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				mrc: model.ManagedResourceConnection{
+					Nodes:      []model.ManagedResource{gbucket, ginstance},
+					TotalCount: 2,
+				},
+			},
+		},
+		"ProviderManagedResources": {
+			reason: "We should only return managed resources of kinds defined by the supplied provider's active revision.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				provider: &id,
+			},
+			want: want{
+				mrc: model.ManagedResourceConnection{
+					Nodes:      []model.ManagedResource{gbucket},
+					TotalCount: 1,
+				},
+			},
+		},
+		"NotReady": {
+			reason: "We should only return managed resources that aren't ready.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ready: ptr.To(false),
+			},
+			want: want{
+				mrc: model.ManagedResourceConnection{
+					Nodes:      []model.ManagedResource{ginstance},
+					TotalCount: 1,
+				},
+			},
+		},
+		"Synced": {
+			reason: "We should only return managed resources that are synced.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				synced: ptr.To(true),
+			},
+			want: want{
+				mrc: model.ManagedResourceConnection{},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.ManagedResources(tc.args.ctx, tc.args.provider, tc.args.ready, tc.args.synced, nil, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.ManagedResources(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.ManagedResources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.mrc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.ManagedResourceConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.ManagedResource{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.ManagedResources(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryProviderConfigs(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ProviderKind,
+		Name:       "coolprovider",
+	}
+
+	crd := func(name, group, kind string, scope kextv1.ResourceScope) unstructured.Unstructured {
+		crd := xunstructured.NewCRD()
+		crd.SetName(name)
+		crd.SetSpecGroup(group)
+		crd.SetSpecScope(scope)
+		crd.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: kind, ListKind: kind + "List"})
+		crd.SetSpecVersions([]kextv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true}})
+		return *crd.GetUnstructured()
+	}
+	aws := crd("providerconfigs.aws.example.org", "aws.example.org", "ProviderConfig", kextv1.ClusterScoped)
+	gcp := crd("providerconfigs.gcp.example.org", "gcp.example.org", "ProviderConfig", kextv1.ClusterScoped)
+	usages := crd("providerconfigusages.aws.example.org", "aws.example.org", "ProviderConfigUsage", kextv1.ClusterScoped)
+	namespaced := crd("providerconfigs.app.example.org", "app.example.org", "ProviderConfig", kextv1.NamespaceScoped)
+
+	// The active ProviderRevision of our provider, which defines AWS provider
+	// configs.
+	active := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+		Status: pkgv1.PackageRevisionStatus{
+			ObjectRefs: []xpv1.TypedReference{
+				{APIVersion: kextv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition", Name: aws.GetName()},
+				{APIVersion: kextv1.SchemeGroupVersion.String(), Kind: "CustomResourceDefinition", Name: usages.GetName()},
+			},
+		},
+	}
+
+	pc := func(apiVersion, name string) unstructured.Unstructured {
+		u := unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind("ProviderConfig")
+		u.SetName(name)
+		return u
+	}
+	awspc := pc("aws.example.org/v1", "default")
+	gcppc := pc("gcp.example.org/v1", "default")
+	gawspc := model.GetProviderConfig(&awspc)
+	ggcppc := model.GetProviderConfig(&gcppc)
+
+	list := func(t *testing.T) func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *pkgv1.ProviderRevisionList:
+				l.Items = []pkgv1.ProviderRevision{active}
+			case *unstructured.UnstructuredList:
+				switch {
+				case l.GetKind() == "CustomResourceDefinitionList":
+					l.Items = []unstructured.Unstructured{aws, gcp, usages, namespaced}
+				case l.GetAPIVersion() == "aws.example.org/v1" && l.GetKind() == "ProviderConfigList":
+					l.Items = []unstructured.Unstructured{awspc}
+				case l.GetAPIVersion() == "gcp.example.org/v1" && l.GetKind() == "ProviderConfigList":
+					l.Items = []unstructured.Unstructured{gcppc}
+				default:
+					t.Errorf("unexpected list of %q kind %q", l.GetAPIVersion(), l.GetKind())
+				}
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx      context.Context
+		provider *model.ReferenceID
+	}
+	type want struct {
+		pcc  model.ProviderConfigConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListProviderRevisionsError": {
+			reason: "If we can't list provider revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				provider: &id,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviderRevs)),
+				},
+			},
+		},
+		"ListCRDsError": {
+			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListCRDs)),
+				},
+			},
+		},
+		"ListProviderConfigsError": {
+			reason: "If we can't list provider configs of a kind we should add the error to the GraphQL context.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+						if l, ok := obj.(*unstructured.UnstructuredList); ok && l.GetKind() == "CustomResourceDefinitionList" {
+							l.Items = []unstructured.Unstructured{aws}
+							return nil
+						}
+						return errBoom
+					},
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListResources)),
+				},
+			},
+		},
+		"AllProviderConfigs": {
+			reason: "We should return cluster scoped provider configs of every kind.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pcc: model.ProviderConfigConnection{
+					Nodes:      []model.ProviderConfig{gawspc, ggcppc},
+					TotalCount: 2,
+				},
+			},
+		},
+		"ProviderProviderConfigs": {
+			reason: "We should only return provider configs of kinds defined by the supplied provider's active revision.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				provider: &id,
+			},
+			want: want{
+				pcc: model.ProviderConfigConnection{
+					Nodes:      []model.ProviderConfig{gawspc},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.ProviderConfigs(tc.args.ctx, tc.args.provider, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.ProviderConfigs(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.ProviderConfigs(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pcc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.ProviderConfigConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.ProviderConfig{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.ProviderConfigs(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCompositeResources(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	established := func(xrd extv1.CompositeResourceDefinition) extv1.CompositeResourceDefinition {
+		xrd.Status.SetConditions(xpv1.Condition{Type: extv1.TypeEstablished, Status: corev1.ConditionTrue})
+		return xrd
+	}
+	versions := []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}}
+
+	// An established XRD that offers a claim.
+	buckets := established(extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xbuckets.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XBucket", ListKind: "XBucketList"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Bucket", ListKind: "BucketList"},
+			Versions:   versions,
+		},
+	})
+
+	// An established XRD that doesn't offer a claim, and whose XRs we can't
+	// list.
+	databases := established(extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:    "example.org",
+			Names:    kextv1.CustomResourceDefinitionNames{Kind: "XDatabase", ListKind: "XDatabaseList"},
+			Versions: versions,
+		},
+	})
+
+	// An XRD that isn't established yet.
+	queues := extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xqueues.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XQueue", ListKind: "XQueueList"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Queue", ListKind: "QueueList"},
+			Versions:   versions,
+		},
+	}
+
+	xr := unstructured.Unstructured{}
+	xr.SetAPIVersion("example.org/v1")
+	xr.SetKind("XBucket")
+	xr.SetName("coolbucket")
+	_ = fieldpath.Pave(xr.Object).SetValue("status.conditions", []any{map[string]any{"type": "Ready", "status": "True"}})
+	gxr := model.GetCompositeResource(&xr)
+
+	list := func(t *testing.T) func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *extv1.CompositeResourceDefinitionList:
+				l.Items = []extv1.CompositeResourceDefinition{buckets, databases, queues}
+			case *unstructured.UnstructuredList:
+				switch l.GetKind() {
+				case "XBucketList":
+					l.Items = []unstructured.Unstructured{xr}
+				case "XDatabaseList":
+					return errBoom
+				default:
+					t.Errorf("unexpected list of kind %q", l.GetKind())
+				}
+			}
+			return nil
+		}
+	}
+
+	type args struct {
+		ctx   context.Context
+		ready *bool
+	}
+	type want struct {
+		xrc  model.CompositeResourceConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListXRDs)),
+				},
+			},
+		},
+		"PartialSuccess": {
+			reason: "We should return the XRs we can list, and add an error for each XRD whose XRs we can't list to the GraphQL context.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrc: model.CompositeResourceConnection{
+					Nodes:      []model.CompositeResource{gxr},
+					TotalCount: 1,
+				},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrapf(errors.Wrap(errBoom, errListResources), errFmtListDefinedBy, databases.GetName())),
+				},
+			},
+		},
+		"NotReady": {
+			reason: "We should only return XRs that aren't ready.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list(t)}, nil
+			}),
+			args: args{
+				ctx:   graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ready: ptr.To(false),
+			},
+			want: want{
+				xrc: model.CompositeResourceConnection{},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrapf(errors.Wrap(errBoom, errListResources), errFmtListDefinedBy, databases.GetName())),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.CompositeResources(tc.args.ctx, tc.args.ready, nil, nil, nil, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResources(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.xrc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.CompositeResourceConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.CompositeResource{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResources(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCompositeResourceClaims(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	established := func(xrd extv1.CompositeResourceDefinition) extv1.CompositeResourceDefinition {
+		xrd.Status.SetConditions(xpv1.Condition{Type: extv1.TypeEstablished, Status: corev1.ConditionTrue})
+		return xrd
+	}
+	versions := []extv1.CompositeResourceDefinitionVersion{{Name: "v1", Served: true, Referenceable: true}}
+
+	// An established XRD that offers a claim.
+	buckets := established(extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xbuckets.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XBucket", ListKind: "XBucketList"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Bucket", ListKind: "BucketList"},
+			Versions:   versions,
+		},
+	})
+
+	// An established XRD that doesn't offer a claim, and whose XRs we can't
+	// list.
+	databases := established(extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xdatabases.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:    "example.org",
+			Names:    kextv1.CustomResourceDefinitionNames{Kind: "XDatabase", ListKind: "XDatabaseList"},
+			Versions: versions,
+		},
+	})
+
+	// An XRD that isn't established yet.
+	queues := extv1.CompositeResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "xqueues.example.org"},
+		Spec: extv1.CompositeResourceDefinitionSpec{
+			Group:      "example.org",
+			Names:      kextv1.CustomResourceDefinitionNames{Kind: "XQueue", ListKind: "XQueueList"},
+			ClaimNames: &kextv1.CustomResourceDefinitionNames{Kind: "Queue", ListKind: "QueueList"},
+			Versions:   versions,
+		},
+	}
+
+	xrc := unstructured.Unstructured{}
+	xrc.SetAPIVersion("example.org/v1")
+	xrc.SetKind("Bucket")
+	xrc.SetNamespace("default")
+	xrc.SetName("coolbucket")
+	gxrc := model.GetCompositeResourceClaim(&xrc)
+
+	type args struct {
+		ctx       context.Context
+		namespace *string
+	}
+	type want struct {
+		xrcc model.CompositeResourceClaimConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListXRDs)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should return claims of every kind offered by an established XRD, in the supplied namespace.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: func(_ context.Context, obj client.ObjectList, opts ...client.ListOption) error {
+						switch l := obj.(type) {
+						case *extv1.CompositeResourceDefinitionList:
+							l.Items = []extv1.CompositeResourceDefinition{buckets, databases, queues}
+						case *unstructured.UnstructuredList:
+							lo := &client.ListOptions{}
+							lo.ApplyOptions(opts)
+							if diff := cmp.Diff("default", lo.Namespace); diff != "" {
+								t.Errorf("-want namespace, +got namespace:\n%s", diff)
+							}
+							switch l.GetKind() {
+							case "BucketList":
+								l.Items = []unstructured.Unstructured{xrc}
+							default:
+								t.Errorf("unexpected list of kind %q", l.GetKind())
+							}
+						}
+						return nil
+					},
+				}, nil
+			}),
+			args: args{
+				ctx:       graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				namespace: ptr.To("default"),
+			},
+			want: want{
+				xrcc: model.CompositeResourceClaimConnection{
+					Nodes:      []model.CompositeResourceClaim{gxrc},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.CompositeResourceClaims(tc.args.ctx, tc.args.namespace, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResourceClaims(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResourceClaims(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.xrcc, got,
+				cmpopts.EquateEmpty(),
+				cmpopts.IgnoreFields(model.CompositeResourceClaimConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.CompositeResourceClaim{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.CompositeResourceClaims(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQuerySecret(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	gsec := model.GetSecret(&corev1.Secret{})
+
+	type args struct {
+		ctx       context.Context
+		namespace string
+		name      string
+	}
+	type want struct {
+		sec  *model.Secret
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetSecretError": {
+			reason: "If we can't get the secret we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetSecret)),
+				},
+			},
+		},
+		"Success": {
+			reason: "If we can get and model the secret we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				sec: &gsec,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Secret(tc.args.ctx, tc.args.namespace, tc.args.name)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Secret(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.Secret(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.sec, got, cmp.AllowUnexported(model.Secret{}), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.Secret(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryConfigMap(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	gsec := model.GetConfigMap(&corev1.ConfigMap{})
+
+	type args struct {
+		ctx       context.Context
+		namespace string
+		name      string
+	}
+	type want struct {
+		cm   *model.ConfigMap
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetConfigMapError": {
+			reason: "If we can't get the config map we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetConfigMap)),
+				},
+			},
+		},
+		"Success": {
+			reason: "If we can get and model the config map we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				cm: &gsec,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.ConfigMap(tc.args.ctx, tc.args.namespace, tc.args.name)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ConfigMap(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ns.ConfigMap(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.cm, got, cmp.AllowUnexported(model.ConfigMap{}), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\ns.ConfigMap(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryProviders(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	p := pkgv1.Provider{ObjectMeta: metav1.ObjectMeta{Name: "coolprovider"}}
+	gp := model.GetProvider(&p)
+
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		pc   model.ProviderConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListProvidersError": {
+			reason: "If we can't list providers we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviders)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should successfully return any providers we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ProviderList) = pkgv1.ProviderList{Items: []pkgv1.Provider{p}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pc: model.ProviderConnection{
+					Nodes:      []model.Provider{gp},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Providers(tc.args.ctx, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Providers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Providers(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.ProviderConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Providers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryProviderRevisions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ProviderKind,
+		Name:       "coolprovider",
+	}
+
+	// The active ProviderRevision that we control.
+	active := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+	}
+	gactive := model.GetProviderRevision(&active)
+
+	// A ProviderRevision we control, but that is inactive.
+	inactive := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "inactiverev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive}},
+	}
+	ginactive := model.GetProviderRevision(&inactive)
+
+	// A ProviderRevision which we do not control.
+	other := pkgv1.ProviderRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "not-ours",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "other",
+			}},
+		},
+	}
+	gother := model.GetProviderRevision(&other)
+
+	type args struct {
+		ctx    context.Context
+		id     *model.ReferenceID
+		active *bool
+	}
+	type want struct {
+		pc   model.ProviderRevisionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListRevisionsError": {
+			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListProviderRevs)),
+				},
+			},
+		},
+		"AllRevisions": {
+			reason: "We should successfully return any revisions we own that we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
+							Items: []pkgv1.ProviderRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pc: model.ProviderRevisionConnection{
+					Nodes:      []model.ProviderRevision{gactive, ginactive, gother},
+					TotalCount: 3,
+				},
+			},
+		},
+		"ProvidersRevisions": {
+			reason: "We should successfully return any revisions the supplied provider id owns that we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
+							Items: []pkgv1.ProviderRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  &id,
+			},
+			want: want{
+				pc: model.ProviderRevisionConnection{
+					Nodes:      []model.ProviderRevision{gactive, ginactive},
+					TotalCount: 2,
+				},
+			},
+		},
+		"ActiveRevisions": {
+			reason: "We should successfully return any active revisions that we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
+							Items: []pkgv1.ProviderRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				active: ptr.To(true),
+			},
+			want: want{
+				pc: model.ProviderRevisionConnection{
+					Nodes:      []model.ProviderRevision{gactive},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.ProviderRevisions(tc.args.ctx, tc.args.id, tc.args.active, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.ProviderRevisionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryFunctions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	f := pkgv1.Function{ObjectMeta: metav1.ObjectMeta{Name: "coolfunction"}}
+	gf := model.GetFunction(&f)
+
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		pc   model.FunctionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListFunctionsError": {
+			reason: "If we can't list functions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListFunctions)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should successfully return any functions we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.FunctionList) = pkgv1.FunctionList{Items: []pkgv1.Function{f}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pc: model.FunctionConnection{
+					Nodes:      []model.Function{gf},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Functions(tc.args.ctx, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Functions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Functions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.FunctionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Functions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryFunctionRevisions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.FunctionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.FunctionKind,
+		Name:       "coolfunction",
+	}
+
+	// The active FunctionRevision that we control.
+	active := pkgv1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "coolrev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.FunctionRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+	}
+	gactive := model.GetFunctionRevision(&active)
+
+	// A FunctionRevision we control, but that is inactive.
+	inactive := pkgv1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "inactiverev",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			}},
+		},
+		Spec: pkgv1.FunctionRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive}},
+	}
+	ginactive := model.GetFunctionRevision(&inactive)
+
+	// A FunctionRevision which we do not control.
+	other := pkgv1.FunctionRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "not-ours",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "other",
+			}},
+		},
+	}
+	gother := model.GetFunctionRevision(&other)
+
+	type args struct {
+		ctx    context.Context
+		id     *model.ReferenceID
+		active *bool
+	}
+	type want struct {
+		pc   model.FunctionRevisionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListRevisionsError": {
+			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListFunctionRevs)),
+				},
+			},
+		},
+		"AllRevisions": {
+			reason: "We should successfully return any revisions we own that we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.FunctionRevisionList) = pkgv1.FunctionRevisionList{
+							Items: []pkgv1.FunctionRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				pc: model.FunctionRevisionConnection{
+					Nodes:      []model.FunctionRevision{gactive, ginactive, gother},
+					TotalCount: 3,
+				},
+			},
+		},
+		"FunctionsRevisions": {
+			reason: "We should successfully return any revisions the supplied function id owns that we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.FunctionRevisionList) = pkgv1.FunctionRevisionList{
+							Items: []pkgv1.FunctionRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				id:  &id,
+			},
+			want: want{
+				pc: model.FunctionRevisionConnection{
+					Nodes:      []model.FunctionRevision{gactive, ginactive},
+					TotalCount: 2,
+				},
+			},
+		},
+		"ActiveRevisions": {
+			reason: "We should successfully return any active revisions that we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.FunctionRevisionList) = pkgv1.FunctionRevisionList{
+							Items: []pkgv1.FunctionRevision{other, active, inactive},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				active: ptr.To(true),
+			},
+			want: want{
+				pc: model.FunctionRevisionConnection{
+					Nodes:      []model.FunctionRevision{gactive},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.FunctionRevisions(tc.args.ctx, tc.args.id, tc.args.active, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.FunctionRevisionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryCustomResourceDefinitions(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationRevisionKind,
+		Name:       "example",
+	}
+
+	owned := xunstructured.NewCRD()
+	owned.SetName("coolconfig")
+	owned.SetOwnerReferences([]metav1.OwnerReference{
+		// Some spurious owner references that we should ignore.
+		{
+			APIVersion: "wat",
+		},
+		{
+			APIVersion: id.APIVersion,
+			Kind:       "wat",
+		},
+		{
+			APIVersion: id.APIVersion,
+			Kind:       id.Kind,
+			Name:       "wat",
+		},
+		// The reference that indicates this XRD is owned by our desired
+		// ConfigurationRevision (or a ConfigurationRevision generally).
+		{
+			APIVersion: id.APIVersion,
+			Kind:       id.Kind,
+			Name:       id.Name,
+		},
+	})
+
+	gowned := model.GetCustomResourceDefinition(owned)
+
+	dangler := xunstructured.NewCRD()
+	dangler.SetName("coolconfig")
+
+	gdangler := model.GetCustomResourceDefinition(dangler)
+
+	type args struct {
+		ctx      context.Context
+		revision *model.ReferenceID
+	}
+	type want struct {
+		xrdc model.CustomResourceDefinitionConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListCRDsError": {
+			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+				},
+			},
+		},
+		"AllCRDs": {
+			reason: "We should successfully return all CRDs we can list and model when no arguments are supplied.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
+							Items: []unstructured.Unstructured{
+								dangler.Unstructured,
+								owned.Unstructured,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes: []model.CustomResourceDefinition{
+						gdangler,
+						gowned,
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"OwnedCRDs": {
+			reason: "We should successfully return the CRDs we can list and model that are owned by the supplied ID.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
+							Items: []unstructured.Unstructured{
+								dangler.Unstructured,
+								owned.Unstructured,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				revision: &id,
+			},
+			want: want{
+				xrdc: model.CustomResourceDefinitionConnection{
+					Nodes: []model.CustomResourceDefinition{
+						gowned,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.CustomResourceDefinitions(tc.args.ctx, tc.args.revision, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.xrdc, got,
+				cmpopts.IgnoreFields(model.CustomResourceDefinitionConnection{}, "Edges", "PageInfo"),
+				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
+				cmpopts.IgnoreFields(model.CustomResourceDefinition{}, "PavedAccess"),
+			); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryConfigurations(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	c := pkgv1.Configuration{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	gc := model.GetConfiguration(&c)
+
+	type args struct {
+		ctx context.Context
+	}
+	type want struct {
+		cc   model.ConfigurationConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				pc: model.ProviderConnection{
-					Nodes:      []model.Provider{gp},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListConfigurationsError": {
+			reason: "If we can't list configurations we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+				},
+			},
+		},
+		"Success": {
+			reason: "We should successfully return any configurations we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*pkgv1.ConfigurationList) = pkgv1.ConfigurationList{Items: []pkgv1.Configuration{c}}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{
+				cc: model.ConfigurationConnection{
+					Nodes:      []model.Configuration{gc},
 					TotalCount: 1,
 				},
 			},
@@ -771,33 +3318,33 @@ func TestQueryProviders(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.Providers(tc.args.ctx)
+			got, err := q.Configurations(tc.args.ctx, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Providers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Providers(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Providers(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreFields(model.ConfigurationConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryProviderRevisions(t *testing.T) {
+func TestQueryConfigurationRevisions(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	id := model.ReferenceID{
-		APIVersion: pkgv1.ProviderGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ProviderKind,
-		Name:       "coolprovider",
+		APIVersion: pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationKind,
+		Name:       "coolconfig",
 	}
 
-	// The active ProviderRevision that we control.
-	active := pkgv1.ProviderRevision{
+	// The active ConfigurationRevision that we control.
+	active := pkgv1.ConfigurationRevision{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "coolrev",
 			OwnerReferences: []metav1.OwnerReference{{
@@ -806,12 +3353,12 @@ func TestQueryProviderRevisions(t *testing.T) {
 				Name:       id.Name,
 			}},
 		},
-		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive}},
+		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive},
 	}
-	gactive := model.GetProviderRevision(&active)
+	gactive := model.GetConfigurationRevision(&active)
 
-	// A ProviderRevision we control, but that is inactive.
-	inactive := pkgv1.ProviderRevision{
+	// A ConfigurationRevision we control, but that is inactive.
+	inactive := pkgv1.ConfigurationRevision{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "inactiverev",
 			OwnerReferences: []metav1.OwnerReference{{
@@ -820,12 +3367,12 @@ func TestQueryProviderRevisions(t *testing.T) {
 				Name:       id.Name,
 			}},
 		},
-		Spec: pkgv1.ProviderRevisionSpec{PackageRevisionSpec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive}},
+		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive},
 	}
-	ginactive := model.GetProviderRevision(&inactive)
+	ginactive := model.GetConfigurationRevision(&inactive)
 
-	// A ProviderRevision which we do not control.
-	other := pkgv1.ProviderRevision{
+	// A ConfigurationRevision which we do not control.
+	other := pkgv1.ConfigurationRevision{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "not-ours",
 			OwnerReferences: []metav1.OwnerReference{{
@@ -835,7 +3382,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 			}},
 		},
 	}
-	gother := model.GetProviderRevision(&other)
+	gother := model.GetConfigurationRevision(&other)
 
 	type args struct {
 		ctx    context.Context
@@ -843,7 +3390,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 		active *bool
 	}
 	type want struct {
-		pc   model.ProviderRevisionConnection
+		pc   model.ConfigurationRevisionConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -880,7 +3427,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListProviderRevs)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigRevs)),
 				},
 			},
 		},
@@ -889,8 +3436,8 @@ func TestQueryProviderRevisions(t *testing.T) {
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
-							Items: []pkgv1.ProviderRevision{other, active, inactive},
+						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
+							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
 						}
 						return nil
 					}),
@@ -900,19 +3447,19 @@ func TestQueryProviderRevisions(t *testing.T) {
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				pc: model.ProviderRevisionConnection{
-					Nodes:      []model.ProviderRevision{gactive, ginactive, gother},
+				pc: model.ConfigurationRevisionConnection{
+					Nodes:      []model.ConfigurationRevision{gactive, ginactive, gother},
 					TotalCount: 3,
 				},
 			},
 		},
-		"ProvidersRevisions": {
+		"ConfigurationsRevisions": {
 			reason: "We should successfully return any revisions the supplied provider id owns that we can list and model.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
-							Items: []pkgv1.ProviderRevision{other, active, inactive},
+						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
+							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
 						}
 						return nil
 					}),
@@ -923,8 +3470,8 @@ func TestQueryProviderRevisions(t *testing.T) {
 				id:  &id,
 			},
 			want: want{
-				pc: model.ProviderRevisionConnection{
-					Nodes:      []model.ProviderRevision{gactive, ginactive},
+				pc: model.ConfigurationRevisionConnection{
+					Nodes:      []model.ConfigurationRevision{gactive, ginactive},
 					TotalCount: 2,
 				},
 			},
@@ -934,8 +3481,8 @@ func TestQueryProviderRevisions(t *testing.T) {
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ProviderRevisionList) = pkgv1.ProviderRevisionList{
-							Items: []pkgv1.ProviderRevision{other, active, inactive},
+						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
+							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
 						}
 						return nil
 					}),
@@ -946,8 +3493,8 @@ func TestQueryProviderRevisions(t *testing.T) {
 				active: ptr.To(true),
 			},
 			want: want{
-				pc: model.ProviderRevisionConnection{
-					Nodes:      []model.ProviderRevision{gactive},
+				pc: model.ConfigurationRevisionConnection{
+					Nodes:      []model.ConfigurationRevision{gactive},
 					TotalCount: 1,
 				},
 			},
@@ -960,7 +3507,7 @@ func TestQueryProviderRevisions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.ProviderRevisions(tc.args.ctx, tc.args.id, tc.args.active)
+			got, err := q.ConfigurationRevisions(tc.args.ctx, tc.args.id, tc.args.active, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -969,14 +3516,14 @@ func TestQueryProviderRevisions(t *testing.T) {
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreFields(model.ConfigurationRevisionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
 				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryCustomResourceDefinitions(t *testing.T) {
+func TestQueryCompositeResourceDefinitions(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	id := model.ReferenceID{
@@ -985,44 +3532,43 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 		Name:       "example",
 	}
 
-	owned := xunstructured.NewCRD()
-	owned.SetName("coolconfig")
-	owned.SetOwnerReferences([]metav1.OwnerReference{
-		// Some spurious owner references that we should ignore.
-		{
-			APIVersion: "wat",
-		},
-		{
-			APIVersion: id.APIVersion,
-			Kind:       "wat",
-		},
-		{
-			APIVersion: id.APIVersion,
-			Kind:       id.Kind,
-			Name:       "wat",
-		},
-		// The reference that indicates this XRD is owned by our desired
-		// ConfigurationRevision (or a ConfigurationRevision generally).
-		{
-			APIVersion: id.APIVersion,
-			Kind:       id.Kind,
-			Name:       id.Name,
+	owned := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{
+		Name: "coolconfig",
+		OwnerReferences: []metav1.OwnerReference{
+			// Some spurious owner references that we should ignore.
+			{
+				APIVersion: "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "wat",
+			},
+			// The reference that indicates this XRD is owned by our desired
+			// ConfigurationRevision (or a ConfigurationRevision generally).
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			},
 		},
-	})
-
-	gowned := model.GetCustomResourceDefinition(owned)
-
-	dangler := xunstructured.NewCRD()
-	dangler.SetName("coolconfig")
+	}}
+	gowned := model.GetCompositeResourceDefinition(&owned)
 
-	gdangler := model.GetCustomResourceDefinition(dangler)
+	dangler := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	gdangler := model.GetCompositeResourceDefinition(&dangler)
 
 	type args struct {
 		ctx      context.Context
 		revision *model.ReferenceID
+		dangling *bool
 	}
 	type want struct {
-		xrdc model.CustomResourceDefinitionConnection
+		xrdc model.CompositeResourceDefinitionConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1047,8 +3593,8 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 				},
 			},
 		},
-		"ListCRDsError": {
-			reason: "If we can't list CRDs we should add the error to the GraphQL context and return early.",
+		"ListXRDsError": {
+			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
@@ -1063,15 +3609,15 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 				},
 			},
 		},
-		"AllCRDs": {
-			reason: "We should successfully return all CRDs we can list and model when no arguments are supplied.",
+		"AllXRDs": {
+			reason: "We should successfully return all XRDs we can list and model when no arguments are supplied.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
-							Items: []unstructured.Unstructured{
-								dangler.Unstructured,
-								owned.Unstructured,
+						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
+							Items: []extv1.CompositeResourceDefinition{
+								dangler,
+								owned,
 							},
 						}
 						return nil
@@ -1082,8 +3628,8 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				xrdc: model.CustomResourceDefinitionConnection{
-					Nodes: []model.CustomResourceDefinition{
+				xrdc: model.CompositeResourceDefinitionConnection{
+					Nodes: []model.CompositeResourceDefinition{
 						gdangler,
 						gowned,
 					},
@@ -1091,15 +3637,43 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 				},
 			},
 		},
-		"OwnedCRDs": {
-			reason: "We should successfully return the CRDs we can list and model that are owned by the supplied ID.",
+		"DanglingXRDs": {
+			reason: "We should successfully return dangling XRDs we can list and model.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*unstructured.UnstructuredList) = unstructured.UnstructuredList{
-							Items: []unstructured.Unstructured{
-								dangler.Unstructured,
-								owned.Unstructured,
+						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
+							Items: []extv1.CompositeResourceDefinition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				dangling: ptr.To(true),
+			},
+			want: want{
+				xrdc: model.CompositeResourceDefinitionConnection{
+					Nodes: []model.CompositeResourceDefinition{
+						gdangler,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		"OwnedXRDs": {
+			reason: "We should successfully return the XRDs we can list and model that are owned by the supplied ID.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
+							Items: []extv1.CompositeResourceDefinition{
+								dangler,
+								owned,
 							},
 						}
 						return nil
@@ -1111,8 +3685,8 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 				revision: &id,
 			},
 			want: want{
-				xrdc: model.CustomResourceDefinitionConnection{
-					Nodes: []model.CustomResourceDefinition{
+				xrdc: model.CompositeResourceDefinitionConnection{
+					Nodes: []model.CompositeResourceDefinition{
 						gowned,
 					},
 					TotalCount: 1,
@@ -1127,7 +3701,7 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.CustomResourceDefinitions(tc.args.ctx, tc.args.revision)
+			got, err := q.CompositeResourceDefinitions(tc.args.ctx, tc.args.revision, tc.args.dangling, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -1136,27 +3710,60 @@ func TestQueryCustomResourceDefinitions(t *testing.T) {
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.xrdc, got,
-				cmpopts.IgnoreUnexported(model.ObjectMeta{}),
-				cmpopts.IgnoreFields(model.CustomResourceDefinition{}, "PavedAccess"),
-			); diff != "" {
+			if diff := cmp.Diff(tc.want.xrdc, got, cmpopts.IgnoreFields(model.CompositeResourceDefinitionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
 				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryConfigurations(t *testing.T) {
+func TestQueryCompositions(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	c := pkgv1.Configuration{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
-	gc := model.GetConfiguration(&c)
+	id := model.ReferenceID{
+		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
+		Kind:       pkgv1.ConfigurationRevisionKind,
+		Name:       "example",
+	}
+
+	owned := extv1.Composition{ObjectMeta: metav1.ObjectMeta{
+		Name: "coolconfig",
+		OwnerReferences: []metav1.OwnerReference{
+			// Some spurious owner references that we should ignore.
+			{
+				APIVersion: "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       "wat",
+			},
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       "wat",
+			},
+			// The reference that indicates this Composition is owned by our
+			// desired ConfigurationRevision (or a ConfigurationRevision
+			// generally).
+			{
+				APIVersion: id.APIVersion,
+				Kind:       id.Kind,
+				Name:       id.Name,
+			},
+		},
+	}}
+	gowned := model.GetComposition(&owned)
+
+	dangler := extv1.Composition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
+	gdangler := model.GetComposition(&dangler)
 
 	type args struct {
-		ctx context.Context
+		ctx      context.Context
+		revision *model.ReferenceID
+		dangling *bool
 	}
 	type want struct {
-		cc   model.ConfigurationConnection
+		cc   model.CompositionConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1181,8 +3788,8 @@ func TestQueryConfigurations(t *testing.T) {
 				},
 			},
 		},
-		"ListConfigurationsError": {
-			reason: "If we can't list configurations we should add the error to the GraphQL context and return early.",
+		"ListCompositionsError": {
+			reason: "If we can't list compositions we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
@@ -1197,12 +3804,17 @@ func TestQueryConfigurations(t *testing.T) {
 				},
 			},
 		},
-		"Success": {
-			reason: "We should successfully return any configurations we can list and model.",
+		"AllCompositions": {
+			reason: "We should successfully return all compositions we can list and model when no arguments are supplied.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationList) = pkgv1.ConfigurationList{Items: []pkgv1.Configuration{c}}
+						*obj.(*extv1.CompositionList) = extv1.CompositionList{
+							Items: []extv1.Composition{
+								dangler,
+								owned,
+							},
+						}
 						return nil
 					}),
 				}, nil
@@ -1211,8 +3823,67 @@ func TestQueryConfigurations(t *testing.T) {
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				cc: model.ConfigurationConnection{
-					Nodes:      []model.Configuration{gc},
+				cc: model.CompositionConnection{
+					Nodes: []model.Composition{
+						gdangler,
+						gowned,
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"DanglingCompositions": {
+			reason: "We should successfully return dangling compositions we can list and model.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositionList) = extv1.CompositionList{
+							Items: []extv1.Composition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				dangling: ptr.To(true),
+			},
+			want: want{
+				cc: model.CompositionConnection{
+					Nodes: []model.Composition{
+						gdangler,
+					},
+					TotalCount: 1,
+				},
+			},
+		},
+		"OwnedCompositions": {
+			reason: "We should successfully return the compositions we can list and model that are owned by the supplied ID.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						*obj.(*extv1.CompositionList) = extv1.CompositionList{
+							Items: []extv1.Composition{
+								dangler,
+								owned,
+							},
+						}
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				revision: &id,
+			},
+			want: want{
+				cc: model.CompositionConnection{
+					Nodes: []model.Composition{
+						gowned,
+					},
 					TotalCount: 1,
 				},
 			},
@@ -1225,7 +3896,7 @@ func TestQueryConfigurations(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.Configurations(tc.args.ctx)
+			got, err := q.Compositions(tc.args.ctx, tc.args.revision, tc.args.dangling, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -1234,70 +3905,24 @@ func TestQueryConfigurations(t *testing.T) {
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreFields(model.CompositionConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
 				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryConfigurationRevisions(t *testing.T) {
+func TestQueryEnvironmentConfigs(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationKind,
-		Name:       "coolconfig",
-	}
-
-	// The active ConfigurationRevision that we control.
-	active := pkgv1.ConfigurationRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "coolrev",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
-			}},
-		},
-		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionActive},
-	}
-	gactive := model.GetConfigurationRevision(&active)
-
-	// A ConfigurationRevision we control, but that is inactive.
-	inactive := pkgv1.ConfigurationRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "inactiverev",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
-			}},
-		},
-		Spec: pkgv1.PackageRevisionSpec{DesiredState: pkgv1.PackageRevisionInactive},
-	}
-	ginactive := model.GetConfigurationRevision(&inactive)
-
-	// A ConfigurationRevision which we do not control.
-	other := pkgv1.ConfigurationRevision{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "not-ours",
-			OwnerReferences: []metav1.OwnerReference{{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       "other",
-			}},
-		},
-	}
-	gother := model.GetConfigurationRevision(&other)
+	a := extv1alpha1.EnvironmentConfig{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	b := extv1alpha1.EnvironmentConfig{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
 
 	type args struct {
-		ctx    context.Context
-		id     *model.ReferenceID
-		active *bool
+		ctx context.Context
 	}
 	type want struct {
-		pc   model.ConfigurationRevisionConnection
+		ecc  model.EnvironmentConfigConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1322,8 +3947,8 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 				},
 			},
 		},
-		"ListRevisionsError": {
-			reason: "If we can't list revisions we should add the error to the GraphQL context and return early.",
+		"ListEnvironmentConfigsError": {
+			reason: "If we can't list environment configs we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
@@ -1334,39 +3959,17 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigRevs)),
-				},
-			},
-		},
-		"AllRevisions": {
-			reason: "We should successfully return any revisions we own that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
-							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
-						}
-						return nil
-					}),
-				}, nil
-			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
-			want: want{
-				pc: model.ConfigurationRevisionConnection{
-					Nodes:      []model.ConfigurationRevision{gactive, ginactive, gother},
-					TotalCount: 3,
+					gqlerror.Wrap(errors.Wrap(errBoom, errListEnvConfigs)),
 				},
 			},
 		},
-		"ConfigurationsRevisions": {
-			reason: "We should successfully return any revisions the supplied provider id owns that we can list and model.",
+		"Success": {
+			reason: "We should successfully return all environment configs we can list and model, sorted by ID.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
-							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
+						*obj.(*extv1alpha1.EnvironmentConfigList) = extv1alpha1.EnvironmentConfigList{
+							Items: []extv1alpha1.EnvironmentConfig{b, a},
 						}
 						return nil
 					}),
@@ -1374,38 +3977,17 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 			}),
 			args: args{
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				id:  &id,
 			},
 			want: want{
-				pc: model.ConfigurationRevisionConnection{
-					Nodes:      []model.ConfigurationRevision{gactive, ginactive},
+				ecc: model.EnvironmentConfigConnection{
+					Nodes: []model.EnvironmentConfig{
+						model.GetEnvironmentConfig(&a),
+						model.GetEnvironmentConfig(&b),
+					},
 					TotalCount: 2,
 				},
 			},
 		},
-		"ActiveRevisions": {
-			reason: "We should successfully return any active revisions that we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*pkgv1.ConfigurationRevisionList) = pkgv1.ConfigurationRevisionList{
-							Items: []pkgv1.ConfigurationRevision{other, active, inactive},
-						}
-						return nil
-					}),
-				}, nil
-			}),
-			args: args{
-				ctx:    graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				active: ptr.To(true),
-			},
-			want: want{
-				pc: model.ConfigurationRevisionConnection{
-					Nodes:      []model.ConfigurationRevision{gactive},
-					TotalCount: 1,
-				},
-			},
-		},
 	}
 
 	for name, tc := range cases {
@@ -1414,68 +3996,33 @@ func TestQueryConfigurationRevisions(t *testing.T) {
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.ConfigurationRevisions(tc.args.ctx, tc.args.id, tc.args.active)
+			got, err := q.EnvironmentConfigs(tc.args.ctx, nil, nil, nil, nil, nil)
 			errs := graphql.GetErrors(tc.args.ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Revisions(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.EnvironmentConfigs(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Revisions(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.EnvironmentConfigs(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.pc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Revisions(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.ecc, got, cmpopts.IgnoreFields(model.EnvironmentConfigConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.EnvironmentConfigs(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryCompositeResourceDefinitions(t *testing.T) {
+func TestQueryUsages(t *testing.T) {
 	errBoom := errors.New("boom")
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationRevisionKind,
-		Name:       "example",
-	}
-
-	owned := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{
-		Name: "coolconfig",
-		OwnerReferences: []metav1.OwnerReference{
-			// Some spurious owner references that we should ignore.
-			{
-				APIVersion: "wat",
-			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       "wat",
-			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       "wat",
-			},
-			// The reference that indicates this XRD is owned by our desired
-			// ConfigurationRevision (or a ConfigurationRevision generally).
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
-			},
-		},
-	}}
-	gowned := model.GetCompositeResourceDefinition(&owned)
-
-	dangler := extv1.CompositeResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
-	gdangler := model.GetCompositeResourceDefinition(&dangler)
-
-	type args struct {
-		ctx      context.Context
-		revision *model.ReferenceID
-		dangling *bool
+	a := extv1alpha1.Usage{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	b := extv1alpha1.Usage{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+
+	type args struct {
+		ctx context.Context
 	}
 	type want struct {
-		xrdc model.CompositeResourceDefinitionConnection
+		uc   model.UsageConnection
 		err  error
 		errs gqlerror.List
 	}
@@ -1500,8 +4047,8 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 				},
 			},
 		},
-		"ListXRDsError": {
-			reason: "If we can't list XRDs we should add the error to the GraphQL context and return early.",
+		"ListUsagesError": {
+			reason: "If we can't list usages we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(errBoom),
@@ -1512,20 +4059,17 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 			},
 			want: want{
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errListUsages)),
 				},
 			},
 		},
-		"AllXRDs": {
-			reason: "We should successfully return all XRDs we can list and model when no arguments are supplied.",
+		"Success": {
+			reason: "We should successfully return all usages we can list and model, sorted by ID.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
 					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
-							Items: []extv1.CompositeResourceDefinition{
-								dangler,
-								owned,
-							},
+						*obj.(*extv1alpha1.UsageList) = extv1alpha1.UsageList{
+							Items: []extv1alpha1.Usage{b, a},
 						}
 						return nil
 					}),
@@ -1535,69 +4079,98 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
 			},
 			want: want{
-				xrdc: model.CompositeResourceDefinitionConnection{
-					Nodes: []model.CompositeResourceDefinition{
-						gdangler,
-						gowned,
+				uc: model.UsageConnection{
+					Nodes: []model.Usage{
+						model.GetUsage(&a),
+						model.GetUsage(&b),
 					},
 					TotalCount: 2,
 				},
 			},
 		},
-		"DanglingXRDs": {
-			reason: "We should successfully return dangling XRDs we can list and model.",
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			q := &query{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := q.Usages(tc.args.ctx, nil, nil, nil, nil, nil)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Usages(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nq.Usages(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.uc, got, cmpopts.IgnoreFields(model.UsageConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.Usages(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestQueryPackageLock(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "lock")
+
+	glock := model.GetPackageLock(&pkgv1beta1.Lock{})
+
+	type want struct {
+		l    *model.PackageLock
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		want    want
+	}{
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetLockError": {
+			reason: "If we can't get the lock we should add the error to the GraphQL context and return early.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
-							Items: []extv1.CompositeResourceDefinition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
+					MockGet: test.NewMockGetFn(errBoom),
 				}, nil
 			}),
-			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				dangling: ptr.To(true),
-			},
 			want: want{
-				xrdc: model.CompositeResourceDefinitionConnection{
-					Nodes: []model.CompositeResourceDefinition{
-						gdangler,
-					},
-					TotalCount: 1,
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetLock)),
 				},
 			},
 		},
-		"OwnedXRDs": {
-			reason: "We should successfully return the XRDs we can list and model that are owned by the supplied ID.",
+		"GetLockNotFound": {
+			reason: "If the lock doesn't exist we should return nil.",
 			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
 				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositeResourceDefinitionList) = extv1.CompositeResourceDefinitionList{
-							Items: []extv1.CompositeResourceDefinition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			want: want{},
+		},
+		"Success": {
+			reason: "If we can get and model the lock we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil),
 				}, nil
 			}),
-			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				revision: &id,
-			},
 			want: want{
-				xrdc: model.CompositeResourceDefinitionConnection{
-					Nodes: []model.CompositeResourceDefinition{
-						gowned,
-					},
-					TotalCount: 1,
-				},
+				l: &glock,
 			},
 		},
 	}
@@ -1605,215 +4178,173 @@ func TestQueryCompositeResourceDefinitions(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			q := &query{clients: tc.clients}
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.CompositeResourceDefinitions(tc.args.ctx, tc.args.revision, tc.args.dangling)
-			errs := graphql.GetErrors(tc.args.ctx)
+			got, err := q.PackageLock(ctx)
+			errs := graphql.GetErrors(ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.PackageLock(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.PackageLock(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.xrdc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.l, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nq.PackageLock(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestQueryCompositions(t *testing.T) {
+func TestQueryAPIResources(t *testing.T) {
 	errBoom := errors.New("boom")
+	errPartial := &discovery.ErrGroupDiscoveryFailed{Groups: map[schema.GroupVersion]error{{Group: "metrics.k8s.io", Version: "v1beta1"}: errBoom}}
 
-	id := model.ReferenceID{
-		APIVersion: pkgv1.ConfigurationRevisionGroupVersionKind.GroupVersion().String(),
-		Kind:       pkgv1.ConfigurationRevisionKind,
-		Name:       "example",
+	groups := []*metav1.APIGroup{
+		{Name: "", PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "v1", Version: "v1"}},
+		{Name: "example.org", PreferredVersion: metav1.GroupVersionForDiscovery{GroupVersion: "example.org/v1", Version: "v1"}},
 	}
-
-	owned := extv1.Composition{ObjectMeta: metav1.ObjectMeta{
-		Name: "coolconfig",
-		OwnerReferences: []metav1.OwnerReference{
-			// Some spurious owner references that we should ignore.
-			{
-				APIVersion: "wat",
-			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       "wat",
-			},
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       "wat",
+	lists := []*metav1.APIResourceList{
+		{
+			GroupVersion: "v1",
+			APIResources: []metav1.APIResource{
+				{Name: "secrets", Namespaced: true, Kind: "Secret", Verbs: metav1.Verbs{"get", "list"}},
 			},
-			// The reference that indicates this Composition is owned by our
-			// desired ConfigurationRevision (or a ConfigurationRevision
-			// generally).
-			{
-				APIVersion: id.APIVersion,
-				Kind:       id.Kind,
-				Name:       id.Name,
+		},
+		{
+			GroupVersion: "example.org/v1",
+			APIResources: []metav1.APIResource{
+				{Name: "buckets", Kind: "Bucket", Verbs: metav1.Verbs{"get", "list"}, Categories: []string{"crossplane", "managed"}},
+				{Name: "buckets/status", Kind: "Bucket", Verbs: metav1.Verbs{"get"}},
+				{Name: "compositions", Kind: "Composition", Verbs: metav1.Verbs{"get", "list"}, Categories: []string{"crossplane"}},
 			},
 		},
-	}}
-	gowned := model.GetComposition(&owned)
+	}
+	all := model.GetAPIResources(groups, lists)
 
-	dangler := extv1.Composition{ObjectMeta: metav1.ObjectMeta{Name: "coolconfig"}}
-	gdangler := model.GetComposition(&dangler)
+	// Discovery blocks until the test is done, as if the API server never
+	// responded.
+	blocked := make(chan struct{})
+	defer close(blocked)
+
+	cancelled, cancel := context.WithCancel(graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover))
+	cancel()
 
 	type args struct {
-		ctx      context.Context
-		revision *model.ReferenceID
-		dangling *bool
+		ctx        context.Context
+		group      *string
+		categories []string
 	}
 	type want struct {
-		cc   model.CompositionConnection
+		r    []model.APIResource
 		err  error
 		errs gqlerror.List
 	}
 
 	cases := map[string]struct {
-		reason  string
-		clients ClientCache
-		args    args
-		want    want
+		reason    string
+		discovery Discoverer
+		args      args
+		want      want
 	}{
-		"GetClientError": {
-			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{}, errBoom
+		"DiscoveryTimeout": {
+			reason: "If discovery doesn't finish before our context is done we should add the error to the GraphQL context and return early.",
+			discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+				<-blocked
+				return groups, lists, nil
 			}),
 			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ctx: cancelled,
 			},
 			want: want{
+				r: []model.APIResource{},
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+					gqlerror.Wrap(errors.Wrap(context.Canceled, errDiscover)),
 				},
 			},
 		},
-		"ListCompositionsError": {
-			reason: "If we can't list compositions we should add the error to the GraphQL context and return early.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(errBoom),
-				}, nil
+		"DiscoveryError": {
+			reason: "If we can't discover API resources we should add the error to the GraphQL context and return early.",
+			discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+				return nil, nil, errBoom
 			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
 			want: want{
+				r: []model.APIResource{},
 				errs: gqlerror.List{
-					gqlerror.Wrap(errors.Wrap(errBoom, errListConfigs)),
+					gqlerror.Wrap(errors.Wrap(errBoom, errDiscover)),
 				},
 			},
 		},
-		"AllCompositions": {
-			reason: "We should successfully return all compositions we can list and model when no arguments are supplied.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositionList) = extv1.CompositionList{
-							Items: []extv1.Composition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
-				}, nil
+		"PartialDiscovery": {
+			reason: "If we can't discover some API groups we should add the error to the GraphQL context and return the API resources we could discover.",
+			discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+				return groups, lists, errPartial
 			}),
-			args: args{
-				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-			},
 			want: want{
-				cc: model.CompositionConnection{
-					Nodes: []model.Composition{
-						gdangler,
-						gowned,
-					},
-					TotalCount: 2,
+				r: all,
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errPartial, errDiscover)),
 				},
 			},
 		},
-		"DanglingCompositions": {
-			reason: "We should successfully return dangling compositions we can list and model.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositionList) = extv1.CompositionList{
-							Items: []extv1.Composition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
-				}, nil
+		"AllResources": {
+			reason: "We should return all API resources if no filters are supplied.",
+			discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+				return groups, lists, nil
+			}),
+			want: want{
+				r: all,
+			},
+		},
+		"FilterByGroup": {
+			reason: "We should only return API resources in the supplied group.",
+			discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+				return groups, lists, nil
 			}),
 			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				dangling: ptr.To(true),
+				group: ptr.To(""),
 			},
 			want: want{
-				cc: model.CompositionConnection{
-					Nodes: []model.Composition{
-						gdangler,
-					},
-					TotalCount: 1,
-				},
+				r: all[:1],
 			},
 		},
-		"OwnedCompositions": {
-			reason: "We should successfully return the compositions we can list and model that are owned by the supplied ID.",
-			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
-				return &test.MockClient{
-					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
-						*obj.(*extv1.CompositionList) = extv1.CompositionList{
-							Items: []extv1.Composition{
-								dangler,
-								owned,
-							},
-						}
-						return nil
-					}),
-				}, nil
+		"FilterByCategories": {
+			reason: "We should only return API resources in all of the supplied categories.",
+			discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+				return groups, lists, nil
 			}),
 			args: args{
-				ctx:      graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
-				revision: &id,
+				categories: []string{"crossplane", "managed"},
 			},
 			want: want{
-				cc: model.CompositionConnection{
-					Nodes: []model.Composition{
-						gowned,
-					},
-					TotalCount: 1,
-				},
+				r: []model.APIResource{all[1]},
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			q := &query{clients: tc.clients}
+			q := &query{discovery: tc.discovery}
+			ctx := tc.args.ctx
+			if ctx == nil {
+				ctx = graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+			}
 
 			// Our GraphQL resolvers never return errors. We instead add an
 			// error to the GraphQL context and return early.
-			got, err := q.Compositions(tc.args.ctx, tc.args.revision, tc.args.dangling)
-			errs := graphql.GetErrors(tc.args.ctx)
+			got, err := q.APIResources(ctx, tc.args.group, tc.args.categories)
+			errs := graphql.GetErrors(ctx)
 
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.APIResources(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
 			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+				t.Errorf("\n%s\nq.APIResources(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
 			}
-			if diff := cmp.Diff(tc.want.cc, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
-				t.Errorf("\n%s\nq.Configurations(...): -want, +got:\n%s\n", tc.reason, diff)
+			if diff := cmp.Diff(tc.want.r, got); diff != "" {
+				t.Errorf("\n%s\nq.APIResources(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
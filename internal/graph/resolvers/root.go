@@ -15,10 +15,15 @@
 package resolvers
 
 import (
+	"context"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+
 	"github.com/upbound/xgql/internal/auth"
 	"github.com/upbound/xgql/internal/clients"
 	"github.com/upbound/xgql/internal/graph/generated"
@@ -41,19 +46,85 @@ func (fn ClientCacheFn) Get(cr auth.Credentials, o ...clients.GetOption) (client
 	return fn(cr, o...)
 }
 
+// A LogReader can read the logs of a pod using the supplied credentials.
+type LogReader interface {
+	// PodLogs returns the logs of the supplied pod.
+	PodLogs(ctx context.Context, cr auth.Credentials, namespace, name string, o *corev1.PodLogOptions) ([]byte, error)
+}
+
+// A LogReaderFn is a function that can read the logs of a pod using the
+// supplied credentials.
+type LogReaderFn func(ctx context.Context, cr auth.Credentials, namespace, name string, o *corev1.PodLogOptions) ([]byte, error)
+
+// PodLogs returns the logs of the supplied pod.
+func (fn LogReaderFn) PodLogs(ctx context.Context, cr auth.Credentials, namespace, name string, o *corev1.PodLogOptions) ([]byte, error) {
+	return fn(ctx, cr, namespace, name, o)
+}
+
+// A Discoverer discovers the kinds of resource an API server serves.
+type Discoverer interface {
+	// ServerGroupsAndResources returns the API groups and resources served by
+	// the API server.
+	ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error)
+}
+
+// A DiscovererFn is a function that discovers the kinds of resource an API
+// server serves.
+type DiscovererFn func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error)
+
+// ServerGroupsAndResources returns the API groups and resources served by the
+// API server.
+func (fn DiscovererFn) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	return fn()
+}
+
 // The Root resolver.
 type Root struct {
-	clients ClientCache
+	clients   ClientCache
+	logs      LogReader
+	discovery Discoverer
+}
+
+// An Option configures the root resolver.
+type Option func(r *Root)
+
+// WithLogReader configures the LogReader used to read the logs of pods. Pod
+// logs can't be read unless a LogReader is supplied.
+func WithLogReader(l LogReader) Option {
+	return func(r *Root) {
+		r.logs = l
+	}
+}
+
+// WithDiscoverer configures the Discoverer used to discover the kinds of
+// resource the API server serves. API resources can't be discovered unless a
+// Discoverer is supplied.
+func WithDiscoverer(d Discoverer) Option {
+	return func(r *Root) {
+		r.discovery = d
+	}
 }
 
 // New returns a new root resolver.
-func New(cc ClientCache) *Root {
-	return &Root{clients: cc}
+func New(cc ClientCache, o ...Option) *Root {
+	r := &Root{
+		clients: cc,
+		logs: LogReaderFn(func(_ context.Context, _ auth.Credentials, _, _ string, _ *corev1.PodLogOptions) ([]byte, error) {
+			return nil, errors.New(errNoLogReader)
+		}),
+		discovery: DiscovererFn(func() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+			return nil, nil, errors.New(errNoDiscoverer)
+		}),
+	}
+	for _, fn := range o {
+		fn(r)
+	}
+	return r
 }
 
 // Query resolves GraphQL queries.
 func (r *Root) Query() generated.QueryResolver {
-	return &query{clients: r.clients}
+	return &query{clients: r.clients, discovery: r.discovery}
 }
 
 // Mutation resolves GraphQL mutations.
@@ -116,6 +187,32 @@ func (r *Root) Composition() generated.CompositionResolver {
 	return &composition{clients: r.clients}
 }
 
+// CompositionRevision resolves properties of the CompositionRevision GraphQL
+// type.
+func (r *Root) CompositionRevision() generated.CompositionRevisionResolver {
+	return &compositionRevision{clients: r.clients}
+}
+
+// PipelineStep resolves properties of the PipelineStep GraphQL type.
+func (r *Root) PipelineStep() generated.PipelineStepResolver {
+	return &pipelineStep{clients: r.clients}
+}
+
+// EnvironmentConfig resolves properties of the EnvironmentConfig GraphQL type.
+func (r *Root) EnvironmentConfig() generated.EnvironmentConfigResolver {
+	return &environmentConfig{clients: r.clients}
+}
+
+// Usage resolves properties of the Usage GraphQL type.
+func (r *Root) Usage() generated.UsageResolver {
+	return &usage{clients: r.clients}
+}
+
+// UsageResource resolves properties of the UsageResource GraphQL type.
+func (r *Root) UsageResource() generated.UsageResourceResolver {
+	return &usageResource{clients: r.clients}
+}
+
 // Configuration resolves properties of the Configuration GraphQL type.
 func (r *Root) Configuration() generated.ConfigurationResolver {
 	return &configuration{clients: r.clients}
@@ -166,6 +263,11 @@ func (r *Root) Provider() generated.ProviderResolver {
 	return &provider{clients: r.clients}
 }
 
+// ProviderSpec resolves properties of the ProviderSpec GraphQL type.
+func (r *Root) ProviderSpec() generated.ProviderSpecResolver {
+	return &providerSpec{clients: r.clients}
+}
+
 // ProviderRevision resolves properties of the ProviderRevision GraphQL type.
 func (r *Root) ProviderRevision() generated.ProviderRevisionResolver {
 	return &providerRevision{clients: r.clients}
@@ -177,7 +279,55 @@ func (r *Root) ProviderRevisionStatus() generated.ProviderRevisionStatusResolver
 	return &providerRevisionStatus{clients: r.clients}
 }
 
+// Function resolves properties of the Function GraphQL type.
+func (r *Root) Function() generated.FunctionResolver {
+	return &function{clients: r.clients}
+}
+
+// FunctionSpec resolves properties of the FunctionSpec GraphQL type.
+func (r *Root) FunctionSpec() generated.FunctionSpecResolver {
+	return &functionSpec{clients: r.clients}
+}
+
+// FunctionRevision resolves properties of the FunctionRevision GraphQL type.
+func (r *Root) FunctionRevision() generated.FunctionRevisionResolver {
+	return &functionRevision{clients: r.clients}
+}
+
+// FunctionRevisionStatus resolves properties of the FunctionRevisionStatus
+// GraphQL type.
+func (r *Root) FunctionRevisionStatus() generated.FunctionRevisionStatusResolver {
+	return &functionRevisionStatus{clients: r.clients}
+}
+
+// DeploymentRuntimeConfig resolves properties of the DeploymentRuntimeConfig
+// GraphQL type.
+func (r *Root) DeploymentRuntimeConfig() generated.DeploymentRuntimeConfigResolver {
+	return &deploymentRuntimeConfig{clients: r.clients}
+}
+
+// ControllerConfig resolves properties of the ControllerConfig GraphQL type.
+func (r *Root) ControllerConfig() generated.ControllerConfigResolver {
+	return &controllerConfig{clients: r.clients}
+}
+
+// Pod resolves properties of the Pod GraphQL type.
+func (r *Root) Pod() generated.PodResolver {
+	return &pod{clients: r.clients, logs: r.logs}
+}
+
+// PackageLock resolves properties of the PackageLock GraphQL type.
+func (r *Root) PackageLock() generated.PackageLockResolver {
+	return &packageLock{clients: r.clients}
+}
+
 // ProviderConfig resolves properties of the ProviderConfig GraphQL type.
 func (r *Root) ProviderConfig() generated.ProviderConfigResolver {
 	return &providerConfig{clients: r.clients}
 }
+
+// ProviderConfigUsage resolves properties of the ProviderConfigUsage GraphQL
+// type.
+func (r *Root) ProviderConfigUsage() generated.ProviderConfigUsageResolver {
+	return &providerConfigUsage{clients: r.clients}
+}
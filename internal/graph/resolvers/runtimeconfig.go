@@ -0,0 +1,302 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	pkgv1alpha1 "github.com/crossplane/crossplane/apis/pkg/v1alpha1"
+	pkgv1beta1 "github.com/crossplane/crossplane/apis/pkg/v1beta1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errGetRuntimeConfig    = "cannot get deployment runtime config"
+	errGetControllerConfig = "cannot get controller config"
+	errListDeployments     = "cannot list deployments"
+	errModelDeployment     = "cannot model deployment"
+	errConvertDeployment   = "cannot convert deployment"
+	errListPods            = "cannot list pods"
+	errParseSelector       = "cannot parse deployment selector"
+)
+
+// Crossplane labels the Deployment of a provider or function package revision
+// with the name of the revision.
+const labelRevision = "pkg.crossplane.io/revision"
+
+// packageRuntime resolves the configuration, Deployment and Pods of a provider
+// or function package's runtime.
+type packageRuntime struct {
+	clients ClientCache
+}
+
+// RuntimeConfig resolves the referenced DeploymentRuntimeConfig.
+func (r *packageRuntime) RuntimeConfig(ctx context.Context, ref *model.RuntimeConfigReference) (*model.DeploymentRuntimeConfig, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	rc := &pkgv1beta1.DeploymentRuntimeConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, rc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetRuntimeConfig))
+		}
+		return nil, nil
+	}
+
+	out := model.GetDeploymentRuntimeConfig(rc)
+	return &out, nil
+}
+
+// ControllerConfig resolves the referenced ControllerConfig.
+func (r *packageRuntime) ControllerConfig(ctx context.Context, ref *model.LocalObjectReference) (*model.ControllerConfig, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	cc := &pkgv1alpha1.ControllerConfig{}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, cc); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetControllerConfig))
+		}
+		return nil, nil
+	}
+
+	out := model.GetControllerConfig(cc)
+	return &out, nil
+}
+
+// Deployment resolves the Deployment controlled by the supplied package
+// revision.
+func (r *packageRuntime) Deployment(ctx context.Context, name string, uid types.UID) (model.KubernetesResource, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	d, err := getDeployment(ctx, c, name, uid)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return nil, nil
+	}
+	if d == nil {
+		return nil, nil
+	}
+
+	out, err := model.GetKubernetesResource(d)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelDeployment))
+		return nil, nil
+	}
+	return out, nil
+}
+
+// Runtime resolves the Deployment controlled by the supplied package revision,
+// and the Pods it manages.
+func (r *packageRuntime) Runtime(ctx context.Context, name string, uid types.UID) (*model.PackageRuntime, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	u, err := getDeployment(ctx, c, name, uid)
+	if err != nil {
+		graphql.AddError(ctx, err)
+		return nil, nil
+	}
+	if u == nil {
+		return nil, nil
+	}
+
+	kr, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelDeployment))
+		return nil, nil
+	}
+
+	d := &appsv1.Deployment{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, d); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errConvertDeployment))
+		return nil, nil
+	}
+	sel, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errParseSelector))
+		return nil, nil
+	}
+
+	// Pods aren't cached, so this list goes straight to the API server.
+	in := &corev1.PodList{}
+	if err := c.List(ctx, in, client.InNamespace(d.GetNamespace()), client.MatchingLabelsSelector{Selector: sel}); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListPods))
+		return nil, nil
+	}
+
+	out := &model.PackageRuntime{
+		Deployment: kr,
+		Pods:       make([]model.Pod, len(in.Items)),
+	}
+	for i := range in.Items {
+		out.Pods[i] = model.GetPod(&in.Items[i])
+	}
+	return out, nil
+}
+
+// getDeployment returns the Deployment controlled by the supplied package
+// revision, or nil if there is none. We don't know which namespace Crossplane
+// runs package Deployments in, or what they're named if a runtime config
+// overrides their name, so we look for a Deployment labelled with the revision
+// in any namespace.
+func getDeployment(ctx context.Context, c client.Client, name string, uid types.UID) (*kunstructured.Unstructured, error) {
+	// Deployments aren't cached, so this list goes straight to the API server.
+	in := &kunstructured.UnstructuredList{}
+	in.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DeploymentList"))
+	if err := c.List(ctx, in, client.MatchingLabels{labelRevision: name}); err != nil {
+		return nil, errors.Wrap(err, errListDeployments)
+	}
+
+	for i := range in.Items {
+		d := &in.Items[i] // To avoid taking the address of the range var.
+
+		// The label narrows the list, but only the controller reference tells
+		// us the revision actually owns the Deployment.
+		ref := metav1.GetControllerOf(d)
+		if ref == nil || ref.UID != uid {
+			continue
+		}
+		return d, nil
+	}
+
+	return nil, nil
+}
+
+type providerSpec struct {
+	clients ClientCache
+}
+
+func (r *providerSpec) RuntimeConfig(ctx context.Context, obj *model.ProviderSpec) (*model.DeploymentRuntimeConfig, error) {
+	rt := &packageRuntime{clients: r.clients}
+	return rt.RuntimeConfig(ctx, obj.RuntimeConfigRef)
+}
+
+func (r *providerSpec) ControllerConfig(ctx context.Context, obj *model.ProviderSpec) (*model.ControllerConfig, error) {
+	rt := &packageRuntime{clients: r.clients}
+	return rt.ControllerConfig(ctx, obj.ControllerConfigRef)
+}
+
+type functionSpec struct {
+	clients ClientCache
+}
+
+func (r *functionSpec) RuntimeConfig(ctx context.Context, obj *model.FunctionSpec) (*model.DeploymentRuntimeConfig, error) {
+	rt := &packageRuntime{clients: r.clients}
+	return rt.RuntimeConfig(ctx, obj.RuntimeConfigRef)
+}
+
+func (r *functionSpec) ControllerConfig(ctx context.Context, obj *model.FunctionSpec) (*model.ControllerConfig, error) {
+	rt := &packageRuntime{clients: r.clients}
+	return rt.ControllerConfig(ctx, obj.ControllerConfigRef)
+}
+
+type deploymentRuntimeConfig struct {
+	clients ClientCache
+}
+
+func (r *deploymentRuntimeConfig) Events(ctx context.Context, obj *model.DeploymentRuntimeConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *deploymentRuntimeConfig) UsedBy(ctx context.Context, obj *model.DeploymentRuntimeConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *deploymentRuntimeConfig) Uses(ctx context.Context, obj *model.DeploymentRuntimeConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+type controllerConfig struct {
+	clients ClientCache
+}
+
+func (r *controllerConfig) Events(ctx context.Context, obj *model.ControllerConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *controllerConfig) UsedBy(ctx context.Context, obj *model.ControllerConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *controllerConfig) Uses(ctx context.Context, obj *model.ControllerConfig, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
@@ -0,0 +1,397 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/99designs/gqlgen/graphql"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1 "github.com/crossplane/crossplane/apis/apiextensions/v1"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+	xunstructured "github.com/upbound/xgql/internal/unstructured"
+)
+
+const (
+	errNegativeMaxDepth = "maxDepth must not be negative"
+	errGetRelated       = "cannot get related resource"
+	errModelRelated     = "cannot model related resource"
+)
+
+// maxTreeConcurrency is the maximum number of goroutines that may traverse a
+// tree concurrently, in addition to the goroutine that started traversing it.
+const maxTreeConcurrency = 16
+
+// A tree of the Crossplane resources descending from a root resource.
+type tree struct {
+	clients ClientCache
+
+	// maxDepth is the maximum depth to traverse, or nil to traverse to any
+	// depth. The root of the tree is at depth zero.
+	maxDepth *int
+
+	// include resources related to the tree by these relations, in addition
+	// to composite and composed resources.
+	include map[model.CrossplaneResourceTreeRelation]bool
+
+	// sem bounds the number of goroutines traversing the tree.
+	sem chan struct{}
+}
+
+func newTree(c ClientCache, maxDepth *int, include []model.CrossplaneResourceTreeRelation) *tree {
+	t := &tree{
+		clients:  c,
+		maxDepth: maxDepth,
+		include:  make(map[model.CrossplaneResourceTreeRelation]bool, len(include)),
+		sem:      make(chan struct{}, maxTreeConcurrency),
+	}
+	for _, r := range include {
+		t.include[r] = true
+	}
+	return t
+}
+
+// A treeNode is a node at a particular depth of a tree.
+type treeNode struct {
+	model.CrossplaneResourceTreeNode
+
+	depth int
+}
+
+// traversable returns true if the children of a node at the supplied depth
+// should be traversed.
+func (t *tree) traversable(depth int) bool {
+	return t.maxDepth == nil || depth < *t.maxDepth
+}
+
+// Resolve the tree descending from the supplied root resource. Composite and
+// composed resources are returned first, in depth first order, followed by any
+// included related resources. Errors are added to the GraphQL context, in
+// which case Resolve returns nil.
+func (t *tree) Resolve(ctx context.Context, root model.KubernetesResource) []model.CrossplaneResourceTreeNode {
+	nodes := t.descendants(ctx, root, nil, nil, 0)
+	if len(graphql.GetErrors(ctx)) > 0 {
+		return nil
+	}
+
+	if len(t.include) > 0 {
+		nodes = append(nodes, t.related(ctx, nodes)...)
+		if len(graphql.GetErrors(ctx)) > 0 {
+			return nil
+		}
+	}
+
+	out := make([]model.CrossplaneResourceTreeNode, len(nodes))
+	for i := range nodes {
+		out[i] = nodes[i].CrossplaneResourceTreeNode
+	}
+	return out
+}
+
+// Recursively collect the supplied resource and its composite or composed
+// descendants.
+func (t *tree) descendants(ctx context.Context, res model.KubernetesResource, parentID *model.ReferenceID, rel *model.CrossplaneResourceTreeRelation, depth int) []treeNode {
+	list := []treeNode{{CrossplaneResourceTreeNode: model.CrossplaneResourceTreeNode{ParentID: parentID, Relation: rel, Resource: res}, depth: depth}}
+	if !t.traversable(depth) {
+		return list
+	}
+
+	switch typedRes := res.(type) {
+	case model.CompositeResource:
+		compositeResolver := compositeResourceSpec{clients: t.clients}
+		resources, err := compositeResolver.Resources(ctx, &typedRes.Spec, nil, nil, nil, nil, nil)
+		if err != nil || len(graphql.GetErrors(ctx)) > 0 {
+			return nil
+		}
+
+		composed := model.CrossplaneResourceTreeRelationComposedResource
+		childLists := make([][]treeNode, len(resources.Nodes))
+		t.each(len(resources.Nodes), func(i int) {
+			childLists[i] = t.descendants(ctx, resources.Nodes[i], &typedRes.ID, &composed, depth+1)
+		})
+
+		if len(graphql.GetErrors(ctx)) > 0 {
+			return nil
+		}
+
+		for _, childList := range childLists {
+			list = append(list, childList...)
+		}
+		return list
+	case model.CompositeResourceClaim:
+		claimResolver := compositeResourceClaimSpec{clients: t.clients}
+		composite, err := claimResolver.Resource(ctx, &typedRes.Spec)
+		if err != nil || len(graphql.GetErrors(ctx)) > 0 {
+			return nil
+		}
+
+		if composite == nil {
+			return list
+		}
+
+		xr := model.CrossplaneResourceTreeRelationCompositeResource
+		childList := t.descendants(ctx, *composite, &typedRes.ID, &xr, depth+1)
+		if len(graphql.GetErrors(ctx)) > 0 {
+			return nil
+		}
+
+		return append(list, childList...)
+	default:
+		return list
+	}
+}
+
+// each calls fn for each index up to n, concurrently when the tree's
+// concurrency limit allows and otherwise in the calling goroutine. Falling back
+// to the calling goroutine ensures a traversal never blocks waiting for
+// goroutines that are themselves waiting on their descendants.
+func (t *tree) each(n int, fn func(i int)) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		i := i // So we don't capture the loop variable.
+		select {
+		case t.sem <- struct{}{}:
+			wg.Add(1)
+			go func() {
+				defer func() { <-t.sem }()
+				defer wg.Done()
+				fn(i)
+			}()
+		default:
+			fn(i)
+		}
+	}
+	wg.Wait()
+}
+
+// A relatedRef is a reference from a node of the tree to a related resource.
+type relatedRef struct {
+	parent   *treeNode
+	relation model.CrossplaneResourceTreeRelation
+	id       model.ReferenceID
+}
+
+// related returns nodes for the resources related to the supplied nodes by the
+// tree's included relations. Related resources are not traversed. A resource
+// related to several nodes is returned once, as a child of the first node that
+// relates to it.
+func (t *tree) related(ctx context.Context, nodes []treeNode) []treeNode { //nolint:gocyclo
+	// This isn't _really_ that complex; it's a long but simple switch.
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := t.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil
+	}
+
+	seen := make(map[model.ReferenceID]bool, len(nodes))
+	for i := range nodes {
+		seen[model.GetResourceID(nodes[i].Resource)] = true
+	}
+
+	refs := make([]relatedRef, 0)
+	add := func(n *treeNode, rel model.CrossplaneResourceTreeRelation, id model.ReferenceID) {
+		if !t.include[rel] || id.Name == "" || seen[id] {
+			return
+		}
+		seen[id] = true
+		refs = append(refs, relatedRef{parent: n, relation: rel, id: id})
+	}
+	secret := func(n *treeNode, namespace, name string) {
+		add(n, model.CrossplaneResourceTreeRelationConnectionSecret, model.ReferenceID{APIVersion: "v1", Kind: "Secret", Namespace: namespace, Name: name})
+	}
+
+	// We only need to discover the kinds of provider config if we're going to
+	// include them.
+	var pcVersions map[string]string
+	if t.include[model.CrossplaneResourceTreeRelationProviderConfig] {
+		crds := xunstructured.NewCRDList()
+		if err := c.List(ctx, crds.GetUnstructuredList()); err != nil {
+			graphql.AddError(ctx, errors.Wrap(err, errListCRDs))
+			return nil
+		}
+		pcVersions = providerConfigVersions(crds)
+	}
+
+	for i := range nodes {
+		n := &nodes[i]
+		if !t.traversable(n.depth) {
+			continue
+		}
+
+		switch r := n.Resource.(type) {
+		case model.CompositeResourceClaim:
+			if s := r.Spec.WriteConnectionSecretToReference; s != nil {
+				secret(n, s.Namespace, s.Name)
+			}
+		case model.CompositeResource:
+			if s := r.Spec.WriteConnectionSecretToReference; s != nil {
+				secret(n, s.Namespace, s.Name)
+			}
+			if ref := r.Spec.CompositionReference; ref != nil {
+				add(n, model.CrossplaneResourceTreeRelationComposition, model.ReferenceID{APIVersion: extv1.SchemeGroupVersion.String(), Kind: extv1.CompositionKind, Name: ref.Name})
+			}
+
+			xr := &xunstructured.Composite{Unstructured: kunstructured.Unstructured{Object: r.UnstructuredContent()}}
+			if ref := xr.GetCompositionRevisionReference(); ref != nil {
+				add(n, model.CrossplaneResourceTreeRelationCompositionRevision, model.ReferenceID{APIVersion: extv1.SchemeGroupVersion.String(), Kind: extv1.CompositionRevisionKind, Name: ref.Name})
+			}
+			for _, ref := range xr.GetEnvironmentConfigReferences() {
+				id := model.ReferenceID{APIVersion: ref.APIVersion, Kind: ref.Kind, Name: ref.Name}
+				if id.APIVersion == "" || id.Kind == "" {
+					id.APIVersion, id.Kind = extv1alpha1.SchemeGroupVersion.String(), extv1alpha1.EnvironmentConfigKind
+				}
+				add(n, model.CrossplaneResourceTreeRelationEnvironmentConfig, id)
+			}
+		case model.ManagedResource:
+			if s := r.Spec.WriteConnectionSecretToReference; s != nil {
+				secret(n, s.Namespace, s.Name)
+			}
+			ref := r.Spec.ProviderConfigRef
+			if ref == nil {
+				continue
+			}
+			gv, _ := schema.ParseGroupVersion(r.APIVersion)
+			if g, v, ok := providerConfigGroupVersion(pcVersions, gv.Group); ok {
+				add(n, model.CrossplaneResourceTreeRelationProviderConfig, model.ReferenceID{APIVersion: schema.GroupVersion{Group: g, Version: v}.String(), Kind: "ProviderConfig", Name: ref.Name})
+			}
+		}
+	}
+
+	out := make([]*treeNode, len(refs))
+
+	// Collect all concurrently.
+	var wg sync.WaitGroup
+	for i := range refs {
+		i, ref := i, refs[i] // So we don't capture the loop variable.
+		t.sem <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-t.sem }()
+			defer wg.Done()
+
+			u := &kunstructured.Unstructured{}
+			u.SetAPIVersion(ref.id.APIVersion)
+			u.SetKind(ref.id.Kind)
+			nn := types.NamespacedName{Namespace: ref.id.Namespace, Name: ref.id.Name}
+			if err := c.Get(ctx, nn, u); err != nil {
+				if !apierrors.IsNotFound(err) {
+					graphql.AddError(ctx, errors.Wrap(err, errGetRelated))
+				}
+				return
+			}
+
+			kr, err := model.GetKubernetesResource(u)
+			if err != nil {
+				graphql.AddError(ctx, errors.Wrap(err, errModelRelated))
+				return
+			}
+
+			parentID := model.GetResourceID(ref.parent.Resource)
+			rel := ref.relation
+			out[i] = &treeNode{
+				CrossplaneResourceTreeNode: model.CrossplaneResourceTreeNode{ParentID: &parentID, Relation: &rel, Resource: kr},
+				depth:                      ref.parent.depth + 1,
+			}
+		}()
+	}
+	wg.Wait()
+
+	list := make([]treeNode, 0, len(out))
+	for _, n := range out {
+		if n != nil {
+			list = append(list, *n)
+		}
+	}
+	return list
+}
+
+// parents returns the ID of the parent of each of the supplied nodes, keyed by
+// the node's ID.
+func parents(nodes []model.CrossplaneResourceTreeNode) map[model.ReferenceID]*model.ReferenceID {
+	out := make(map[model.ReferenceID]*model.ReferenceID, len(nodes))
+	for _, n := range nodes {
+		out[model.GetResourceID(n.Resource)] = n.ParentID
+	}
+	return out
+}
+
+// reparent points each of the supplied nodes at its nearest ancestor that is
+// also one of the supplied nodes, using the supplied parents of every node in
+// the unfiltered tree. Nodes with no such ancestor get a nil parent ID.
+func reparent(nodes []model.CrossplaneResourceTreeNode, parents map[model.ReferenceID]*model.ReferenceID) {
+	kept := make(map[model.ReferenceID]bool, len(nodes))
+	for _, n := range nodes {
+		kept[model.GetResourceID(n.Resource)] = true
+	}
+	for i := range nodes {
+		p := nodes[i].ParentID
+
+		// Each step moves one level up the tree, so we never need to take
+		// more steps than there are nodes.
+		for steps := 0; p != nil && !kept[*p] && steps < len(parents); steps++ {
+			p = parents[*p]
+		}
+		if p != nil && !kept[*p] {
+			p = nil
+		}
+		nodes[i].ParentID = p
+	}
+}
+
+// providerConfigVersions returns the version of each group of the supplied
+// CRDs that defines a ProviderConfig, keyed by group.
+func providerConfigVersions(crds *xunstructured.CustomResourceDefinitionList) map[string]string {
+	out := map[string]string{}
+	for i := range crds.Items {
+		crd := &xunstructured.CustomResourceDefinition{Unstructured: crds.Items[i]}
+		if !isProviderConfigCRD(crd) {
+			continue
+		}
+		m := model.GetCustomResourceDefinition(crd)
+		out[m.Spec.Group] = pickCRDVersion(m.Spec.Versions)
+	}
+	return out
+}
+
+// providerConfigGroupVersion returns the group and version of the
+// ProviderConfig used by managed resources of the supplied group. Providers
+// usually define their ProviderConfig in a parent of their managed resources'
+// groups; e.g. s3.aws.upbound.io managed resources use aws.upbound.io
+// ProviderConfigs.
+func providerConfigGroupVersion(versions map[string]string, group string) (string, string, bool) {
+	for g := group; g != ""; {
+		if v, ok := versions[g]; ok {
+			return g, v, true
+		}
+		i := strings.Index(g, ".")
+		if i < 0 {
+			break
+		}
+		g = g[i+1:]
+	}
+	return "", "", false
+}
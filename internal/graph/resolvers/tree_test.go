@@ -0,0 +1,234 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	kextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/model"
+	xunstructured "github.com/upbound/xgql/internal/unstructured"
+)
+
+func TestTreeResolve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// A claim that writes its connection details to a secret.
+	xrc := unstructured.Unstructured{}
+	xrc.SetAPIVersion("example.org/v1")
+	xrc.SetKind("Bucket")
+	xrc.SetNamespace("default")
+	xrc.SetName("coolclaim")
+	_ = fieldpath.Pave(xrc.Object).SetValue("spec.resourceRef", map[string]any{"apiVersion": "example.org/v1", "kind": "XBucket", "name": "coolxr"})
+	_ = fieldpath.Pave(xrc.Object).SetValue("spec.writeConnectionSecretToRef.name", "claim-secret")
+	gxrc, _ := model.GetKubernetesResource(&xrc)
+
+	// A composite resource that composes two managed resources, which use the
+	// same provider config.
+	get := func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		u := obj.(*unstructured.Unstructured)
+		u.SetName(key.Name)
+		u.SetNamespace(key.Namespace)
+
+		switch key.Name {
+		case "coolxr":
+			_ = fieldpath.Pave(u.Object).SetValue("spec.compositionRef.name", "coolcomposition")
+			_ = fieldpath.Pave(u.Object).SetValue("spec.compositionRevisionRef.name", "coolcomposition-abcdef")
+			_ = fieldpath.Pave(u.Object).SetValue("spec.environmentConfigRefs", []any{map[string]any{"name": "coolenv"}})
+			_ = fieldpath.Pave(u.Object).SetValue("spec.writeConnectionSecretToRef", map[string]any{"namespace": "default", "name": "xr-secret"})
+			_ = fieldpath.Pave(u.Object).SetValue("spec.resourceRefs", []any{
+				map[string]any{"apiVersion": "s3.aws.example.org/v1", "kind": "Bucket", "name": "bucket-a"},
+				map[string]any{"apiVersion": "s3.aws.example.org/v1", "kind": "Bucket", "name": "bucket-b"},
+			})
+		case "bucket-a", "bucket-b":
+			_ = fieldpath.Pave(u.Object).SetValue("spec.providerConfigRef.name", "default")
+		case "coolcomposition-abcdef":
+			return kerrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		return nil
+	}
+
+	list := func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		crd := xunstructured.NewCRD()
+		crd.SetName("providerconfigs.aws.example.org")
+		crd.SetSpecGroup("aws.example.org")
+		crd.SetSpecScope(kextv1.ClusterScoped)
+		crd.SetSpecNames(kextv1.CustomResourceDefinitionNames{Kind: "ProviderConfig"})
+		crd.SetSpecVersions([]kextv1.CustomResourceDefinitionVersion{{Name: "v1", Served: true}})
+		obj.(*unstructured.UnstructuredList).Items = []unstructured.Unstructured{*crd.GetUnstructured()}
+		return nil
+	}
+
+	// We summarize each node as "parent -> kind/name (relation)".
+	summarize := func(nodes []model.CrossplaneResourceTreeNode) []string {
+		out := make([]string, len(nodes))
+		for i, n := range nodes {
+			id := model.GetResourceID(n.Resource)
+			parent, rel := "", ""
+			if n.ParentID != nil {
+				parent = n.ParentID.Name
+			}
+			if n.Relation != nil {
+				rel = string(*n.Relation)
+			}
+			out[i] = fmt.Sprintf("%s -> %s/%s (%s)", parent, id.Kind, id.Name, rel)
+		}
+		return out
+	}
+
+	type args struct {
+		maxDepth *int
+		include  []model.CrossplaneResourceTreeRelation
+	}
+	type want struct {
+		nodes []string
+		errs  gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"CompositeAndComposed": {
+			reason: "By default we should only include composite and composed resources.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get}, nil
+			}),
+			want: want{
+				nodes: []string{
+					" -> Bucket/coolclaim ()",
+					"coolclaim -> XBucket/coolxr (COMPOSITE_RESOURCE)",
+					"coolxr -> Bucket/bucket-a (COMPOSED_RESOURCE)",
+					"coolxr -> Bucket/bucket-b (COMPOSED_RESOURCE)",
+				},
+			},
+		},
+		"Related": {
+			reason: "We should include related resources once each, as children of the first resource that relates to them. Missing related resources should be ignored.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get, MockList: list}, nil
+			}),
+			args: args{
+				include: []model.CrossplaneResourceTreeRelation{
+					model.CrossplaneResourceTreeRelationProviderConfig,
+					model.CrossplaneResourceTreeRelationConnectionSecret,
+					model.CrossplaneResourceTreeRelationComposition,
+					model.CrossplaneResourceTreeRelationCompositionRevision,
+					model.CrossplaneResourceTreeRelationEnvironmentConfig,
+				},
+			},
+			want: want{
+				nodes: []string{
+					" -> Bucket/coolclaim ()",
+					"coolclaim -> XBucket/coolxr (COMPOSITE_RESOURCE)",
+					"coolxr -> Bucket/bucket-a (COMPOSED_RESOURCE)",
+					"coolxr -> Bucket/bucket-b (COMPOSED_RESOURCE)",
+					"coolclaim -> Secret/claim-secret (CONNECTION_SECRET)",
+					"coolxr -> Secret/xr-secret (CONNECTION_SECRET)",
+					"coolxr -> Composition/coolcomposition (COMPOSITION)",
+					"coolxr -> EnvironmentConfig/coolenv (ENVIRONMENT_CONFIG)",
+					"bucket-a -> ProviderConfig/default (PROVIDER_CONFIG)",
+				},
+			},
+		},
+		"RelatedWithMaxDepth": {
+			reason: "We should not include resources related to nodes at the maximum depth.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get}, nil
+			}),
+			args: args{
+				maxDepth: ptr.To(1),
+				include:  []model.CrossplaneResourceTreeRelation{model.CrossplaneResourceTreeRelationConnectionSecret},
+			},
+			want: want{
+				nodes: []string{
+					" -> Bucket/coolclaim ()",
+					"coolclaim -> XBucket/coolxr (COMPOSITE_RESOURCE)",
+					"coolclaim -> Secret/claim-secret (CONNECTION_SECRET)",
+				},
+			},
+		},
+		"ListCRDsError": {
+			reason: "If we can't list CRDs to discover provider configs we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: get, MockList: test.NewMockListFn(errBoom)}, nil
+			}),
+			args: args{
+				include: []model.CrossplaneResourceTreeRelation{model.CrossplaneResourceTreeRelationProviderConfig},
+			},
+			want: want{
+				nodes: []string{},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListCRDs)),
+				},
+			},
+		},
+		"GetRelatedError": {
+			reason: "If we can't get a related resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+					if key.Name == "claim-secret" {
+						return errBoom
+					}
+					return get(ctx, key, obj)
+				}}, nil
+			}),
+			args: args{
+				include: []model.CrossplaneResourceTreeRelation{model.CrossplaneResourceTreeRelationConnectionSecret},
+			},
+			want: want{
+				nodes: []string{},
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetRelated)),
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover)
+
+			got := newTree(tc.clients, tc.args.maxDepth, tc.args.include).Resolve(ctx, gxrc)
+			errs := graphql.GetErrors(ctx)
+
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nt.Resolve(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.nodes, summarize(got)); diff != "" {
+				t.Errorf("\n%s\nt.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"sort"
+
+	"github.com/99designs/gqlgen/graphql"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+const (
+	errListUsages         = "cannot list usages"
+	errGetUsageResource   = "cannot get usage resource"
+	errModelUsageResource = "cannot model usage resource"
+)
+
+// usages resolves the Usages that pertain to a resource.
+type usages struct {
+	clients ClientCache
+}
+
+// UsedBy resolves the Usages of the supplied resource, i.e. the Usages that
+// block its deletion.
+func (r *usages) UsedBy(ctx context.Context, ref *corev1.ObjectReference, o []model.OrderBy, pa model.PageArgs) (model.UsageConnection, error) {
+	return r.resolve(ctx, ref, o, pa, func(u *extv1alpha1.Usage) *extv1alpha1.Resource { return &u.Spec.Of })
+}
+
+// Uses resolves the Usages by the supplied resource, i.e. the Usages that
+// block deletion of resources it uses.
+func (r *usages) Uses(ctx context.Context, ref *corev1.ObjectReference, o []model.OrderBy, pa model.PageArgs) (model.UsageConnection, error) {
+	return r.resolve(ctx, ref, o, pa, func(u *extv1alpha1.Usage) *extv1alpha1.Resource { return u.Spec.By })
+}
+
+func (r *usages) resolve(ctx context.Context, ref *corev1.ObjectReference, o []model.OrderBy, pa model.PageArgs, resource func(u *extv1alpha1.Usage) *extv1alpha1.Resource) (model.UsageConnection, error) {
+	if ref == nil {
+		return model.UsageConnection{}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return model.UsageConnection{}, nil
+	}
+
+	in := &extv1alpha1.UsageList{}
+	if err := c.List(ctx, in); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errListUsages))
+		return model.UsageConnection{}, nil
+	}
+
+	out := &model.UsageConnection{
+		Nodes: make([]model.Usage, 0),
+	}
+
+	for i := range in.Items {
+		u := &in.Items[i] // To avoid taking the address of the range var.
+
+		if !references(resource(u), ref) {
+			continue
+		}
+
+		out.Nodes = append(out.Nodes, model.GetUsage(u))
+		out.TotalCount++
+	}
+
+	sort.Stable(out)
+	if err := out.Order(o); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errOrder))
+		return model.UsageConnection{}, nil
+	}
+	if err := out.Paginate(pa); err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errPaginate))
+		return model.UsageConnection{}, nil
+	}
+	return *out, nil
+}
+
+// references returns true if the supplied Usage resource references the
+// supplied object. Crossplane resolves a Usage's resource selector to a
+// resource reference, so we don't attempt to match selectors ourselves. A
+// Usage may reference a different version of the object's kind.
+func references(res *extv1alpha1.Resource, ref *corev1.ObjectReference) bool {
+	if res == nil || res.ResourceRef == nil {
+		return false
+	}
+	if res.ResourceRef.Name != ref.Name {
+		return false
+	}
+	return schema.FromAPIVersionAndKind(res.APIVersion, res.Kind).GroupKind() == schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind).GroupKind()
+}
+
+type usage struct {
+	clients ClientCache
+}
+
+func (r *usage) Events(ctx context.Context, obj *model.Usage, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.EventConnection, error) {
+	e := &events{clients: r.clients}
+	return e.Resolve(ctx, &corev1.ObjectReference{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		UID:        types.UID(obj.Metadata.UID),
+	}, orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *usage) UsedBy(ctx context.Context, obj *model.Usage, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.UsedBy(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+func (r *usage) Uses(ctx context.Context, obj *model.Usage, orderBy []model.OrderBy, first *int, after *string, last *int, before *string) (model.UsageConnection, error) {
+	u := &usages{clients: r.clients}
+	return u.Uses(ctx, model.GetResourceReference(obj), orderBy, model.PageArgs{First: first, After: after, Last: last, Before: before})
+}
+
+type usageResource struct {
+	clients ClientCache
+}
+
+func (r *usageResource) Resource(ctx context.Context, obj *model.UsageResource) (model.KubernetesResource, error) {
+	if obj.ResourceRef == nil || obj.APIVersion == nil || obj.Kind == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	creds, _ := auth.FromContext(ctx)
+	c, err := r.clients.Get(creds)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errGetClient))
+		return nil, nil
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(*obj.APIVersion)
+	u.SetKind(*obj.Kind)
+	if err := c.Get(ctx, types.NamespacedName{Name: obj.ResourceRef.Name}, u); err != nil {
+		if !apierrors.IsNotFound(err) {
+			graphql.AddError(ctx, errors.Wrap(err, errGetUsageResource))
+		}
+		return nil, nil
+	}
+
+	out, err := model.GetKubernetesResource(u)
+	if err != nil {
+		graphql.AddError(ctx, errors.Wrap(err, errModelUsageResource))
+		return nil, nil
+	}
+	return out, nil
+}
@@ -0,0 +1,404 @@
+// Copyright 2024 Upbound Inc
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/errors"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	extv1alpha1 "github.com/crossplane/crossplane/apis/apiextensions/v1alpha1"
+
+	"github.com/upbound/xgql/internal/auth"
+	"github.com/upbound/xgql/internal/clients"
+	"github.com/upbound/xgql/internal/graph/generated"
+	"github.com/upbound/xgql/internal/graph/model"
+)
+
+var (
+	_ generated.UsageResolver         = &usage{}
+	_ generated.UsageResourceResolver = &usageResource{}
+)
+
+func TestUsages(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	bucket := extv1alpha1.Resource{
+		APIVersion:  "example.org/v1",
+		Kind:        "Bucket",
+		ResourceRef: &extv1alpha1.ResourceRef{Name: "coolbucket"},
+	}
+	instance := extv1alpha1.Resource{
+		APIVersion:  "example.org/v1",
+		Kind:        "Instance",
+		ResourceRef: &extv1alpha1.ResourceRef{Name: "coolinstance"},
+	}
+
+	// A usage of the bucket by the instance.
+	byInstance := extv1alpha1.Usage{
+		ObjectMeta: metav1.ObjectMeta{Name: "by-instance"},
+		Spec:       extv1alpha1.UsageSpec{Of: bucket, By: &instance},
+	}
+
+	// A usage of the bucket that references a different version of its kind.
+	otherVersion := extv1alpha1.Usage{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-version"},
+		Spec: extv1alpha1.UsageSpec{
+			Of: extv1alpha1.Resource{
+				APIVersion:  "example.org/v1beta1",
+				Kind:        "Bucket",
+				ResourceRef: &extv1alpha1.ResourceRef{Name: "coolbucket"},
+			},
+			Reason: ptr.To("cool reason"),
+		},
+	}
+
+	// A usage of the bucket that has not yet been resolved to a reference.
+	unresolved := extv1alpha1.Usage{
+		ObjectMeta: metav1.ObjectMeta{Name: "unresolved"},
+		Spec: extv1alpha1.UsageSpec{
+			Of: extv1alpha1.Resource{
+				APIVersion:       "example.org/v1",
+				Kind:             "Bucket",
+				ResourceSelector: &extv1alpha1.ResourceSelector{MatchLabels: map[string]string{"cool": "true"}},
+			},
+			Reason: ptr.To("cool reason"),
+		},
+	}
+
+	// A usage of another bucket.
+	other := extv1alpha1.Usage{
+		ObjectMeta: metav1.ObjectMeta{Name: "other"},
+		Spec: extv1alpha1.UsageSpec{
+			Of: extv1alpha1.Resource{
+				APIVersion:  "example.org/v1",
+				Kind:        "Bucket",
+				ResourceRef: &extv1alpha1.ResourceRef{Name: "otherbucket"},
+			},
+			By: &instance,
+		},
+	}
+
+	list := test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		*obj.(*extv1alpha1.UsageList) = extv1alpha1.UsageList{
+			Items: []extv1alpha1.Usage{unresolved, other, otherVersion, byInstance},
+		}
+		return nil
+	})
+
+	type args struct {
+		ctx  context.Context
+		ref  *corev1.ObjectReference
+		uses bool
+		o    []model.OrderBy
+		pa   model.PageArgs
+	}
+	type want struct {
+		uc   model.UsageConnection
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoReference": {
+			reason: "If there's no reference to resolve usages for we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+			},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ref: &corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"ListUsagesError": {
+			reason: "If we can't list usages we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ref: &corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"},
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errListUsages)),
+				},
+			},
+		},
+		"UsedBy": {
+			reason: "We should return usages of the resource, sorted by ID, including usages of other versions of its kind.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ref: &corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"},
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes: []model.Usage{
+						model.GetUsage(&byInstance),
+						model.GetUsage(&otherVersion),
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"UsedByPage": {
+			reason: "We should return the requested page of usages of the resource.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ref: &corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"},
+				pa:  model.PageArgs{First: ptr.To(1)},
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes: []model.Usage{
+						model.GetUsage(&byInstance),
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"UsedByOrdered": {
+			reason: "We should return usages of the resource in the requested order.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ref: &corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "coolbucket"},
+				o:   []model.OrderBy{{Field: "metadata.name", Direction: ptr.To(model.OrderDirectionDesc)}},
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes: []model.Usage{
+						model.GetUsage(&otherVersion),
+						model.GetUsage(&byInstance),
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+		"Uses": {
+			reason: "We should return usages by the resource, sorted by ID.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{MockList: list}, nil
+			}),
+			args: args{
+				ctx:  graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				ref:  &corev1.ObjectReference{APIVersion: "example.org/v1", Kind: "Instance", Name: "coolinstance"},
+				uses: true,
+			},
+			want: want{
+				uc: model.UsageConnection{
+					Nodes: []model.Usage{
+						model.GetUsage(&byInstance),
+						model.GetUsage(&other),
+					},
+					TotalCount: 2,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			u := &usages{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			resolve := u.UsedBy
+			if tc.args.uses {
+				resolve = u.Uses
+			}
+			got, err := resolve(tc.args.ctx, tc.args.ref, tc.args.o, tc.args.pa)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Resolve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nu.Resolve(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.uc, got, cmpopts.IgnoreFields(model.UsageConnection{}, "Edges", "PageInfo"), cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nu.Resolve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUsageResourceResource(t *testing.T) {
+	errBoom := errors.New("boom")
+	errNotFound := kerrors.NewNotFound(schema.GroupResource{}, "coolbucket")
+
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("example.org/v1")
+	u.SetKind("Bucket")
+	u.SetName("coolbucket")
+	gu, _ := model.GetKubernetesResource(u)
+
+	ur := model.UsageResource{
+		APIVersion:  ptr.To("example.org/v1"),
+		Kind:        ptr.To("Bucket"),
+		ResourceRef: &model.UsageResourceRef{Name: "coolbucket"},
+	}
+
+	type args struct {
+		ctx context.Context
+		obj *model.UsageResource
+	}
+	type want struct {
+		kr   model.KubernetesResource
+		err  error
+		errs gqlerror.List
+	}
+
+	cases := map[string]struct {
+		reason  string
+		clients ClientCache
+		args    args
+		want    want
+	}{
+		"NoOp": {
+			reason: "If the resource hasn't been resolved to a reference we should return early.",
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &model.UsageResource{APIVersion: ptr.To("example.org/v1"), Kind: ptr.To("Bucket")},
+			},
+			want: want{},
+		},
+		"GetClientError": {
+			reason: "If we can't get a client we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{}, errBoom
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &ur,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetClient)),
+				},
+			},
+		},
+		"GetResourceError": {
+			reason: "If we can't get the resource we should add the error to the GraphQL context and return early.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errBoom),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &ur,
+			},
+			want: want{
+				errs: gqlerror.List{
+					gqlerror.Wrap(errors.Wrap(errBoom, errGetUsageResource)),
+				},
+			},
+		},
+		"GetResourceNotFound": {
+			reason: "If the resource is not found we return nil",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(errNotFound),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &ur,
+			},
+			want: want{},
+		},
+		"Success": {
+			reason: "If we can get and model the resource we should return it.",
+			clients: ClientCacheFn(func(_ auth.Credentials, _ ...clients.GetOption) (client.Client, error) {
+				return &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+						*obj.(*unstructured.Unstructured) = *u
+						return nil
+					}),
+				}, nil
+			}),
+			args: args{
+				ctx: graphql.WithResponseContext(context.Background(), graphql.DefaultErrorPresenter, graphql.DefaultRecover),
+				obj: &ur,
+			},
+			want: want{
+				kr: gu,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := &usageResource{clients: tc.clients}
+
+			// Our GraphQL resolvers never return errors. We instead add an
+			// error to the GraphQL context and return early.
+			got, err := r.Resource(tc.args.ctx, tc.args.obj)
+			errs := graphql.GetErrors(tc.args.ctx)
+
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Resource(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.errs, errs, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nr.Resource(...): -want GraphQL errors, +got GraphQL errors:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.kr, got, cmpopts.IgnoreUnexported(model.ObjectMeta{}, fieldpath.Paved{})); diff != "" {
+				t.Errorf("\n%s\nr.Resource(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -82,3 +82,44 @@ func (u *ProviderConfig) GetConditions() []xpv1.Condition {
 	}
 	return conditioned.Conditions
 }
+
+// ProbablyProviderConfigUsage returns true if the supplied *Unstructured is
+// probably a provider config usage. It considers any cluster scoped resource of
+// kind: ProviderConfigUsage to probably be a provider config usage.
+func ProbablyProviderConfigUsage(u *unstructured.Unstructured) bool {
+	return u.GetNamespace() == "" && u.GetKind() == "ProviderConfigUsage"
+}
+
+// A ProviderConfigUsage resource.
+type ProviderConfigUsage struct {
+	unstructured.Unstructured
+}
+
+// GetUnstructured returns the underlying *Unstructured.
+func (u *ProviderConfigUsage) GetUnstructured() *unstructured.Unstructured {
+	return &u.Unstructured
+}
+
+// GetProviderConfigReference of this provider config usage.
+func (u *ProviderConfigUsage) GetProviderConfigReference() xpv1.Reference {
+	out := xpv1.Reference{}
+	_ = fieldpath.Pave(u.Object).GetValueInto("providerConfigRef", &out)
+	return out
+}
+
+// SetProviderConfigReference of this provider config usage.
+func (u *ProviderConfigUsage) SetProviderConfigReference(ref xpv1.Reference) {
+	_ = fieldpath.Pave(u.Object).SetValue("providerConfigRef", ref)
+}
+
+// GetResourceReference of this provider config usage.
+func (u *ProviderConfigUsage) GetResourceReference() xpv1.TypedReference {
+	out := xpv1.TypedReference{}
+	_ = fieldpath.Pave(u.Object).GetValueInto("resourceRef", &out)
+	return out
+}
+
+// SetResourceReference of this provider config usage.
+func (u *ProviderConfigUsage) SetResourceReference(ref xpv1.TypedReference) {
+	_ = fieldpath.Pave(u.Object).SetValue("resourceRef", ref)
+}
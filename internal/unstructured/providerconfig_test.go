@@ -26,7 +26,10 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 )
 
-var _ resource.ProviderConfig = &ProviderConfig{}
+var (
+	_ resource.ProviderConfig      = &ProviderConfig{}
+	_ resource.ProviderConfigUsage = &ProviderConfigUsage{}
+)
 
 func emptyPC() *ProviderConfig {
 	return &ProviderConfig{Unstructured: unstructured.Unstructured{Object: map[string]interface{}{}}}
@@ -204,3 +207,57 @@ func TestProviderConfigConditions(t *testing.T) {
 		})
 	}
 }
+
+func emptyPCU() *ProviderConfigUsage {
+	return &ProviderConfigUsage{Unstructured: unstructured.Unstructured{Object: map[string]interface{}{}}}
+}
+
+func TestProviderConfigUsageProviderConfigReference(t *testing.T) {
+	ref := xpv1.Reference{Name: "cool"}
+	cases := map[string]struct {
+		u    *ProviderConfigUsage
+		set  xpv1.Reference
+		want xpv1.Reference
+	}{
+		"NewRef": {
+			u:    emptyPCU(),
+			set:  ref,
+			want: ref,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.u.SetProviderConfigReference(tc.set)
+			got := tc.u.GetProviderConfigReference()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nu.GetProviderConfigReference(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigUsageResourceReference(t *testing.T) {
+	ref := xpv1.TypedReference{APIVersion: "example.org/v1", Kind: "Bucket", Name: "cool", UID: "no-you-id"}
+	cases := map[string]struct {
+		u    *ProviderConfigUsage
+		set  xpv1.TypedReference
+		want xpv1.TypedReference
+	}{
+		"NewRef": {
+			u:    emptyPCU(),
+			set:  ref,
+			want: ref,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tc.u.SetResourceReference(tc.set)
+			got := tc.u.GetResourceReference()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\nu.GetResourceReference(): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}